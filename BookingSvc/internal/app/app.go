@@ -4,17 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/api/grpc/bookingpb"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/clients/grpc"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/controller"
+	grpcserver "github.com/Quizert/room-reservation-system/BookingSvc/internal/controller/grpc"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/propagation"
 	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
 
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/clients/http/icalfeed"
 	paymentClient "github.com/Quizert/room-reservation-system/BookingSvc/internal/clients/http/paymentsvc"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/clients/kafka"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/config"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/service"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/storage/postgres"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/worker"
 
 	"github.com/Quizert/room-reservation-system/Libs/metrics"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -23,16 +28,43 @@ import (
 	"go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
+	googlegrpc "google.golang.org/grpc"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 )
 
+const (
+	defaultHoldTTL          = 15 * time.Minute
+	defaultReaperInterval   = time.Minute
+	defaultOutboxInterval   = time.Second
+	defaultIdempotencyTTL   = 24 * time.Hour
+	defaultWaitlistTTL      = 2 * time.Hour
+	defaultClaimURL         = "http://localhost:8080/bookings/waitlist/claim?token="
+	defaultWebhookTolerance = 5 * time.Minute
+	outboxBatchSize         = 100
+	defaultReconcileAfter   = 5 * time.Minute
+	defaultReconcileEvery   = time.Minute
+	reconcileBatchSize      = 100
+	defaultICalFeedURL      = "http://localhost:8080/bookings/ical/"
+	defaultICalSyncEvery    = 15 * time.Minute
+	icalFetchTimeout        = 30 * time.Second
+)
+
 type App struct {
 	mainServer     *http.Server
+	grpcServer     *googlegrpc.Server
+	bookingGRPC    *grpcserver.Server
 	metricServer   *http.Server
+	reaper         *worker.Reaper
+	outboxRelay    *worker.OutboxRelay
+	reconciler     *worker.Reconciler
+	icalSyncer     *worker.ICalSyncer
 	dbPool         *pgxpool.Pool
 	tracerProvider *trace.TracerProvider // TracerProvider для управления жизненным циклом
 	log            *zap.Logger
@@ -63,9 +95,33 @@ func NewHotelClient(cfg *config.Config, logger *zap.Logger) (*grpc.HotelSvcClien
 	return grpc.NewHotelClient(cfg.GRPCHotelHost, cfg.GRPCHotelPort)
 }
 
-func NewPaymentClient(cfg *config.Config, logger *zap.Logger) *paymentClient.Client {
-	logger.Info("Initializing Payment service client", zap.String("url", cfg.PaymentSvcURL))
-	return paymentClient.NewPaymentSvcClient(cfg.PaymentSvcURL)
+func NewPaymentClient(cfg *config.Config, logger *zap.Logger) (*paymentClient.Client, error) {
+	refundURL, statusURL, err := paymentURLs(cfg)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("Initializing Payment service client", zap.String("url", cfg.PaymentSvcURL), zap.String("refund url", refundURL), zap.String("status url", statusURL))
+	return paymentClient.NewPaymentSvcClient(cfg.PaymentSvcURL, refundURL, statusURL), nil
+}
+
+// paymentURLs возвращает адреса возврата и статуса платежа. Незаданные адреса выводятся из PAYMENT_SERVICE_URL
+// по маршрутам PaymentSystem: возврат - /refund на том же хосте, статус - /status от адреса оплаты.
+func paymentURLs(cfg *config.Config) (string, string, error) {
+	if cfg.PaymentSvcURL == "" {
+		return "", "", errors.New("payment service url is not set")
+	}
+	base, err := url.Parse(cfg.PaymentSvcURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return "", "", fmt.Errorf("invalid payment service url %q", cfg.PaymentSvcURL)
+	}
+	refundURL, statusURL := cfg.PaymentRefundURL, cfg.PaymentStatusURL
+	if refundURL == "" {
+		refundURL = base.ResolveReference(&url.URL{Path: "/refund"}).String()
+	}
+	if statusURL == "" {
+		statusURL = strings.TrimSuffix(cfg.PaymentSvcURL, "/") + "/status"
+	}
+	return refundURL, statusURL, nil
 }
 
 func InitTracerProvider(serviceName, endpoint string) (*trace.TracerProvider, error) {
@@ -92,6 +148,24 @@ func InitTracerProvider(serviceName, endpoint string) (*trace.TracerProvider, er
 
 	return tp, nil
 }
+
+// parseDuration разбирает длительность из конфига, пустое значение заменяется значением по умолчанию
+func parseDuration(value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	return time.ParseDuration(value)
+}
+
+func (a *App) ListenGRPCServer() error {
+	lis, err := net.Listen("tcp", a.bookingGRPC.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %v", err)
+	}
+
+	return a.grpcServer.Serve(lis)
+}
+
 func (a *App) Init(ctx context.Context) error {
 	logger, err := zap.NewDevelopment()
 	if err != nil {
@@ -118,7 +192,61 @@ func (a *App) Init(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize auth client: %w", err)
 	}
 
-	paymentSvcClient := NewPaymentClient(cfg, a.log)
+	paymentSvcClient, err := NewPaymentClient(cfg, a.log)
+	if err != nil {
+		return fmt.Errorf("failed to initialize payment client: %w", err)
+	}
+
+	holdTTL, err := parseDuration(cfg.HoldTTL, defaultHoldTTL)
+	if err != nil {
+		return fmt.Errorf("error parsing hold ttl: %w", err)
+	}
+	reaperInterval, err := parseDuration(cfg.ReaperInterval, defaultReaperInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing reaper interval: %w", err)
+	}
+	outboxInterval, err := parseDuration(cfg.OutboxInterval, defaultOutboxInterval)
+	if err != nil {
+		return fmt.Errorf("error parsing outbox interval: %w", err)
+	}
+	idempotencyTTL, err := parseDuration(cfg.IdempotencyTTL, defaultIdempotencyTTL)
+	if err != nil {
+		return fmt.Errorf("error parsing idempotency ttl: %w", err)
+	}
+	waitlistOfferTTL, err := parseDuration(cfg.WaitlistOfferTTL, defaultWaitlistTTL)
+	if err != nil {
+		return fmt.Errorf("error parsing waitlist offer ttl: %w", err)
+	}
+	claimURL := cfg.WaitlistClaimURL
+	if claimURL == "" {
+		claimURL = defaultClaimURL
+	}
+	// Без секрета не пройдёт ни один вебхук оплаты
+	if cfg.WebhookSecret == "" {
+		return errors.New("payment webhook secret is not set")
+	}
+	webhookTolerance, err := parseDuration(cfg.WebhookTolerance, defaultWebhookTolerance)
+	if err != nil {
+		return fmt.Errorf("error parsing webhook tolerance: %w", err)
+	}
+	reconcileAfter, err := parseDuration(cfg.ReconcileAfter, defaultReconcileAfter)
+	if err != nil {
+		return fmt.Errorf("error parsing reconcile threshold: %w", err)
+	}
+	reconcileInterval, err := parseDuration(cfg.ReconcilePeriod, defaultReconcileEvery)
+	if err != nil {
+		return fmt.Errorf("error parsing reconcile interval: %w", err)
+	}
+
+	icalSyncInterval, err := parseDuration(cfg.ICalSyncPeriod, defaultICalSyncEvery)
+	if err != nil {
+		return fmt.Errorf("error parsing ical sync interval: %w", err)
+	}
+	icalFeedURL := cfg.ICalFeedURL
+	if icalFeedURL == "" {
+		icalFeedURL = defaultICalFeedURL
+	}
+	icalClient := icalfeed.NewICalFeedClient(icalFetchTimeout)
 
 	dbPool, err := NewDatabasePool(ctx, cfg, a.log)
 	if err != nil {
@@ -134,8 +262,19 @@ func (a *App) Init(ctx context.Context) error {
 	tracer := a.tracerProvider.Tracer("BookingSvc")
 	repo := postgres.NewPostgresRepository(dbPool, tracer)
 	a.dbPool = dbPool
-	mainService := service.NewBookingServiceImpl(repo, kafkaProducer, hotelClient, authClient, paymentSvcClient, tracer, a.log)
+	mainService := service.NewBookingServiceImpl(repo, kafkaProducer, hotelClient, authClient, paymentSvcClient, icalClient, holdTTL, idempotencyTTL, waitlistOfferTTL, claimURL, cfg.WebhookSecret, webhookTolerance, icalFeedURL, tracer, a.log)
+	a.reaper = worker.NewReaper(mainService, reaperInterval, a.log)
+	a.outboxRelay = worker.NewOutboxRelay(mainService, outboxInterval, outboxBatchSize, a.log)
+	a.reconciler = worker.NewReconciler(mainService, reconcileInterval, reconcileAfter, reconcileBatchSize, a.log)
+	a.icalSyncer = worker.NewICalSyncer(mainService, icalSyncInterval, a.log)
 	bookingHandler := controller.NewBookingHandler(mainService, tracer)
+	a.bookingGRPC = grpcserver.NewServer(mainService, ":"+cfg.GRPCPort, controller.JWTSecret, tracer)
+	// Те же интерцепторы трассировки, что и у gRPC-сервера AuthSvc
+	a.grpcServer = googlegrpc.NewServer(
+		googlegrpc.UnaryInterceptor(otelgrpc.UnaryServerInterceptor()),
+		googlegrpc.StreamInterceptor(otelgrpc.StreamServerInterceptor()),
+	)
+	bookingpb.RegisterBookingServiceServer(a.grpcServer, a.bookingGRPC)
 
 	mainRoute := controller.SetupRoutes(bookingHandler)
 	metricRoute := metrics.SetupMetricsRoute()
@@ -178,6 +317,31 @@ func (a *App) Start(ctx context.Context) error {
 		return nil
 	})
 
+	group.Go(func() error {
+		if err := a.ListenGRPCServer(); err != nil {
+			a.log.Error("Error in gRPC server", zap.Error(err))
+			return fmt.Errorf("failed to serve gRPC server: %w", err)
+		}
+		a.log.Info("gRPC server stopped")
+		return nil
+	})
+
+	group.Go(func() error {
+		return a.reaper.Run(groupCtx)
+	})
+
+	group.Go(func() error {
+		return a.outboxRelay.Run(groupCtx)
+	})
+
+	group.Go(func() error {
+		return a.reconciler.Run(groupCtx)
+	})
+
+	group.Go(func() error {
+		return a.icalSyncer.Run(groupCtx)
+	})
+
 	group.Go(func() error {
 		<-groupCtx.Done()
 		return a.Stop(context.Background())
@@ -202,6 +366,10 @@ func (a *App) Stop(ctx context.Context) error {
 		}
 	}
 
+	if a.grpcServer != nil {
+		a.grpcServer.GracefulStop()
+	}
+
 	if a.tracerProvider != nil {
 		if err := a.tracerProvider.Shutdown(ctx); err != nil {
 			a.log.Error("Failed to shutdown tracer provider", zap.Error(err))
@@ -6,6 +6,7 @@ import (
 	"github.com/Quizert/room-reservation-system/AuthSvc/pkj/authpb"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"log"
 )
 
 type AuthSvcClient struct {
@@ -35,6 +36,6 @@ func NewAuthClient(grpcHost, grpcPort string) (*AuthSvcClient, error) {
 func (a *AuthSvcClient) Close() {
 	err := a.conn.Close()
 	if err != nil {
-		fmt.Errorf("could not close connection: %w", err)
+		log.Printf("could not close connection: %v", err)
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"log"
 )
 
 type HotelSvcClient struct {
@@ -21,6 +22,22 @@ func (c *HotelSvcClient) GetRoomsByHotelId(ctx context.Context, req *hotelpb.Get
 	return c.Api.GetRoomsByHotelId(ctx, req)
 }
 
+func (c *HotelSvcClient) GetRoomDetails(ctx context.Context, req *hotelpb.GetRoomDetailsRequest) (*hotelpb.GetRoomDetailsResponse, error) {
+	return c.Api.GetRoomDetails(ctx, req)
+}
+
+func (c *HotelSvcClient) GetPriceQuotes(ctx context.Context, req *hotelpb.GetPriceQuotesRequest) (*hotelpb.GetPriceQuotesResponse, error) {
+	return c.Api.GetPriceQuotes(ctx, req)
+}
+
+func (c *HotelSvcClient) GetPromoCode(ctx context.Context, req *hotelpb.GetPromoCodeRequest) (*hotelpb.GetPromoCodeResponse, error) {
+	return c.Api.GetPromoCode(ctx, req)
+}
+
+func (c *HotelSvcClient) SearchHotels(ctx context.Context, req *hotelpb.SearchHotelsRequest) (*hotelpb.SearchHotelsResponse, error) {
+	return c.Api.SearchHotels(ctx, req)
+}
+
 func NewHotelClient(grpcHost, grpcPort string) (*HotelSvcClient, error) {
 	conn, err := grpc.Dial(
 		fmt.Sprintf("%s:%s", grpcHost, grpcPort),
@@ -38,6 +55,6 @@ func NewHotelClient(grpcHost, grpcPort string) (*HotelSvcClient, error) {
 func (c *HotelSvcClient) Close() {
 	err := c.conn.Close()
 	if err != nil {
-		fmt.Errorf("could not close connection: %w", err)
+		log.Printf("could not close connection: %v", err)
 	}
 }
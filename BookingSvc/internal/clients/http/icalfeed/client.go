@@ -0,0 +1,133 @@
+package icalfeed
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/ical"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+const (
+	// Предельный размер календаря другой площадки
+	maxFeedSize = 5 << 20
+	// Предельное число переходов по редиректам
+	maxFeedRedirects = 5
+)
+
+// Client загружает календари других площадок по HTTP.
+// Адрес календаря задаёт владелец отеля, поэтому соединения с внутренними адресами запрещены:
+// проверяется каждый адрес, с которым открывается соединение, в том числе после редиректа.
+type Client struct {
+	client *http.Client
+}
+
+func NewICalFeedClient(timeout time.Duration) *Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: checkDialAddress}
+	return &Client{
+		client: &http.Client{
+			Timeout: timeout,
+			// Прокси из окружения не используется: иначе проверялся бы адрес прокси, а не календаря
+			Transport: &http.Transport{
+				DialContext:         dialer.DialContext,
+				TLSHandshakeTimeout: timeout,
+				MaxIdleConns:        10,
+				IdleConnTimeout:     90 * time.Second,
+			},
+			CheckRedirect: checkRedirect,
+		},
+	}
+}
+
+// CheckFeedURL проверяет адрес календаря до подписки: схема http или https, а имя хоста
+// разрешается только в публичные адреса. При загрузке адрес проверяется ещё раз.
+func (c *Client) CheckFeedURL(ctx context.Context, feedURL string) error {
+	parsed, err := url.Parse(feedURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Hostname() == "" {
+		return fmt.Errorf("calendar url %q: %w", feedURL, myerror.ErrICalFeedForbidden)
+	}
+	addrs, err := net.DefaultResolver.LookupNetIP(ctx, "ip", parsed.Hostname())
+	if err != nil {
+		return fmt.Errorf("myerror in resolving calendar host: %w: %w", myerror.ErrICalFeedUnavailable, err)
+	}
+	for _, addr := range addrs {
+		if !publicAddress(addr) {
+			return fmt.Errorf("calendar host resolves to %s: %w", addr, myerror.ErrICalFeedForbidden)
+		}
+	}
+	return nil
+}
+
+// FetchEvents загружает и разбирает календарь по адресу feedURL.
+// Ошибки оборачивают myerror.ErrICalFeedForbidden, myerror.ErrICalFeedUnavailable или myerror.ErrICalFeedInvalid.
+func (c *Client) FetchEvents(ctx context.Context, feedURL string) ([]ical.Event, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("myerror in creating request: %w: %w", myerror.ErrICalFeedForbidden, err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("myerror in sending request: %w: %w", myerror.ErrICalFeedUnavailable, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("myerror in ical feed status %s: %w", resp.Status, myerror.ErrICalFeedUnavailable)
+	}
+
+	// Обрезанный календарь потерял бы часть занятых дат, поэтому слишком большой не разбирается
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxFeedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("myerror in reading ical feed: %w: %w", myerror.ErrICalFeedUnavailable, err)
+	}
+	if len(body) > maxFeedSize {
+		return nil, fmt.Errorf("ical feed is larger than %d bytes: %w", maxFeedSize, myerror.ErrICalFeedInvalid)
+	}
+	events, err := ical.Parse(bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("myerror in parsing ical feed: %w: %w", myerror.ErrICalFeedInvalid, err)
+	}
+	return events, nil
+}
+
+// checkDialAddress не даёт открыть соединение с внутренним адресом. Вызывается уже после разрешения имени,
+// поэтому смена DNS-записи между проверкой и загрузкой не помогает обойти запрет.
+func checkDialAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("dial address %q: %w", address, myerror.ErrICalFeedForbidden)
+	}
+	if !publicAddress(addrPort.Addr()) {
+		return fmt.Errorf("dial address %s: %w", addrPort.Addr(), myerror.ErrICalFeedForbidden)
+	}
+	return nil
+}
+
+// checkRedirect разрешает переход только на http и https, остальные адреса проверит checkDialAddress
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxFeedRedirects {
+		return fmt.Errorf("stopped after %d redirects: %w", maxFeedRedirects, myerror.ErrICalFeedUnavailable)
+	}
+	if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+		return fmt.Errorf("redirect to %s: %w", req.URL.Scheme, myerror.ErrICalFeedForbidden)
+	}
+	return nil
+}
+
+// publicAddress - адрес в интернете: не loopback, не частная сеть, не link-local и не multicast
+func publicAddress(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsValid() && !addr.IsUnspecified() && !addr.IsLoopback() && !addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() && !addr.IsLinkLocalMulticast() && !addr.IsInterfaceLocalMulticast() &&
+		!addr.IsMulticast() && !sharedAddressSpace.Contains(addr)
+}
+
+// Адреса операторского NAT (RFC 6598) тоже не принадлежат интернету
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
@@ -0,0 +1,88 @@
+package icalfeed
+
+import (
+	"context"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Календарь другой площадки отдаёт локальный файл, как в ручной проверке импорта
+func TestFetchEvents(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/calendar.ics", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/calendar")
+		http.ServeFile(w, r, "../../../ical/testdata/external.ics")
+	})
+	mux.HandleFunc("/broken.ics", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20261110\r\n"))
+	})
+	mux.HandleFunc("/huge.ics", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("X-PADDING:x\r\n", maxFeedSize/12+1)))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	// Тестовый сервер слушает loopback, поэтому проверка адресов здесь не используется
+	client := &Client{client: server.Client()}
+
+	events, err := client.FetchEvents(context.Background(), server.URL+"/calendar.ics")
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, "a1b2c3@other-platform", events[0].UID)
+
+	_, err = client.FetchEvents(context.Background(), server.URL+"/broken.ics")
+	assert.Error(t, err)
+
+	_, err = client.FetchEvents(context.Background(), server.URL+"/huge.ics")
+	assert.ErrorContains(t, err, "larger than")
+
+	_, err = client.FetchEvents(context.Background(), server.URL+"/missing.ics")
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestFetchEvents_InternalAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request to loopback calendar must not be sent")
+	}))
+	defer server.Close()
+
+	client := NewICalFeedClient(5 * time.Second)
+
+	_, err := client.FetchEvents(context.Background(), server.URL+"/calendar.ics")
+	assert.ErrorIs(t, err, myerror.ErrICalFeedForbidden)
+
+	assert.ErrorIs(t, client.CheckFeedURL(context.Background(), server.URL+"/calendar.ics"), myerror.ErrICalFeedForbidden)
+	assert.ErrorIs(t, client.CheckFeedURL(context.Background(), "http://localhost/calendar.ics"), myerror.ErrICalFeedForbidden)
+	assert.ErrorIs(t, client.CheckFeedURL(context.Background(), "file:///etc/passwd"), myerror.ErrICalFeedForbidden)
+}
+
+func TestPublicAddress(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"100.64.0.1", false},
+		{"0.0.0.0", false},
+		{"::1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"::ffff:127.0.0.1", false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.public, publicAddress(netip.MustParseAddr(tt.addr)), tt.addr)
+	}
+}
@@ -6,20 +6,26 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
 	"log"
 	"net/http"
+	"net/url"
 	"time"
 )
 
 type Client struct {
-	baseUrl string
-	client  *http.Client
+	baseUrl   string
+	refundUrl string
+	statusUrl string
+	client    *http.Client
 }
 
-func NewPaymentSvcClient(baseUrl string) *Client {
+func NewPaymentSvcClient(baseUrl, refundUrl, statusUrl string) *Client {
 	return &Client{
-		baseUrl: baseUrl,
-		client:  &http.Client{Timeout: 5 * time.Minute},
+		baseUrl:   baseUrl,
+		refundUrl: refundUrl,
+		statusUrl: statusUrl,
+		client:    &http.Client{Timeout: 5 * time.Minute},
 	}
 }
 
@@ -45,3 +51,50 @@ func (c *Client) CreatePaymentRequest(ctx context.Context, paymentRequest *model
 	}
 	return nil
 }
+
+func (c *Client) CreateRefundRequest(ctx context.Context, refundRequest *models.RefundRequest) error {
+	jsonRequest, err := json.Marshal(refundRequest)
+	if err != nil {
+		return fmt.Errorf("myerror in marshaling json: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.refundUrl, bytes.NewBuffer(jsonRequest))
+	if err != nil {
+		return fmt.Errorf("myerror in creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("myerror in sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("myerror in payment service refund status: %s", resp.Status)
+	}
+	return nil
+}
+
+// GetPaymentStatus запрашивает статус платежа по заказу, неизвестный заказ - myerror.ErrPaymentNotFound
+func (c *Client) GetPaymentStatus(ctx context.Context, orderID string) (*models.PaymentStatus, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.statusUrl+"?order_id="+url.QueryEscape(orderID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("myerror in creating request: %w", err)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("myerror in sending request: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("payment order %s: %w", orderID, myerror.ErrPaymentNotFound)
+	default:
+		return nil, fmt.Errorf("myerror in payment service status: %s", resp.Status)
+	}
+	var paymentStatus models.PaymentStatus
+	if err = json.NewDecoder(resp.Body).Decode(&paymentStatus); err != nil {
+		return nil, fmt.Errorf("myerror in decoding payment status: %w", err)
+	}
+	return &paymentStatus, nil
+}
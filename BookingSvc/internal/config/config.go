@@ -15,11 +15,26 @@ type Config struct {
 	GRPCAuthHost     string
 	GRPCAuthPort     string
 	HTTPPort         string
+	GRPCPort         string
 	HTTPMetricPort   string
 	KafkaBroker      string
 	KafkaTopicClient string
 	KafkaTopicHotel  string
-	PaymentSvcURL    string
+	PaymentSvcURL    string // Адрес оплаты PaymentSystem, обязателен, например "http://payment:8082/payment"
+	PaymentRefundURL string // Адрес возврата, по умолчанию /refund на хосте PaymentSvcURL
+	PaymentStatusURL string // Адрес статуса платежа, по умолчанию PaymentSvcURL + "/status"
+	HoldTTL          string // Срок ожидания оплаты, например "15m"
+	ReaperInterval   string
+	OutboxInterval   string
+	IdempotencyTTL   string // Сколько повтор запроса с тем же Idempotency-Key возвращает исходный ответ
+	WaitlistOfferTTL string // Сколько действует ссылка на освободившуюся комнату из листа ожидания
+	WaitlistClaimURL string // Адрес, к которому добавляется токен предложения
+	WebhookSecret    string // Общий с PaymentSystem секрет подписи вебхуков
+	WebhookTolerance string // Допустимое расхождение времени подписи вебхука, например "5m"
+	ReconcileAfter   string // Через сколько ожидания оплаты статус платежа сверяется с PaymentSystem
+	ReconcilePeriod  string
+	ICalFeedURL      string // Адрес выгрузки .ics, к которому добавляется токен ссылки
+	ICalSyncPeriod   string // Как часто загружаются календари других площадок
 }
 
 func LoadConfig() (*Config, error) {
@@ -34,10 +49,25 @@ func LoadConfig() (*Config, error) {
 		GRPCAuthHost:     os.Getenv("AUTH_GRPC_HOST"),
 		GRPCAuthPort:     os.Getenv("AUTH_GRPC_PORT"),
 		HTTPPort:         os.Getenv("BOOKING_HTTP_PORT"),
+		GRPCPort:         os.Getenv("BOOKING_GRPC_PORT"),
 		HTTPMetricPort:   os.Getenv("BOOKING_HTTP_METRIC_PORT"),
 		KafkaBroker:      os.Getenv("KAFKA_BROKER"),
 		KafkaTopicClient: os.Getenv("KAFKA_TOPIC_CLIENT"),
 		KafkaTopicHotel:  os.Getenv("KAFKA_TOPIC_HOTEL"),
 		PaymentSvcURL:    os.Getenv("PAYMENT_SERVICE_URL"),
+		PaymentRefundURL: os.Getenv("PAYMENT_SERVICE_REFUND_URL"),
+		PaymentStatusURL: os.Getenv("PAYMENT_SERVICE_STATUS_URL"),
+		HoldTTL:          os.Getenv("BOOKING_HOLD_TTL"),
+		ReaperInterval:   os.Getenv("BOOKING_REAPER_INTERVAL"),
+		OutboxInterval:   os.Getenv("BOOKING_OUTBOX_INTERVAL"),
+		IdempotencyTTL:   os.Getenv("BOOKING_IDEMPOTENCY_TTL"),
+		WaitlistOfferTTL: os.Getenv("BOOKING_WAITLIST_OFFER_TTL"),
+		WaitlistClaimURL: os.Getenv("BOOKING_WAITLIST_CLAIM_URL"),
+		WebhookSecret:    os.Getenv("PAYMENT_WEBHOOK_SECRET"),
+		WebhookTolerance: os.Getenv("BOOKING_WEBHOOK_TOLERANCE"),
+		ReconcileAfter:   os.Getenv("BOOKING_RECONCILE_AFTER"),
+		ReconcilePeriod:  os.Getenv("BOOKING_RECONCILE_INTERVAL"),
+		ICalFeedURL:      os.Getenv("BOOKING_ICAL_FEED_URL"),
+		ICalSyncPeriod:   os.Getenv("BOOKING_ICAL_SYNC_INTERVAL"),
 	}, nil
 }
@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// CreateRoomBlock снимает комнату с продажи на период: ремонт или личное использование владельцем
+func (b *BookingHandler) CreateRoomBlock(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.CreateRoomBlock")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusCreated
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/blocks", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	var blockRequest models.RoomBlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&blockRequest); err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", blockRequest.HotelID),
+		attribute.Int("room_id", blockRequest.RoomID))
+
+	block, err := b.bookingService.CreateRoomBlock(ctx, &blockRequest, userID)
+	if err != nil {
+		span.RecordError(err)
+		status = writeBlockError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(block)
+	span.AddEvent("Room block created successfully")
+}
+
+// DeleteRoomBlock возвращает комнату в продажу
+func (b *BookingHandler) DeleteRoomBlock(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.DeleteRoomBlock")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusNoContent
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/blocks/{id}", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	blockID, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid block id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int64("block_id", blockID))
+
+	if err = b.bookingService.DeleteRoomBlock(ctx, blockID, userID); err != nil {
+		span.RecordError(err)
+		status = writeBlockError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+	span.AddEvent("Room block deleted successfully")
+}
+
+// writeBlockError отвечает на ошибку блокировки комнаты и возвращает HTTP статус ответа
+func writeBlockError(w http.ResponseWriter, err error) int {
+	switch {
+	case errors.Is(err, myerror.ErrInvalidRoomBlock):
+		http.Error(w, "invalid room, period or reason", http.StatusBadRequest)
+		return http.StatusBadRequest
+	case errors.Is(err, myerror.ErrForbiddenAccess):
+		http.Error(w, "forbidden access", http.StatusForbidden)
+		return http.StatusForbidden
+	case errors.Is(err, myerror.ErrHotelNotFound):
+		http.Error(w, "hotel not found", http.StatusNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, myerror.ErrRoomNotFound):
+		http.Error(w, "room not found", http.StatusNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, myerror.ErrRoomBlockNotFound):
+		http.Error(w, myerror.ErrRoomBlockNotFound.Error(), http.StatusNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, myerror.ErrRoomBlockConflict):
+		http.Error(w, myerror.ErrRoomBlockConflict.Error(), http.StatusConflict)
+		return http.StatusConflict
+	case errors.Is(err, myerror.ErrRoomBlockImported):
+		http.Error(w, myerror.ErrRoomBlockImported.Error(), http.StatusConflict)
+		return http.StatusConflict
+	}
+	http.Error(w, "server error", http.StatusInternalServerError)
+	return http.StatusInternalServerError
+}
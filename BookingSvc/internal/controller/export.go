@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ExportHotelBookings выгружает бронирования отеля за период в CSV или NDJSON для бухгалтерии.
+// Строки пишутся в ответ по мере чтения из базы, поэтому ответ начинается только с первой строкой:
+// до неё ошибку ещё можно вернуть статусом, после - соединение обрывается.
+func (b *BookingHandler) ExportHotelBookings(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.ExportHotelBookings")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/export", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	hotelID, err := strconv.Atoi(r.URL.Query().Get("hotel_id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid hotel_id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", hotelID))
+
+	encoder, err := newExportEncoder(w, r.URL.Query().Get("format"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	filter, err := parseBookingFilter(r.URL.Query())
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rows := 0
+	err = b.bookingService.ExportBookingsByHotelID(ctx, hotelID, userID, filter, func(row *models.BookingExportRow) error {
+		rows++
+		if rows == 1 {
+			if err := encoder.Begin(hotelID); err != nil {
+				return err
+			}
+		}
+		return encoder.Encode(row)
+	})
+	if err == nil && rows == 0 {
+		err = encoder.Begin(hotelID)
+	}
+	if err == nil {
+		err = encoder.Flush()
+	}
+	if err != nil {
+		span.RecordError(err)
+		if rows > 0 {
+			// Заголовки уже отправлены: обрыв соединения не даст принять неполную выгрузку за целую
+			status = http.StatusInternalServerError
+			panic(http.ErrAbortHandler)
+		}
+		status = writeExportError(w, err)
+		return
+	}
+	span.SetAttributes(attribute.Int("rows", rows))
+	span.AddEvent("Bookings exported successfully")
+}
+
+// exportEncoder пишет строки выгрузки в ответ. Begin отправляет заголовки ответа перед первой строкой.
+type exportEncoder interface {
+	Begin(hotelID int) error
+	Encode(row *models.BookingExportRow) error
+	Flush() error
+}
+
+func newExportEncoder(w http.ResponseWriter, format string) (exportEncoder, error) {
+	switch format {
+	case "", models.ExportFormatCSV:
+		return &csvExportEncoder{w: w, csv: csv.NewWriter(w)}, nil
+	case models.ExportFormatNDJSON:
+		return &ndjsonExportEncoder{w: w, json: json.NewEncoder(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+type csvExportEncoder struct {
+	w   http.ResponseWriter
+	csv *csv.Writer
+}
+
+func (e *csvExportEncoder) Begin(hotelID int) error {
+	writeExportHeaders(e.w, "text/csv; charset=utf-8", fmt.Sprintf("bookings-hotel-%d.csv", hotelID))
+	return e.csv.Write(models.BookingExportHeader)
+}
+
+func (e *csvExportEncoder) Encode(row *models.BookingExportRow) error {
+	return e.csv.Write(row.CSVRecord())
+}
+
+func (e *csvExportEncoder) Flush() error {
+	e.csv.Flush()
+	return e.csv.Error()
+}
+
+type ndjsonExportEncoder struct {
+	w    http.ResponseWriter
+	json *json.Encoder
+}
+
+func (e *ndjsonExportEncoder) Begin(hotelID int) error {
+	writeExportHeaders(e.w, "application/x-ndjson", fmt.Sprintf("bookings-hotel-%d.ndjson", hotelID))
+	return nil
+}
+
+// Encode пишет объект и перевод строки
+func (e *ndjsonExportEncoder) Encode(row *models.BookingExportRow) error {
+	return e.json.Encode(row)
+}
+
+func (e *ndjsonExportEncoder) Flush() error {
+	return nil
+}
+
+func writeExportHeaders(w http.ResponseWriter, contentType, filename string) {
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	w.WriteHeader(http.StatusOK)
+}
+
+// writeExportError отвечает на ошибку выгрузки до её начала и возвращает HTTP статус ответа
+func writeExportError(w http.ResponseWriter, err error) int {
+	switch {
+	case errors.Is(err, myerror.ErrInvalidBookingFilter):
+		http.Error(w, myerror.ErrInvalidBookingFilter.Error(), http.StatusBadRequest)
+		return http.StatusBadRequest
+	case errors.Is(err, myerror.ErrForbiddenAccess):
+		http.Error(w, "forbidden access", http.StatusForbidden)
+		return http.StatusForbidden
+	case errors.Is(err, myerror.ErrHotelNotFound):
+		http.Error(w, "hotel not found", http.StatusNotFound)
+		return http.StatusNotFound
+	}
+	http.Error(w, "server error", http.StatusInternalServerError)
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// authorize проверяет JWT так же, как HTTP middleware: роль пользователя должна совпадать с hotelier
+func (s *Server) authorize(ctx context.Context, hotelier bool) (*models.User, error) {
+	user, isHotelier, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if isHotelier != hotelier {
+		return nil, status.Error(codes.PermissionDenied, "forbidden access")
+	}
+	return user, nil
+}
+
+// authenticate разбирает JWT из метаданных authorization: Bearer <token> и возвращает пользователя и его роль
+func (s *Server) authenticate(ctx context.Context) (*models.User, bool, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get("authorization")) == 0 {
+		return nil, false, status.Error(codes.Unauthenticated, "authorization missing")
+	}
+	tokenEncoded, found := strings.CutPrefix(md.Get("authorization")[0], "Bearer ")
+	if !found {
+		return nil, false, status.Error(codes.Unauthenticated, "invalid authorization format")
+	}
+	token, err := jwt.Parse(tokenEncoded, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("alg format is wrong %v", token.Header["alg"])
+		}
+		return s.secret, nil
+	})
+	if err != nil {
+		return nil, false, status.Error(codes.Unauthenticated, "invalid auth")
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, false, status.Error(codes.Unauthenticated, "invalid auth")
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, false, status.Error(codes.Unauthenticated, "invalid user id")
+	}
+	isHotelier, _ := claims["is_hotelier"].(bool)
+	username, _ := claims["username"].(string)
+	chatID, _ := claims["chat_id"].(string)
+	return &models.User{UserID: int(userID), Username: username, ChatID: chatID}, isHotelier, nil
+}
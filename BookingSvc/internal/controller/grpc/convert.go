@@ -0,0 +1,141 @@
+package grpc
+
+import (
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/api/grpc/bookingpb"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"time"
+)
+
+// parseTime разбирает дату RFC3339, пустая строка - nil
+func parseTime(name, value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", name, err)
+	}
+	date = date.UTC()
+	return &date, nil
+}
+
+// parseRequiredTime разбирает обязательную дату RFC3339
+func parseRequiredTime(name, value string) (time.Time, error) {
+	date, err := parseTime(name, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if date == nil {
+		return time.Time{}, fmt.Errorf("%s is required", name)
+	}
+	return *date, nil
+}
+
+func formatTime(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.UTC().Format(time.RFC3339)
+}
+
+func toBookingRequest(req *bookingpb.CreateBookingRequest) (*models.BookingRequest, error) {
+	startDate, err := parseRequiredTime("start_date", req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+	endDate, err := parseRequiredTime("end_date", req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BookingRequest{
+		HotelID:        int(req.HotelId),
+		RoomID:         int(req.RoomId),
+		CountOfPeople:  int(req.CountOfPeople),
+		Adults:         int(req.Adults),
+		Children:       int(req.Children),
+		CardNumber:     req.CardNumber,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		PromoCode:      req.PromoCode,
+		IdempotencyKey: req.IdempotencyKey,
+	}, nil
+}
+
+func toBookingFilter(req *bookingpb.ListBookingsRequest) (*models.BookingFilter, error) {
+	filter := &models.BookingFilter{
+		Statuses:   req.Statuses,
+		DateFilter: req.DateFilter,
+		RoomID:     int(req.RoomId),
+		Sort:       req.Sort,
+		Limit:      int(req.Limit),
+	}
+	var err error
+	if filter.From, err = parseTime("from", req.From); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseTime("to", req.To); err != nil {
+		return nil, err
+	}
+	if req.Cursor != "" {
+		if filter.Cursor, err = models.DecodeBookingCursor(req.Cursor); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func toProtoBooking(booking *models.Booking) *bookingpb.Booking {
+	return &bookingpb.Booking{
+		Id:              int32(booking.ID),
+		UserId:          int32(booking.UserID),
+		RoomId:          int32(booking.RoomID),
+		HotelId:         int32(booking.HotelID),
+		Status:          booking.Status,
+		Amount:          int32(booking.Amount),
+		CountOfPeople:   int32(booking.CountOfPeople),
+		HotelName:       booking.HotelName,
+		RoomDescription: booking.RoomDescription,
+		RoomNumber:      int32(booking.RoomNumber),
+		Username:        booking.Username,
+		StartDate:       formatTime(&booking.StartDate),
+		EndDate:         formatTime(&booking.EndDate),
+		CreatedAt:       formatTime(&booking.CreatedAt),
+		HoldExpiresAt:   formatTime(booking.HoldExpiresAt),
+		GroupId:         int32(booking.GroupID),
+		StatusChangedAt: formatTime(booking.StatusChangedAt),
+		Children:        int32(booking.Children),
+	}
+}
+
+func toProtoDetails(details *models.BookingDetails) *bookingpb.BookingDetails {
+	history := make([]*bookingpb.StatusChange, 0, len(details.StatusHistory))
+	for _, change := range details.StatusHistory {
+		history = append(history, &bookingpb.StatusChange{
+			FromStatus: change.From,
+			Status:     change.Status,
+			Actor:      change.Actor,
+			ActorId:    int32(change.ActorID),
+			Reason:     change.Reason,
+			At:         formatTime(&change.At),
+		})
+	}
+	return &bookingpb.BookingDetails{Booking: toProtoBooking(details.Booking), StatusHistory: history}
+}
+
+func toProtoAvailableRoom(room *models.AvailableRoom) *bookingpb.AvailableRoom {
+	availableRoom := &bookingpb.AvailableRoom{
+		Id:               int32(room.ID),
+		RoomTypeId:       int32(room.RoomTypeID),
+		Number:           int32(room.Number),
+		Description:      room.Description,
+		BasePrice:        int32(room.BasePrice),
+		MaxAdults:        int32(room.MaxAdults),
+		MaxChildren:      int32(room.MaxChildren),
+		BedConfiguration: room.BedConfiguration,
+	}
+	if room.Price != nil {
+		availableRoom.TotalPrice = int32(room.Price.Total)
+	}
+	return availableRoom
+}
@@ -0,0 +1,182 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/Quizert/room-reservation-system/BookingSvc/api/grpc/bookingpb"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/controller"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server - gRPC API бронирований поверх того же сервиса, что и HTTP API
+type Server struct {
+	bookingpb.UnimplementedBookingServiceServer
+	bookingSvc controller.BookingService
+	secret     []byte // Секрет проверки JWT
+	trace      trace.Tracer
+	Addr       string
+}
+
+func NewServer(bookingSvc controller.BookingService, addr, secret string, trace trace.Tracer) *Server {
+	return &Server{bookingSvc: bookingSvc, Addr: addr, secret: []byte(secret), trace: trace}
+}
+
+func (s *Server) CreateBooking(ctx context.Context, req *bookingpb.CreateBookingRequest) (*bookingpb.Booking, error) {
+	ctx, span := s.trace.Start(ctx, "GRPC.CreateBooking")
+	defer span.End()
+
+	user, err := s.authorize(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.IdempotencyKey) > controller.MaxIdempotencyKeyLength {
+		return nil, status.Error(codes.InvalidArgument, "invalid idempotency key")
+	}
+	bookingRequest, err := toBookingRequest(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	booking, err := s.bookingSvc.CreateBooking(ctx, bookingRequest, user)
+	if err != nil {
+		span.RecordError(err)
+		return nil, statusError(err)
+	}
+	return toProtoBooking(booking), nil
+}
+
+func (s *Server) GetBooking(ctx context.Context, req *bookingpb.GetBookingRequest) (*bookingpb.BookingDetails, error) {
+	ctx, span := s.trace.Start(ctx, "GRPC.GetBooking")
+	defer span.End()
+	span.SetAttributes(attribute.Int("booking.booking_id", int(req.BookingId)))
+
+	// Бронирование доступно и гостю, и владельцу отеля - права проверяет сервис
+	user, _, err := s.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.bookingSvc.GetBookingByID(ctx, int(req.BookingId), user.UserID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, statusError(err)
+	}
+	return toProtoDetails(details), nil
+}
+
+func (s *Server) ListBookings(ctx context.Context, req *bookingpb.ListBookingsRequest) (*bookingpb.ListBookingsResponse, error) {
+	ctx, span := s.trace.Start(ctx, "GRPC.ListBookings")
+	defer span.End()
+
+	// Бронирования отеля видит только владелец, свои бронирования - гость
+	user, err := s.authorize(ctx, req.HotelId != 0)
+	if err != nil {
+		return nil, err
+	}
+	filter, err := toBookingFilter(req)
+	if err != nil {
+		span.RecordError(err)
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	var page *models.BookingPage
+	if req.HotelId != 0 {
+		page, err = s.bookingSvc.GetBookingsByHotelID(ctx, int(req.HotelId), user.UserID, filter)
+	} else {
+		page, err = s.bookingSvc.GetBookingsByUserID(ctx, user.UserID, filter)
+	}
+	if err != nil {
+		span.RecordError(err)
+		return nil, statusError(err)
+	}
+
+	response := &bookingpb.ListBookingsResponse{
+		Bookings:   make([]*bookingpb.BookingDetails, 0, len(page.Bookings)),
+		NextCursor: page.NextCursor,
+	}
+	for _, details := range page.Bookings {
+		response.Bookings = append(response.Bookings, toProtoDetails(details))
+	}
+	return response, nil
+}
+
+func (s *Server) CancelBooking(ctx context.Context, req *bookingpb.CancelBookingRequest) (*bookingpb.CancelBookingResponse, error) {
+	ctx, span := s.trace.Start(ctx, "GRPC.CancelBooking")
+	defer span.End()
+	span.SetAttributes(attribute.Int("booking.booking_id", int(req.BookingId)))
+
+	user, err := s.authorize(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.bookingSvc.CancelBooking(ctx, int(req.BookingId), user); err != nil {
+		span.RecordError(err)
+		return nil, statusError(err)
+	}
+	return &bookingpb.CancelBookingResponse{}, nil
+}
+
+func (s *Server) GetAvailableRooms(ctx context.Context, req *bookingpb.GetAvailableRoomsRequest) (*bookingpb.GetAvailableRoomsResponse, error) {
+	ctx, span := s.trace.Start(ctx, "GRPC.GetAvailableRooms")
+	defer span.End()
+	span.SetAttributes(attribute.Int("booking.hotel_id", int(req.HotelId)))
+
+	startDate, err := parseRequiredTime("start_date", req.StartDate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	endDate, err := parseRequiredTime("end_date", req.EndDate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// Гости задаются count_of_people или adults и children, по умолчанию один взрослый
+	guests := models.Guests{Adults: 1}
+	if req.CountOfPeople != 0 || req.Adults != 0 || req.Children != 0 {
+		var ok bool
+		guests, ok = models.NewGuests(int(req.CountOfPeople), int(req.Adults), int(req.Children))
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "invalid count_of_people, adults or children")
+		}
+	}
+
+	rooms, err := s.bookingSvc.GetAvailableRooms(ctx, int(req.HotelId), startDate, endDate, guests)
+	if err != nil {
+		span.RecordError(err)
+		return nil, statusError(err)
+	}
+	response := &bookingpb.GetAvailableRoomsResponse{Rooms: make([]*bookingpb.AvailableRoom, 0, len(rooms))}
+	for _, room := range rooms {
+		response.Rooms = append(response.Rooms, toProtoAvailableRoom(room))
+	}
+	return response, nil
+}
+
+// statusError переводит ошибки сервиса в коды gRPC так же, как HTTP API переводит их в статусы
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, myerror.ErrInvalidBookingData),
+		errors.Is(err, myerror.ErrInvalidBookingFilter),
+		errors.Is(err, myerror.ErrRoomNotFound),
+		errors.Is(err, myerror.ErrPromoCodeNotFound):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, myerror.ErrBookingNotFound),
+		errors.Is(err, myerror.ErrHotelNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, myerror.ErrForbiddenAccess):
+		return status.Error(codes.PermissionDenied, myerror.ErrForbiddenAccess.Error())
+	case errors.Is(err, myerror.ErrBookingAlreadyExists),
+		errors.Is(err, myerror.ErrIdempotencyKeyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, myerror.ErrInvalidBookingStatus),
+		errors.Is(err, myerror.ErrRoomCapacityExceeded),
+		errors.Is(err, myerror.ErrRoomDataMismatch),
+		errors.Is(err, myerror.ErrIdempotencyKeyReused),
+		errors.Is(err, myerror.ErrPromoCodeNotApplicable),
+		errors.Is(err, myerror.ErrPromoCodeExhausted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return status.Error(codes.Internal, "internal server error")
+}
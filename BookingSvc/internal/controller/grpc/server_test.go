@@ -0,0 +1,183 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/Quizert/room-reservation-system/BookingSvc/api/grpc/bookingpb"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/mocks"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret"
+
+// authContext - входящий контекст с JWT пользователя, как его передаёт клиент в метаданных
+func authContext(t *testing.T, userID int, isHotelier bool) context.Context {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"user_id":     userID,
+		"is_hotelier": isHotelier,
+		"username":    "testuser",
+		"chat_id":     "testchat",
+	})
+	signed, err := token.SignedString([]byte(testSecret))
+	assert.NoError(t, err)
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+signed))
+}
+
+func TestCreateBooking(t *testing.T) {
+	startDate := time.Date(2030, 5, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2030, 5, 3, 0, 0, 0, 0, time.UTC)
+	validRequest := &bookingpb.CreateBookingRequest{
+		HotelId:       1,
+		RoomId:        2,
+		CountOfPeople: 2,
+		CardNumber:    "4111111111111111",
+		StartDate:     startDate.Format(time.RFC3339),
+		EndDate:       endDate.Format(time.RFC3339),
+	}
+
+	tests := []struct {
+		name         string
+		ctx          func(t *testing.T) context.Context
+		request      *bookingpb.CreateBookingRequest
+		callService  bool
+		serviceErr   error
+		expectedCode codes.Code
+	}{
+		{"created", func(t *testing.T) context.Context { return authContext(t, 1, false) }, validRequest, true, nil, codes.OK},
+		{"no token", func(t *testing.T) context.Context { return context.Background() }, validRequest, false, nil, codes.Unauthenticated},
+		{"hotelier token", func(t *testing.T) context.Context { return authContext(t, 1, true) }, validRequest, false, nil, codes.PermissionDenied},
+		{"invalid dates", func(t *testing.T) context.Context { return authContext(t, 1, false) },
+			&bookingpb.CreateBookingRequest{HotelId: 1, RoomId: 2, StartDate: "tomorrow"}, false, nil, codes.InvalidArgument},
+		{"room taken", func(t *testing.T) context.Context { return authContext(t, 1, false) }, validRequest, true, myerror.ErrBookingAlreadyExists, codes.AlreadyExists},
+		{"too many guests", func(t *testing.T) context.Context { return authContext(t, 1, false) }, validRequest, true, myerror.ErrRoomCapacityExceeded, codes.FailedPrecondition},
+		{"storage failure", func(t *testing.T) context.Context { return authContext(t, 1, false) }, validRequest, true, errors.New("db down"), codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			server := NewServer(mockBookingService, ":0", testSecret, otel.Tracer("test-tracer"))
+
+			if tt.callService {
+				expectedRequest := &models.BookingRequest{
+					HotelID:       1,
+					RoomID:        2,
+					CountOfPeople: 2,
+					CardNumber:    "4111111111111111",
+					StartDate:     startDate,
+					EndDate:       endDate,
+				}
+				expectedUser := &models.User{UserID: 1, Username: "testuser", ChatID: "testchat"}
+				var booking *models.Booking
+				if tt.serviceErr == nil {
+					booking = &models.Booking{ID: 10, UserID: 1, HotelID: 1, RoomID: 2, Status: models.StatusPendingPayment, StartDate: startDate, EndDate: endDate}
+				}
+				mockBookingService.
+					EXPECT().
+					CreateBooking(gomock.Any(), expectedRequest, expectedUser).
+					Return(booking, tt.serviceErr).
+					Times(1)
+			}
+
+			response, err := server.CreateBooking(tt.ctx(t), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, int32(10), response.Id)
+				assert.Equal(t, models.StatusPendingPayment, response.Status)
+				assert.Equal(t, "2030-05-01T00:00:00Z", response.StartDate)
+			}
+		})
+	}
+}
+
+func TestListBookings(t *testing.T) {
+	page := &models.BookingPage{
+		Bookings:   []*models.BookingDetails{models.NewBookingDetails(&models.Booking{ID: 3, HotelID: 7}, nil)},
+		NextCursor: "next",
+	}
+
+	tests := []struct {
+		name         string
+		ctx          func(t *testing.T) context.Context
+		request      *bookingpb.ListBookingsRequest
+		expect       func(service *mocks.MockBookingService)
+		expectedCode codes.Code
+	}{
+		{
+			name:    "guest bookings",
+			ctx:     func(t *testing.T) context.Context { return authContext(t, 1, false) },
+			request: &bookingpb.ListBookingsRequest{Statuses: []string{models.StatusConfirmed}, Limit: 5},
+			expect: func(service *mocks.MockBookingService) {
+				filter := &models.BookingFilter{Statuses: []string{models.StatusConfirmed}, Limit: 5}
+				service.EXPECT().GetBookingsByUserID(gomock.Any(), 1, filter).Return(page, nil).Times(1)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:    "hotel bookings",
+			ctx:     func(t *testing.T) context.Context { return authContext(t, 2, true) },
+			request: &bookingpb.ListBookingsRequest{HotelId: 7},
+			expect: func(service *mocks.MockBookingService) {
+				service.EXPECT().GetBookingsByHotelID(gomock.Any(), 7, 2, &models.BookingFilter{}).Return(page, nil).Times(1)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "hotel bookings for guest",
+			ctx:          func(t *testing.T) context.Context { return authContext(t, 1, false) },
+			request:      &bookingpb.ListBookingsRequest{HotelId: 7},
+			expect:       func(service *mocks.MockBookingService) {},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "invalid from",
+			ctx:          func(t *testing.T) context.Context { return authContext(t, 1, false) },
+			request:      &bookingpb.ListBookingsRequest{From: "yesterday"},
+			expect:       func(service *mocks.MockBookingService) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:    "not hotel owner",
+			ctx:     func(t *testing.T) context.Context { return authContext(t, 2, true) },
+			request: &bookingpb.ListBookingsRequest{HotelId: 7},
+			expect: func(service *mocks.MockBookingService) {
+				service.EXPECT().GetBookingsByHotelID(gomock.Any(), 7, 2, &models.BookingFilter{}).Return(nil, myerror.ErrForbiddenAccess).Times(1)
+			},
+			expectedCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			server := NewServer(mockBookingService, ":0", testSecret, otel.Tracer("test-tracer"))
+			tt.expect(mockBookingService)
+
+			response, err := server.ListBookings(tt.ctx(t), tt.request)
+
+			assert.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Len(t, response.Bookings, 1)
+				assert.Equal(t, int32(3), response.Bookings[0].Booking.Id)
+				assert.Equal(t, "next", response.NextCursor)
+			}
+		})
+	}
+}
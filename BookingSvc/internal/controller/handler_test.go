@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/ical"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/mocks"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
@@ -14,7 +15,9 @@ import (
 	"go.uber.org/zap"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 )
@@ -57,12 +60,12 @@ func TestCreateBookingHandler_Success(t *testing.T) {
 	mockBookingService.
 		EXPECT().
 		CreateBooking(gomock.Any(), gomock.Any(), gomock.Any()).
-		DoAndReturn(func(ctx context.Context, br *models.BookingRequest, user *models.User) error {
+		DoAndReturn(func(ctx context.Context, br *models.BookingRequest, user *models.User) (*models.Booking, error) {
 			// Можно добавить дополнительные проверки аргументов здесь
 			assert.Equal(t, 1, user.UserID)
 			assert.Equal(t, "testuser", user.Username)
 			assert.Equal(t, "testchat", user.ChatID)
-			return nil
+			return br.ToBooking(user), nil
 		}).
 		Times(1)
 
@@ -71,6 +74,98 @@ func TestCreateBookingHandler_Success(t *testing.T) {
 	bookingHandler.CreateBooking(rr, req)
 
 	assert.Equal(t, http.StatusCreated, rr.Code)
+	var booking models.Booking
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&booking))
+	assert.Equal(t, models.StatusPendingPayment, booking.Status)
+}
+
+// TestCreateBookingHandler_IdempotencyKey проверяет, что повтор с тем же ключом получает тот же ответ
+func TestCreateBookingHandler_IdempotencyKey(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	body, err := json.Marshal(models.BookingRequest{
+		RoomID:        1,
+		HotelID:       1,
+		StartDate:     time.Now().Add(24 * time.Hour),
+		EndDate:       time.Now().Add(48 * time.Hour),
+		CountOfPeople: 2,
+		CardNumber:    "4111111111111111",
+	})
+	assert.NoError(t, err)
+
+	created := &models.Booking{ID: 42, UserID: 1, RoomID: 1, HotelID: 1, Status: models.StatusPendingPayment}
+	mockBookingService.
+		EXPECT().
+		CreateBooking(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, br *models.BookingRequest, user *models.User) (*models.Booking, error) {
+			assert.Equal(t, "retry-key", br.IdempotencyKey)
+			return created, nil
+		}).
+		Times(2)
+
+	responses := make([]string, 0, 2)
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/bookings", bytes.NewBuffer(body))
+		req.Header.Set("Idempotency-Key", "retry-key")
+		req = req.WithContext(createContext(req.Context(), 1))
+
+		rr := httptest.NewRecorder()
+		bookingHandler.CreateBooking(rr, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		responses = append(responses, rr.Body.String())
+	}
+	assert.Equal(t, responses[0], responses[1])
+}
+
+// TestCreateBookingHandler_IdempotencyErrors проверяет ответы на неверное использование ключа идемпотентности
+func TestCreateBookingHandler_IdempotencyErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		key            string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"key too long", strings.Repeat("k", 256), nil, http.StatusBadRequest, "invalid idempotency key\n"},
+		{"key in use", "key", myerror.ErrIdempotencyKeyExists, http.StatusConflict, myerror.ErrIdempotencyKeyExists.Error() + "\n"},
+		{"key reused", "key", myerror.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, myerror.ErrIdempotencyKeyReused.Error() + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			if tt.serviceErr != nil {
+				mockBookingService.
+					EXPECT().
+					CreateBooking(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, tt.serviceErr).
+					Times(1)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/bookings", bytes.NewBufferString(`{"room_id": 1}`))
+			req.Header.Set("Idempotency-Key", tt.key)
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.CreateBooking(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedBody, rr.Body.String())
+		})
+	}
 }
 
 // TestCreateBookingHandler_BadRequest проверяет обработку некорректного запроса
@@ -134,7 +229,7 @@ func TestCreateBookingHandler_Conflict(t *testing.T) {
 	mockBookingService.
 		EXPECT().
 		CreateBooking(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(myerror.ErrBookingAlreadyExists).
+		Return(nil, myerror.ErrBookingAlreadyExists).
 		Times(1)
 
 	rr := httptest.NewRecorder()
@@ -145,6 +240,289 @@ func TestCreateBookingHandler_Conflict(t *testing.T) {
 	assert.Equal(t, myerror.ErrBookingAlreadyExists.Error()+"\n", rr.Body.String())
 }
 
+// TestCreateBookingHandler_RoomErrors проверяет ответы, когда данные комнаты не совпадают с HotelSvc
+func TestCreateBookingHandler_RoomErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+		expectedBody   string
+	}{
+		{"invalid data", myerror.ErrInvalidBookingData, http.StatusBadRequest, "invalid booking data\n"},
+		{"room not in hotel", myerror.ErrRoomNotFound, http.StatusBadRequest, "room not found\n"},
+		{"stale price", myerror.ErrRoomDataMismatch, http.StatusConflict, myerror.ErrRoomDataMismatch.Error() + "\n"},
+		{"too many guests", myerror.ErrRoomCapacityExceeded, http.StatusUnprocessableEntity, myerror.ErrRoomCapacityExceeded.Error() + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				CreateBooking(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, tt.serviceErr).
+				Times(1)
+
+			req := httptest.NewRequest(http.MethodPost, "/bookings", bytes.NewBufferString(`{"room_id": 1, "hotel_id": 1, "room_base_price": 1}`))
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.CreateBooking(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+// TestCreateBookingHandler_PromoCodeErrors проверяет коды ответа при неподходящем промокоде
+func TestCreateBookingHandler_PromoCodeErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"unknown code", myerror.ErrPromoCodeNotFound, http.StatusBadRequest},
+		{"outside validity window", myerror.ErrPromoCodeNotApplicable, http.StatusUnprocessableEntity},
+		{"usage limit reached", myerror.ErrPromoCodeExhausted, http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				CreateBooking(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, bookingRequest *models.BookingRequest, _ *models.User) (*models.Booking, error) {
+					assert.Equal(t, "NOV15", bookingRequest.PromoCode)
+					return nil, tt.serviceErr
+				}).
+				Times(1)
+
+			req := httptest.NewRequest(http.MethodPost, "/bookings", bytes.NewBufferString(`{"room_id": 1, "hotel_id": 1, "promo_code": "NOV15"}`))
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.CreateBooking(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+			assert.Equal(t, tt.serviceErr.Error()+"\n", rr.Body.String())
+		})
+	}
+}
+
+// TestCreateGroupBookingHandler_Success проверяет создание группового бронирования
+func TestCreateGroupBookingHandler_Success(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	groupRequest := models.GroupBookingRequest{
+		HotelID: 1,
+		Rooms: []models.GroupRoomRequest{
+			{RoomID: 1, CountOfPeople: 2},
+			{RoomID: 2, CountOfPeople: 3},
+		},
+		CardNumber: "4111111111111111",
+		StartDate:  time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC),
+		EndDate:    time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC),
+	}
+	group := &models.GroupBooking{
+		ID:      7,
+		UserID:  1,
+		HotelID: 1,
+		Amount:  500,
+		Bookings: []*models.Booking{
+			{ID: 10, RoomID: 1, GroupID: 7, Amount: 200, Status: models.StatusPendingPayment},
+			{ID: 11, RoomID: 2, GroupID: 7, Amount: 300, Status: models.StatusPendingPayment},
+		},
+	}
+	mockBookingService.
+		EXPECT().
+		CreateGroupBooking(gomock.Any(), &groupRequest, models.NewUser(1, "testuser", "testchat")).
+		Return(group, nil).
+		Times(1)
+
+	body, err := json.Marshal(groupRequest)
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/bookings/groups", bytes.NewBuffer(body))
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.CreateGroupBooking(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var response models.GroupBooking
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, 7, response.ID)
+	assert.Equal(t, 500, response.Amount)
+	assert.Len(t, response.Bookings, 2)
+}
+
+// TestCreateGroupBookingHandler_Errors проверяет, что занятость любой комнаты отклоняет всю группу
+func TestCreateGroupBookingHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"invalid rooms", myerror.ErrInvalidBookingData, http.StatusBadRequest},
+		{"room not in hotel", myerror.ErrRoomNotFound, http.StatusBadRequest},
+		{"room too small", myerror.ErrRoomCapacityExceeded, http.StatusUnprocessableEntity},
+		{"one room taken", myerror.ErrBookingAlreadyExists, http.StatusConflict},
+		{"storage failure", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				CreateGroupBooking(gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(nil, tt.serviceErr).
+				Times(1)
+
+			req := httptest.NewRequest(http.MethodPost, "/bookings/groups",
+				bytes.NewBufferString(`{"hotel_id": 1, "rooms": [{"room_id": 1, "count_of_people": 2}]}`))
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.CreateGroupBooking(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+// TestJoinWaitlistHandler_Success проверяет постановку гостя в лист ожидания
+func TestJoinWaitlistHandler_Success(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	waitlistRequest := models.WaitlistRequest{
+		HotelID:       1,
+		RoomTypeID:    2,
+		StartDate:     time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC),
+		EndDate:       time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC),
+		CountOfPeople: 2,
+	}
+	entry := waitlistRequest.ToWaitlistEntry(models.NewUser(1, "testuser", "testchat"))
+	entry.ID = 5
+	mockBookingService.
+		EXPECT().
+		JoinWaitlist(gomock.Any(), &waitlistRequest, models.NewUser(1, "testuser", "testchat")).
+		Return(entry, nil).
+		Times(1)
+
+	body, err := json.Marshal(waitlistRequest)
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/bookings/waitlist", bytes.NewBuffer(body))
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.JoinWaitlist(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var response models.WaitlistEntry
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, 5, response.ID)
+	assert.Equal(t, models.WaitlistWaiting, response.Status)
+	assert.NotContains(t, rr.Body.String(), "testchat")
+}
+
+// TestClaimWaitlistOfferHandler_Success проверяет бронирование комнаты по токену из предложения
+func TestClaimWaitlistOfferHandler_Success(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	booking := &models.Booking{ID: 12, RoomID: 3, HotelID: 1, Amount: 200, Status: models.StatusPendingPayment}
+	mockBookingService.
+		EXPECT().
+		ClaimWaitlistOffer(gomock.Any(), "abc123", &models.WaitlistClaimRequest{CardNumber: "4111111111111111"}, models.NewUser(1, "testuser", "testchat")).
+		Return(booking, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodPost, "/bookings/waitlist/claim?token=abc123",
+		bytes.NewBufferString(`{"card_number": "4111111111111111"}`))
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.ClaimWaitlistOffer(rr, req)
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	var response models.Booking
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, 12, response.ID)
+}
+
+// TestClaimWaitlistOfferHandler_Errors проверяет коды ответа для просроченных ссылок и занятых комнат
+func TestClaimWaitlistOfferHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"offer expired", myerror.ErrWaitlistOfferUnavailable, http.StatusGone},
+		{"room taken", myerror.ErrBookingAlreadyExists, http.StatusConflict},
+		{"storage failure", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				ClaimWaitlistOffer(gomock.Any(), "abc123", gomock.Any(), gomock.Any()).
+				Return(nil, tt.serviceErr).
+				Times(1)
+
+			req := httptest.NewRequest(http.MethodPost, "/bookings/waitlist/claim?token=abc123",
+				bytes.NewBufferString(`{"card_number": "4111111111111111"}`))
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.ClaimWaitlistOffer(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
 // TestCreateBookingHandler_InternalServerError проверяет обработку внутренней ошибки сервера
 func TestCreateBookingHandler_InternalServerError(t *testing.T) {
 	tracer := otel.Tracer("test-tracer")
@@ -179,7 +557,7 @@ func TestCreateBookingHandler_InternalServerError(t *testing.T) {
 	mockBookingService.
 		EXPECT().
 		CreateBooking(gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(errors.New("error")).
+		Return(nil, errors.New("error")).
 		Times(1)
 
 	rr := httptest.NewRecorder()
@@ -199,17 +577,20 @@ func TestGetBookingByUserID_Success(t *testing.T) {
 	bookingHandler := NewBookingHandler(mockBookingService, tracer)
 
 	userID := 1
-	bookings := []*models.BookingInfo{
+	bookings := &models.BookingPage{Bookings: models.DetailsList([]*models.Booking{
 		{
+			ID:      7,
 			UserID:  userID,
 			RoomID:  101,
 			HotelID: 1,
+			Status:  models.StatusPendingPayment,
+			Amount:  200,
 		},
-	}
+	}, nil)}
 
 	mockBookingService.
 		EXPECT().
-		GetBookingsByUserID(gomock.Any(), userID).
+		GetBookingsByUserID(gomock.Any(), userID, &models.BookingFilter{}).
 		Return(bookings, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/bookings/users?user_id="+strconv.Itoa(userID), nil)
@@ -220,9 +601,9 @@ func TestGetBookingByUserID_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
-	var result []*models.BookingInfo
+	var result models.BookingPage
 	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&result))
-	assert.Equal(t, bookings, result)
+	assert.Equal(t, bookings, &result)
 }
 
 func TestGetBookingByUserID_InvalidUserID(t *testing.T) {
@@ -273,7 +654,7 @@ func TestGetBookingByUserID_InternalServerError(t *testing.T) {
 
 	mockBookingService.
 		EXPECT().
-		GetBookingsByUserID(gomock.Any(), userID).
+		GetBookingsByUserID(gomock.Any(), userID, &models.BookingFilter{}).
 		Return(nil, errors.New("database error"))
 
 	req := httptest.NewRequest(http.MethodGet, "/bookings/users?user_id="+strconv.Itoa(userID), nil)
@@ -296,17 +677,20 @@ func TestGetBookingByHotelID_Success(t *testing.T) {
 
 	userID := 1
 	hotelID := 1
-	bookings := []*models.BookingInfo{
+	bookings := &models.BookingPage{Bookings: models.DetailsList([]*models.Booking{
 		{
+			ID:      7,
 			UserID:  userID,
 			RoomID:  101,
 			HotelID: hotelID,
+			Status:  models.StatusPendingPayment,
+			Amount:  200,
 		},
-	}
+	}, nil)}
 
 	mockBookingService.
 		EXPECT().
-		GetBookingsByHotelID(gomock.Any(), hotelID, userID).
+		GetBookingsByHotelID(gomock.Any(), hotelID, userID, &models.BookingFilter{}).
 		Return(bookings, nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/bookings/hotels?hotel_id="+strconv.Itoa(hotelID), nil)
@@ -317,9 +701,100 @@ func TestGetBookingByHotelID_Success(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, rr.Code)
 	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
-	var result []*models.BookingInfo
+	var result models.BookingPage
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&result))
+	assert.Equal(t, bookings, &result)
+}
+
+// TestGetBookingByHotelID_Filters проверяет разбор фильтров и курсора следующей страницы
+func TestGetBookingByHotelID_Filters(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	from := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)
+	cursor := &models.BookingCursor{Sort: models.SortStartAsc, At: from, ID: 40}
+	expected := &models.BookingFilter{
+		Statuses:   []string{models.StatusPendingPayment, models.StatusConfirmed},
+		From:       &from,
+		To:         &to,
+		DateFilter: models.DateFilterArrivals,
+		RoomID:     3,
+		Sort:       models.SortStartAsc,
+		Limit:      20,
+		Cursor:     cursor,
+	}
+	mockBookingService.
+		EXPECT().
+		GetBookingsByHotelID(gomock.Any(), 1, 1, expected).
+		Return(&models.BookingPage{Bookings: []*models.BookingDetails{}, NextCursor: "next"}, nil).
+		Times(1)
+
+	query := url.Values{}
+	query.Set("hotel_id", "1")
+	query.Set("status", "pending_payment,confirmed")
+	query.Set("from", from.Format(time.RFC3339))
+	query.Set("to", to.Format(time.RFC3339))
+	query.Set("date_filter", models.DateFilterArrivals)
+	query.Set("room_id", "3")
+	query.Set("sort", models.SortStartAsc)
+	query.Set("limit", "20")
+	query.Set("cursor", cursor.Encode())
+	req := httptest.NewRequest(http.MethodGet, "/bookings/hotels?"+query.Encode(), nil)
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.GetBookingByHotelID(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var result models.BookingPage
 	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&result))
-	assert.Equal(t, bookings, result)
+	assert.Equal(t, "next", result.NextCursor)
+}
+
+// TestGetBookingByHotelID_InvalidFilter проверяет ответ 400 на неверные фильтры
+func TestGetBookingByHotelID_InvalidFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		serviceErr error
+	}{
+		{"bad cursor", "&cursor=%21%21", nil},
+		{"bad from", "&from=tomorrow", nil},
+		{"bad limit", "&limit=ten", nil},
+		{"rejected by service", "&sort=price", myerror.ErrInvalidBookingFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			if tt.serviceErr != nil {
+				mockBookingService.
+					EXPECT().
+					GetBookingsByHotelID(gomock.Any(), 1, 1, gomock.Any()).
+					Return(nil, tt.serviceErr).
+					Times(1)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/bookings/hotels?hotel_id=1"+tt.query, nil)
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.GetBookingByHotelID(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
 }
 
 func TestGetBookingByHotelID_InvalidHotelID(t *testing.T) {
@@ -354,7 +829,7 @@ func TestGetBookingByHotelID_ForbiddenAccess(t *testing.T) {
 
 	mockBookingService.
 		EXPECT().
-		GetBookingsByHotelID(gomock.Any(), 1, 2).
+		GetBookingsByHotelID(gomock.Any(), 1, 2, &models.BookingFilter{}).
 		Return(nil, myerror.ErrForbiddenAccess)
 
 	bookingHandler.GetBookingByHotelID(rr, req)
@@ -377,7 +852,7 @@ func TestGetBookingByHotelID_HotelNotFound(t *testing.T) {
 
 	mockBookingService.
 		EXPECT().
-		GetBookingsByHotelID(gomock.Any(), 1, 1).
+		GetBookingsByHotelID(gomock.Any(), 1, 1, &models.BookingFilter{}).
 		Return(nil, myerror.ErrHotelNotFound)
 
 	bookingHandler.GetBookingByHotelID(rr, req)
@@ -399,7 +874,7 @@ func TestGetBookingByHotelID_InternalServerError(t *testing.T) {
 
 	mockBookingService.
 		EXPECT().
-		GetBookingsByHotelID(gomock.Any(), hotelID, userID).
+		GetBookingsByHotelID(gomock.Any(), hotelID, userID, &models.BookingFilter{}).
 		Return(nil, errors.New("database error"))
 
 	req := httptest.NewRequest(http.MethodGet, "/bookings/hotels?hotel_id="+strconv.Itoa(hotelID), nil)
@@ -412,6 +887,834 @@ func TestGetBookingByHotelID_InternalServerError(t *testing.T) {
 	assert.Equal(t, "server error\n", rr.Body.String())
 }
 
+// TestGetBookingByID_Success проверяет, что в ответе есть статус, сумма и история статусов
+func TestGetBookingByID_Success(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	userID := 1
+	bookingID := 5
+	createdAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	paidAt := createdAt.Add(5 * time.Minute)
+	booking := &models.Booking{
+		ID:              bookingID,
+		UserID:          userID,
+		RoomID:          101,
+		HotelID:         1,
+		HotelName:       "Hotel",
+		RoomNumber:      12,
+		Status:          models.StatusConfirmed,
+		Amount:          300,
+		CreatedAt:       createdAt,
+		StatusChangedAt: &paidAt,
+	}
+	history := []*models.StatusChange{
+		{Status: models.StatusPendingPayment, Actor: models.ActorGuest, ActorID: userID, Reason: models.ReasonBookingCreated, At: createdAt},
+		{From: models.StatusPendingPayment, Status: models.StatusConfirmed, Actor: models.ActorPaymentSystem, Reason: models.ReasonPaymentSucceeded, At: paidAt},
+	}
+
+	mockBookingService.
+		EXPECT().
+		GetBookingByID(gomock.Any(), bookingID, userID).
+		Return(models.NewBookingDetails(booking, history), nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/bookings/"+strconv.Itoa(bookingID), nil)
+	req.SetPathValue("id", strconv.Itoa(bookingID))
+	req = req.WithContext(createContext(req.Context(), userID))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.GetBookingByID(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var result models.BookingDetails
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&result))
+	assert.Equal(t, bookingID, result.ID)
+	assert.Equal(t, models.StatusConfirmed, result.Status)
+	assert.Equal(t, 300, result.Amount)
+	assert.Equal(t, history, result.StatusHistory)
+}
+
+// TestGetBookingByID_Errors проверяет коды ответа для чужих и несуществующих бронирований
+func TestGetBookingByID_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"not found", myerror.ErrBookingNotFound, http.StatusNotFound},
+		{"other user", myerror.ErrForbiddenAccess, http.StatusForbidden},
+		{"storage failure", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				GetBookingByID(gomock.Any(), 5, 1).
+				Return(nil, tt.serviceErr).
+				Times(1)
+
+			req := httptest.NewRequest(http.MethodGet, "/bookings/5", nil)
+			req.SetPathValue("id", "5")
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.GetBookingByID(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestHandlePaymentWebHook(t *testing.T) {
+	tests := []struct {
+		name           string
+		target         string
+		paymentStatus  string
+		callService    bool
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"payment succeeded", "/bookings/payment/response?booking_id=5", models.PaymentSuccess, true, nil, http.StatusOK},
+		{"group payment failed", "/bookings/payment/response?group_id=3", models.PaymentFailed, true, nil, http.StatusOK},
+		{"unknown payment status", "/bookings/payment/response?booking_id=5", "refunded", true, myerror.ErrInvalidPaymentStatus, http.StatusBadRequest},
+		{"invalid signature", "/bookings/payment/response?booking_id=5", models.PaymentSuccess, true, myerror.ErrInvalidWebhook, http.StatusUnauthorized},
+		{"replayed webhook", "/bookings/payment/response?booking_id=5", models.PaymentSuccess, true, myerror.ErrWebhookReplayed, http.StatusConflict},
+		{"booking not found", "/bookings/payment/response?booking_id=5", models.PaymentSuccess, true, myerror.ErrBookingNotFound, http.StatusNotFound},
+		{"storage failure", "/bookings/payment/response?booking_id=5", models.PaymentSuccess, true, errors.New("db down"), http.StatusInternalServerError},
+		{"invalid booking id", "/bookings/payment/response?booking_id=abc", models.PaymentSuccess, false, nil, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			// Метаданные из тела не доверяются: заказ берётся из query-параметров
+			body := `{"status": "` + tt.paymentStatus + `", "meta_data": {"booking_id": 7}}`
+			req := httptest.NewRequest(http.MethodPost, tt.target, bytes.NewBufferString(body))
+			req.Header.Set("X-Payment-Timestamp", "1700000000")
+			req.Header.Set("X-Payment-Signature", "abcdef")
+			rr := httptest.NewRecorder()
+
+			if tt.callService {
+				expected := &models.PaymentWebhook{
+					Status:     tt.paymentStatus,
+					Timestamp:  "1700000000",
+					Signature:  "abcdef",
+					RequestURI: tt.target,
+					Body:       []byte(body),
+				}
+				if strings.Contains(tt.target, "group_id") {
+					expected.GroupID = 3
+				} else {
+					expected.BookingID = 5
+				}
+				mockBookingService.
+					EXPECT().
+					UpdateBookingStatus(gomock.Any(), expected).
+					Return(tt.serviceErr).
+					Times(1)
+			}
+
+			bookingHandler.HandlePaymentWebHook(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+// TestSetupRoutes проверяет, что шаблоны маршрутов не конфликтуют между собой
+func TestSetupRoutes(t *testing.T) {
+	assert.NotPanics(t, func() {
+		SetupRoutes(NewBookingHandler(nil, otel.Tracer("test-tracer")))
+	})
+}
+
+func TestCancelBooking_Success(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	userID := 1
+	bookingID := 5
+
+	mockBookingService.
+		EXPECT().
+		CancelBooking(gomock.Any(), bookingID, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id int, user *models.User) error {
+			assert.Equal(t, userID, user.UserID)
+			assert.Equal(t, "testchat", user.ChatID)
+			return nil
+		})
+
+	req := httptest.NewRequest(http.MethodDelete, "/bookings/"+strconv.Itoa(bookingID), nil)
+	req.SetPathValue("id", strconv.Itoa(bookingID))
+	req = req.WithContext(createContext(req.Context(), userID))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.CancelBooking(rr, req)
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+}
+
+func TestCancelBooking_InvalidBookingID(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	req := httptest.NewRequest(http.MethodDelete, "/bookings/invalid", nil)
+	req.SetPathValue("id", "invalid")
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.CancelBooking(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "Invalid booking id\n", rr.Body.String())
+}
+
+func TestCancelBooking_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"not found", myerror.ErrBookingNotFound, http.StatusNotFound, "booking not found\n"},
+		{"forbidden", myerror.ErrForbiddenAccess, http.StatusForbidden, "forbidden access\n"},
+		{"invalid status", myerror.ErrInvalidBookingStatus, http.StatusConflict, "booking can not be cancelled\n"},
+		{"internal", errors.New("refund error"), http.StatusInternalServerError, "server error\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				CancelBooking(gomock.Any(), 5, gomock.Any()).
+				Return(tt.serviceErr)
+
+			req := httptest.NewRequest(http.MethodDelete, "/bookings/5", nil)
+			req.SetPathValue("id", "5")
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.CancelBooking(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			assert.Equal(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestModifyBooking_Success(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	countOfPeople := 3
+	updated := &models.Booking{ID: 5, UserID: 1, RoomID: 101, HotelID: 1, Status: models.StatusConfirmed, Amount: 300, CountOfPeople: countOfPeople}
+
+	mockBookingService.
+		EXPECT().
+		ModifyBooking(gomock.Any(), 5, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id int, req *models.BookingUpdateRequest, user *models.User) (*models.Booking, error) {
+			assert.Equal(t, countOfPeople, *req.CountOfPeople)
+			assert.Nil(t, req.RoomID)
+			assert.Equal(t, 1, user.UserID)
+			return updated, nil
+		})
+
+	req := httptest.NewRequest(http.MethodPatch, "/bookings/5", bytes.NewBufferString(`{"count_of_people": 3}`))
+	req.SetPathValue("id", "5")
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.ModifyBooking(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	var result models.Booking
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&result))
+	assert.Equal(t, *updated, result)
+}
+
+func TestModifyBooking_SurchargePending(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	// Бронирование возвращается на прежних условиях, новые ждут доплаты
+	booking := &models.Booking{ID: 5, UserID: 1, RoomID: 101, HotelID: 1, Status: models.StatusConfirmed, Amount: 300, CountOfPeople: 2,
+		PendingModification: &models.BookingModification{ID: 9, BookingID: 5, Status: models.ModificationPending, RoomID: 101,
+			CountOfPeople: 4, Amount: 500, Surcharge: 200}}
+	mockBookingService.
+		EXPECT().
+		ModifyBooking(gomock.Any(), 5, gomock.Any(), gomock.Any()).
+		Return(booking, nil)
+
+	req := httptest.NewRequest(http.MethodPatch, "/bookings/5", bytes.NewBufferString(`{"count_of_people": 4, "card_number": "4111"}`))
+	req.SetPathValue("id", "5")
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.ModifyBooking(rr, req)
+
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	var result models.Booking
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&result))
+	assert.Equal(t, models.StatusConfirmed, result.Status)
+	assert.Equal(t, 300, result.Amount)
+	if assert.NotNil(t, result.PendingModification) {
+		assert.Equal(t, 200, result.PendingModification.Surcharge)
+		assert.Equal(t, models.ModificationPending, result.PendingModification.Status)
+	}
+}
+
+func TestModifyBooking_BadRequest(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	req := httptest.NewRequest(http.MethodPatch, "/bookings/5", bytes.NewBufferString(`{"room_id": "invalid"}`))
+	req.SetPathValue("id", "5")
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.ModifyBooking(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+}
+
+func TestModifyBooking_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"invalid data", myerror.ErrInvalidBookingData, http.StatusBadRequest, "invalid booking data\n"},
+		{"room not found", myerror.ErrRoomNotFound, http.StatusBadRequest, "room not found\n"},
+		{"too many guests", myerror.ErrRoomCapacityExceeded, http.StatusUnprocessableEntity, myerror.ErrRoomCapacityExceeded.Error() + "\n"},
+		{"not found", myerror.ErrBookingNotFound, http.StatusNotFound, "booking not found\n"},
+		{"forbidden", myerror.ErrForbiddenAccess, http.StatusForbidden, "forbidden access\n"},
+		{"room busy", myerror.ErrBookingAlreadyExists, http.StatusConflict, "room is not available for new dates\n"},
+		{"invalid status", myerror.ErrInvalidBookingStatus, http.StatusConflict, "booking can not be modified\n"},
+		{"internal", errors.New("payment error"), http.StatusInternalServerError, "server error\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				ModifyBooking(gomock.Any(), 5, gomock.Any(), gomock.Any()).
+				Return(nil, tt.serviceErr)
+
+			req := httptest.NewRequest(http.MethodPatch, "/bookings/5", bytes.NewBufferString(`{"room_id": 102}`))
+			req.SetPathValue("id", "5")
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.ModifyBooking(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			assert.Equal(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+// TestGetAvailableRooms_WithPrice проверяет, что свободные комнаты возвращаются со стоимостью по ночам
+// TestGetOccupancyCalendar_Success проверяет ячейки календаря: бронирование из прошлого месяца,
+// ожидающее оплаты бронирование и свободный день выезда
+func TestMarkNoShow_Success(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		releaseNights  bool
+		expectedStatus string
+	}{
+		{"without body", "", false, models.StatusNoShow},
+		{"release remaining nights", `{"release_remaining_nights": true}`, true, models.StatusNoShow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				MarkNoShow(gomock.Any(), 5, 1, tt.releaseNights).
+				Return(&models.Booking{ID: 5, Status: models.StatusNoShow}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/bookings/hotels/5/no-show", strings.NewReader(tt.body))
+			req.SetPathValue("id", "5")
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.MarkNoShow(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			var booking models.Booking
+			assert.NoError(t, json.NewDecoder(rr.Body).Decode(&booking))
+			assert.Equal(t, tt.expectedStatus, booking.Status)
+		})
+	}
+}
+
+func TestStayOperations_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		operation    string
+		serviceErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"check-in not found", "check-in", myerror.ErrBookingNotFound, http.StatusNotFound, "booking not found\n"},
+		{"check-in forbidden", "check-in", myerror.ErrForbiddenAccess, http.StatusForbidden, "forbidden access\n"},
+		{"check-in too early", "check-in", myerror.ErrInvalidBookingStatus, http.StatusConflict, "guest can not be checked in\n"},
+		{"check-out not checked in", "check-out", myerror.ErrInvalidBookingStatus, http.StatusConflict, "guest can not be checked out\n"},
+		{"check-out internal", "check-out", errors.New("db error"), http.StatusInternalServerError, "server error\n"},
+		{"no-show before arrival", "no-show", myerror.ErrInvalidBookingStatus, http.StatusConflict, "booking can not be marked as no-show\n"},
+		{"no-show forbidden", "no-show", myerror.ErrForbiddenAccess, http.StatusForbidden, "forbidden access\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			handler := map[string]http.HandlerFunc{
+				"check-in":  bookingHandler.CheckInBooking,
+				"check-out": bookingHandler.CheckOutBooking,
+				"no-show":   bookingHandler.MarkNoShow,
+			}[tt.operation]
+			switch tt.operation {
+			case "check-in":
+				mockBookingService.EXPECT().CheckInBooking(gomock.Any(), 5, 1).Return(nil, tt.serviceErr)
+			case "check-out":
+				mockBookingService.EXPECT().CheckOutBooking(gomock.Any(), 5, 1).Return(nil, tt.serviceErr)
+			case "no-show":
+				mockBookingService.EXPECT().MarkNoShow(gomock.Any(), 5, 1, false).Return(nil, tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/bookings/hotels/5/"+tt.operation, nil)
+			req.SetPathValue("id", "5")
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			handler(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			assert.Equal(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestGetArrivalsAndDepartures(t *testing.T) {
+	day := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name               string
+		departures         bool
+		expectedDateFilter string
+		expectedStatus     string
+	}{
+		{"arrivals", false, models.DateFilterArrivals, models.StatusConfirmed},
+		{"departures", true, models.DateFilterDepartures, models.StatusCheckedIn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				GetBookingsByHotelID(gomock.Any(), 10, 1, gomock.Any()).
+				DoAndReturn(func(ctx context.Context, hotelID, userID int, filter *models.BookingFilter) (*models.BookingPage, error) {
+					assert.Equal(t, tt.expectedDateFilter, filter.DateFilter)
+					assert.Equal(t, []string{tt.expectedStatus}, filter.Statuses)
+					assert.Equal(t, day, *filter.From)
+					assert.Equal(t, day.AddDate(0, 0, 1), *filter.To)
+					assert.Equal(t, models.SortStartAsc, filter.Sort)
+					return &models.BookingPage{Bookings: []*models.BookingDetails{}}, nil
+				})
+
+			req := httptest.NewRequest(http.MethodGet, "/bookings/hotels/arrivals?hotel_id=10&date=2026-10-18", nil)
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			if tt.departures {
+				bookingHandler.GetDepartures(rr, req)
+			} else {
+				bookingHandler.GetArrivals(rr, req)
+			}
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+		})
+	}
+}
+
+func TestGetArrivals_InvalidDate(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	req := httptest.NewRequest(http.MethodGet, "/bookings/hotels/arrivals?hotel_id=10&date=18.10.2026", nil)
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.GetArrivals(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "Invalid date, expected YYYY-MM-DD\n", rr.Body.String())
+}
+
+func TestGetOccupancyCalendar_Success(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	month := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
+	calendar := models.NewOccupancyCalendar(1, month, []*models.CalendarRoom{
+		{RoomID: 1, RoomNumber: 101},
+		{RoomID: 2, RoomNumber: 102},
+	})
+	paid := &models.Booking{
+		ID: 10, RoomID: 1, Status: models.StatusConfirmed,
+		StartDate: time.Date(2025, 1, 30, 14, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 2, 2, 12, 0, 0, 0, time.UTC),
+	}
+	held := &models.Booking{
+		ID: 11, RoomID: 2, Status: models.StatusPendingPayment,
+		StartDate: time.Date(2025, 2, 27, 14, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 3, 3, 12, 0, 0, 0, time.UTC),
+	}
+	calendar.Occupy(paid, paid.CalendarState())
+	calendar.Occupy(held, held.CalendarState())
+
+	mockBookingService.
+		EXPECT().
+		GetOccupancyCalendar(gomock.Any(), 1, 1, month).
+		Return(calendar, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet, "/bookings/hotels/calendar?hotel_id=1&month=2025-02", nil)
+	req = req.WithContext(createContext(req.Context(), 1))
+	rr := httptest.NewRecorder()
+
+	bookingHandler.GetOccupancyCalendar(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var result models.OccupancyCalendar
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&result))
+	assert.Equal(t, "2025-02", result.Month)
+	assert.Len(t, result.Rooms, 2)
+
+	first := result.Rooms[0].Days
+	assert.Len(t, first, 28)
+	assert.Equal(t, models.CalendarCell{Date: "2025-02-01", State: models.CellBooked, BookingID: 10}, first[0])
+	assert.Equal(t, models.CalendarCell{Date: "2025-02-02", State: models.CellFree}, first[1])
+
+	second := result.Rooms[1].Days
+	assert.Equal(t, models.CellFree, second[25].State)
+	assert.Equal(t, models.CalendarCell{Date: "2025-02-27", State: models.CellHeld, BookingID: 11}, second[26])
+	assert.Equal(t, models.CellHeld, second[27].State)
+}
+
+// TestGetOccupancyCalendar_Errors проверяет разбор месяца и проверку владельца отеля
+func TestGetOccupancyCalendar_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"bad month", "hotel_id=1&month=2025-13", nil, http.StatusBadRequest},
+		{"bad hotel", "hotel_id=x&month=2025-02", nil, http.StatusBadRequest},
+		{"not owner", "hotel_id=1&month=2025-02", myerror.ErrForbiddenAccess, http.StatusForbidden},
+		{"hotel not found", "hotel_id=1&month=2025-02", myerror.ErrHotelNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			if tt.serviceErr != nil {
+				mockBookingService.
+					EXPECT().
+					GetOccupancyCalendar(gomock.Any(), 1, 1, gomock.Any()).
+					Return(nil, tt.serviceErr).
+					Times(1)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/bookings/hotels/calendar?"+tt.query, nil)
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.GetOccupancyCalendar(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
+func TestGetAvailableRooms_WithPrice(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	startDate := time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC)
+	endDate := time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC)
+	rooms := []*models.AvailableRoom{{
+		ID:        1,
+		HotelID:   1,
+		BasePrice: 100,
+		Price: &models.PriceQuote{
+			Nights: []models.NightPrice{
+				{Date: "2025-01-10", Rate: "weekend", Price: 150, ExtraGuestPrice: 100},
+				{Date: "2025-01-11", Rate: "weekend", Price: 150, ExtraGuestPrice: 100},
+			},
+			Subtotal: 500,
+			Total:    500,
+		},
+	}}
+	mockBookingService.
+		EXPECT().
+		GetAvailableRooms(gomock.Any(), 1, startDate, endDate, models.Guests{Adults: 2}).
+		Return(rooms, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/bookings/hotels/rooms?hotel_id=1&start_date=2025-01-10T14:00:00Z&end_date=2025-01-12T12:00:00Z&count_of_people=2", nil)
+	rr := httptest.NewRecorder()
+
+	bookingHandler.GetAvailableRooms(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response []*models.AvailableRoom
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, rooms, response)
+}
+
+// TestGetAvailableRooms_InvalidCountOfPeople проверяет валидацию количества гостей
+func TestGetAvailableRooms_InvalidCountOfPeople(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/bookings/hotels/rooms?hotel_id=1&start_date=2025-01-10T14:00:00Z&end_date=2025-01-12T12:00:00Z&count_of_people=0", nil)
+	rr := httptest.NewRecorder()
+
+	bookingHandler.GetAvailableRooms(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Equal(t, "Invalid count_of_people\n", rr.Body.String())
+}
+
+// TestGetAvailableRooms_Guests проверяет разбор состава гостей из adults и children
+func TestGetAvailableRooms_Guests(t *testing.T) {
+	tests := []struct {
+		name   string
+		query  string
+		guests models.Guests
+		body   string
+	}{
+		{"default one adult", "", models.Guests{Adults: 1}, ""},
+		{"adults and children", "&adults=2&children=2", models.Guests{Adults: 2, Children: 2}, ""},
+		{"count matches", "&count_of_people=3&adults=2&children=1", models.Guests{Adults: 2, Children: 1}, ""},
+		{"count mismatch", "&count_of_people=4&adults=2&children=1", models.Guests{}, "Invalid adults or children\n"},
+		{"children without adults", "&children=2", models.Guests{}, "Invalid adults or children\n"},
+		{"invalid children", "&adults=2&children=x", models.Guests{}, "Invalid children\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, otel.Tracer("test-tracer"))
+			if tt.body == "" {
+				mockBookingService.EXPECT().
+					GetAvailableRooms(gomock.Any(), 1, gomock.Any(), gomock.Any(), tt.guests).
+					Return([]*models.AvailableRoom{}, nil)
+			}
+
+			req := httptest.NewRequest(http.MethodGet,
+				"/bookings/hotels/rooms?hotel_id=1&start_date=2025-01-10T14:00:00Z&end_date=2025-01-12T12:00:00Z"+tt.query, nil)
+			rr := httptest.NewRecorder()
+			bookingHandler.GetAvailableRooms(rr, req)
+
+			if tt.body == "" {
+				assert.Equal(t, http.StatusOK, rr.Code)
+				return
+			}
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+			assert.Equal(t, tt.body, rr.Body.String())
+		})
+	}
+}
+
+func TestSearchHotels_Success(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	results := []*models.HotelSearchResult{{
+		HotelID:   3,
+		HotelName: "Riverside",
+		City:      "Kazan",
+		Address:   "Baumana 1",
+		CheapestRoom: &models.AvailableRoomType{
+			RoomTypeID:     2,
+			RoomID:         7,
+			RoomNumber:     101,
+			AvailableRooms: 3,
+			Price:          &models.PriceQuote{Subtotal: 300, Total: 300},
+		},
+	}}
+	mockBookingService.
+		EXPECT().
+		SearchHotels(gomock.Any(), &models.HotelSearchRequest{
+			City:      "Kazan",
+			StartDate: time.Date(2025, 1, 10, 14, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, 1, 12, 12, 0, 0, 0, time.UTC),
+			Guests:    2,
+			MaxPrice:  500,
+		}).
+		Return(results, nil).
+		Times(1)
+
+	req := httptest.NewRequest(http.MethodGet,
+		"/bookings/search?city=Kazan&start_date=2025-01-10T14:00:00Z&end_date=2025-01-12T12:00:00Z&guests=2&max_price=500", nil)
+	rr := httptest.NewRecorder()
+
+	bookingHandler.SearchHotels(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response []*models.HotelSearchResult
+	assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+	assert.Equal(t, results, response)
+}
+
+func TestSearchHotels_Errors(t *testing.T) {
+	dates := "start_date=2025-01-10T14:00:00Z&end_date=2025-01-12T12:00:00Z"
+	tests := []struct {
+		name           string
+		query          string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{"bad start date", "city=Kazan&start_date=2025-01-10&end_date=2025-01-12T12:00:00Z", nil, http.StatusBadRequest},
+		{"bad guests", "city=Kazan&guests=0&" + dates, nil, http.StatusBadRequest},
+		{"bad price", "city=Kazan&min_price=-1&" + dates, nil, http.StatusBadRequest},
+		{"invalid search", "city=&" + dates, myerror.ErrInvalidBookingData, http.StatusBadRequest},
+		{"hotel service down", "city=Kazan&" + dates, errors.New("unavailable"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			if tt.serviceErr != nil {
+				mockBookingService.
+					EXPECT().
+					SearchHotels(gomock.Any(), gomock.Any()).
+					Return(nil, tt.serviceErr).
+					Times(1)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/bookings/search?"+tt.query, nil)
+			rr := httptest.NewRecorder()
+
+			bookingHandler.SearchHotels(rr, req)
+
+			assert.Equal(t, tt.expectedStatus, rr.Code)
+		})
+	}
+}
+
 //func TestGetAvailableRooms_Success(t *testing.T) {
 //	tracer := otel.Tracer("test-tracer")
 //	ctrl := gomock.NewController(t)
@@ -532,3 +1835,395 @@ func createContext(ctx context.Context, userID int) context.Context {
 	ctx = context.WithValue(ctx, "chat_id", "testchat")
 	return ctx
 }
+
+func TestExportICalFeed(t *testing.T) {
+	tracer := otel.Tracer("test-tracer")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBookingService := mocks.NewMockBookingService(ctrl)
+	bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+	calendar := &ical.Calendar{
+		Name:  "Hotel 10",
+		Stamp: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		Events: []ical.Event{{
+			UID:    "booking-5@booking-svc",
+			Start:  time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+			End:    time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC),
+			AllDay: true,
+		}},
+	}
+	mockBookingService.EXPECT().ExportICalFeed(gomock.Any(), "secret").Return(calendar, nil)
+	mockBookingService.EXPECT().ExportICalFeed(gomock.Any(), "revoked").Return(nil, myerror.ErrICalFeedNotFound)
+
+	// Ссылка открывается без JWT, расширение .ics необязательно
+	req := httptest.NewRequest(http.MethodGet, "/bookings/ical/secret.ics", nil)
+	req.SetPathValue("token", "secret.ics")
+	rr := httptest.NewRecorder()
+
+	bookingHandler.ExportICalFeed(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "text/calendar; charset=utf-8", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), "UID:booking-5@booking-svc\r\n")
+	assert.Contains(t, rr.Body.String(), "DTEND;VALUE=DATE:20261103\r\n")
+
+	req = httptest.NewRequest(http.MethodGet, "/bookings/ical/revoked", nil)
+	req.SetPathValue("token", "revoked")
+	rr = httptest.NewRecorder()
+
+	bookingHandler.ExportICalFeed(rr, req)
+
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+	assert.Equal(t, "calendar not found\n", rr.Body.String())
+}
+
+func TestCreateICalImport(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"created", nil, http.StatusCreated, ""},
+		{"invalid url", myerror.ErrInvalidICalImport, http.StatusBadRequest, "invalid hotel, room or calendar url\n"},
+		{"forbidden", myerror.ErrForbiddenAccess, http.StatusForbidden, "forbidden access\n"},
+		{"room not found", myerror.ErrRoomNotFound, http.StatusNotFound, "room not found\n"},
+		{"duplicate", myerror.ErrICalImportExists, http.StatusConflict, "room already imports this calendar\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				CreateICalImport(gomock.Any(), gomock.Any(), 1).
+				DoAndReturn(func(ctx context.Context, importRequest *models.ICalImportRequest, userID int) (*models.ICalImport, error) {
+					assert.Equal(t, 10, importRequest.HotelID)
+					assert.Equal(t, 5, importRequest.RoomID)
+					assert.Equal(t, "https://example.com/room.ics", importRequest.URL)
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					return &models.ICalImport{ID: 3, HotelID: 10, RoomID: 5, URL: importRequest.URL}, nil
+				})
+
+			body := `{"hotel_id": 10, "room_id": 5, "url": "https://example.com/room.ics"}`
+			req := httptest.NewRequest(http.MethodPost, "/bookings/hotels/ical/imports", strings.NewReader(body))
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.CreateICalImport(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			if tt.serviceErr != nil {
+				assert.Equal(t, tt.expectedBody, rr.Body.String())
+				return
+			}
+			var icalImport models.ICalImport
+			assert.NoError(t, json.NewDecoder(rr.Body).Decode(&icalImport))
+			assert.Equal(t, 3, icalImport.ID)
+		})
+	}
+}
+
+func TestExportHotelBookings(t *testing.T) {
+	rows := []*models.BookingExportRow{
+		models.NewBookingExportRow(&models.Booking{
+			ID: 7, Username: "=HYPERLINK(1)", RoomNumber: 101, Amount: 9000, Status: models.StatusConfirmed,
+			StartDate: time.Date(2026, 11, 1, 14, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 11, 4, 12, 0, 0, 0, time.UTC),
+			CreatedAt: time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC),
+		}),
+		models.NewBookingExportRow(&models.Booking{
+			ID: 8, Username: "ivan, petrov", RoomNumber: 102, Amount: 3000, Status: models.StatusCheckedOut, GroupID: 2,
+			StartDate: time.Date(2026, 11, 2, 14, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2026, 11, 3, 12, 0, 0, 0, time.UTC),
+			CreatedAt: time.Date(2026, 10, 2, 9, 0, 0, 0, time.UTC),
+		}),
+	}
+
+	tests := []struct {
+		name         string
+		format       string
+		rows         []*models.BookingExportRow
+		expectedType string
+		expectedFile string
+		expectedBody string
+	}{
+		{
+			name:         "csv",
+			format:       "csv",
+			rows:         rows,
+			expectedType: "text/csv; charset=utf-8",
+			expectedFile: "bookings-hotel-10.csv",
+			expectedBody: "booking_id,guest_name,room_number,start_date,end_date,nights,amount,status,payment_reference,created_at\n" +
+				"7,'=HYPERLINK(1),101,2026-11-01T14:00:00Z,2026-11-04T12:00:00Z,3,9000,confirmed,7,2026-10-01T09:00:00Z\n" +
+				"8,\"ivan, petrov\",102,2026-11-02T14:00:00Z,2026-11-03T12:00:00Z,1,3000,checked_out," + models.GroupOrderID(2) + ",2026-10-02T09:00:00Z\n",
+		},
+		{
+			name:         "empty csv has header",
+			format:       "",
+			expectedType: "text/csv; charset=utf-8",
+			expectedFile: "bookings-hotel-10.csv",
+			expectedBody: "booking_id,guest_name,room_number,start_date,end_date,nights,amount,status,payment_reference,created_at\n",
+		},
+		{
+			name:         "ndjson",
+			format:       "ndjson",
+			rows:         rows[1:],
+			expectedType: "application/x-ndjson",
+			expectedFile: "bookings-hotel-10.ndjson",
+			expectedBody: `{"booking_id":8,"guest_name":"ivan, petrov","room_number":102,"start_date":"2026-11-02T14:00:00Z",` +
+				`"end_date":"2026-11-03T12:00:00Z","nights":1,"amount":3000,"status":"checked_out",` +
+				`"payment_reference":"` + models.GroupOrderID(2) + `","created_at":"2026-10-02T09:00:00Z"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				ExportBookingsByHotelID(gomock.Any(), 10, 1, gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, hotelID, userID int, filter *models.BookingFilter, write func(*models.BookingExportRow) error) error {
+					assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), *filter.From)
+					assert.Equal(t, time.Date(2026, 12, 1, 0, 0, 0, 0, time.UTC), *filter.To)
+					for _, row := range tt.rows {
+						if err := write(row); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+
+			query := url.Values{
+				"hotel_id": {"10"},
+				"format":   {tt.format},
+				"from":     {"2026-11-01T00:00:00Z"},
+				"to":       {"2026-12-01T00:00:00Z"},
+			}
+			req := httptest.NewRequest(http.MethodGet, "/bookings/hotels/export?"+query.Encode(), nil)
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.ExportHotelBookings(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.Equal(t, tt.expectedType, rr.Header().Get("Content-Type"))
+			assert.Equal(t, `attachment; filename="`+tt.expectedFile+`"`, rr.Header().Get("Content-Disposition"))
+			assert.Equal(t, tt.expectedBody, rr.Body.String())
+		})
+	}
+}
+
+func TestExportHotelBookings_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		serviceErr   error
+		expectedCode int
+	}{
+		{"unknown format", "hotel_id=10&format=xlsx", nil, http.StatusBadRequest},
+		{"invalid hotel", "hotel_id=abc", nil, http.StatusBadRequest},
+		{"missing period", "hotel_id=10", myerror.ErrInvalidBookingFilter, http.StatusBadRequest},
+		{"forbidden", "hotel_id=10", myerror.ErrForbiddenAccess, http.StatusForbidden},
+		{"hotel not found", "hotel_id=10", myerror.ErrHotelNotFound, http.StatusNotFound},
+		{"storage failure", "hotel_id=10", errors.New("db down"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			if tt.serviceErr != nil {
+				mockBookingService.
+					EXPECT().
+					ExportBookingsByHotelID(gomock.Any(), 10, 1, gomock.Any(), gomock.Any()).
+					Return(tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/bookings/hotels/export?"+tt.query, nil)
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.ExportHotelBookings(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			assert.NotContains(t, rr.Header().Get("Content-Disposition"), "attachment")
+		})
+	}
+}
+
+func TestGetHotelReport(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		serviceErr   error
+		expectedCode int
+	}{
+		{"success", "hotel_id=10&from=2026-01-01&to=2026-04-01&group_by=room_type,month", nil, http.StatusOK},
+		{"invalid date", "hotel_id=10&from=2026-01-01T00:00:00Z&to=2026-04-01", nil, http.StatusBadRequest},
+		{"missing period", "hotel_id=10", nil, http.StatusBadRequest},
+		{"rejected by service", "hotel_id=10&from=2026-01-01&to=2026-04-01&group_by=room_type,month",
+			myerror.ErrInvalidReportRequest, http.StatusBadRequest},
+		{"forbidden", "hotel_id=10&from=2026-01-01&to=2026-04-01&group_by=room_type,month",
+			myerror.ErrForbiddenAccess, http.StatusForbidden},
+		{"hotel not found", "hotel_id=10&from=2026-01-01&to=2026-04-01&group_by=room_type,month",
+			myerror.ErrHotelNotFound, http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			if strings.Contains(tt.query, "group_by") {
+				mockBookingService.
+					EXPECT().
+					GetHotelReport(gomock.Any(), gomock.Any(), 1).
+					DoAndReturn(func(ctx context.Context, req *models.ReportRequest, userID int) (*models.HotelReport, error) {
+						assert.Equal(t, 10, req.HotelID)
+						assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), req.From)
+						assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), req.To)
+						assert.Equal(t, []string{models.ReportGroupRoomType, models.ReportGroupMonth}, req.GroupBy)
+						if tt.serviceErr != nil {
+							return nil, tt.serviceErr
+						}
+						return &models.HotelReport{HotelID: 10, Total: &models.KPIRow{OccupancyPercent: 75.5}}, nil
+					})
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/bookings/hotels/reports?"+tt.query, nil)
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.GetHotelReport(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			if tt.expectedCode == http.StatusOK {
+				var report models.HotelReport
+				assert.NoError(t, json.NewDecoder(rr.Body).Decode(&report))
+				assert.Equal(t, 75.5, report.Total.OccupancyPercent)
+			}
+		})
+	}
+}
+
+func TestCreateRoomBlock(t *testing.T) {
+	tests := []struct {
+		name         string
+		serviceErr   error
+		expectedCode int
+		expectedBody string
+	}{
+		{"created", nil, http.StatusCreated, ""},
+		{"no reason", myerror.ErrInvalidRoomBlock, http.StatusBadRequest, "invalid room, period or reason\n"},
+		{"forbidden", myerror.ErrForbiddenAccess, http.StatusForbidden, "forbidden access\n"},
+		{"room not found", myerror.ErrRoomNotFound, http.StatusNotFound, "room not found\n"},
+		{"room is booked", myerror.ErrRoomBlockConflict, http.StatusConflict, "room is booked for this period\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			mockBookingService.
+				EXPECT().
+				CreateRoomBlock(gomock.Any(), gomock.Any(), 1).
+				DoAndReturn(func(ctx context.Context, blockRequest *models.RoomBlockRequest, userID int) (*models.RoomBlock, error) {
+					assert.Equal(t, 5, blockRequest.RoomID)
+					assert.Equal(t, "roof repair", blockRequest.Reason)
+					if tt.serviceErr != nil {
+						return nil, tt.serviceErr
+					}
+					block := blockRequest.ToRoomBlock()
+					block.ID = 12
+					return block, nil
+				})
+
+			body := `{"hotel_id": 10, "room_id": 5, "start_date": "2026-11-01T00:00:00Z", "end_date": "2026-11-05T00:00:00Z", "reason": "roof repair"}`
+			req := httptest.NewRequest(http.MethodPost, "/bookings/hotels/blocks", strings.NewReader(body))
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.CreateRoomBlock(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+			if tt.serviceErr != nil {
+				assert.Equal(t, tt.expectedBody, rr.Body.String())
+				return
+			}
+			var block models.RoomBlock
+			assert.NoError(t, json.NewDecoder(rr.Body).Decode(&block))
+			assert.Equal(t, int64(12), block.ID)
+			assert.Equal(t, models.BlockSourceMaintenance, block.Source)
+		})
+	}
+}
+
+func TestDeleteRoomBlock(t *testing.T) {
+	tests := []struct {
+		name         string
+		blockID      string
+		serviceErr   error
+		expectedCode int
+	}{
+		{"deleted", "12", nil, http.StatusNoContent},
+		{"invalid id", "abc", nil, http.StatusBadRequest},
+		{"not found", "12", myerror.ErrRoomBlockNotFound, http.StatusNotFound},
+		{"imported block", "12", myerror.ErrRoomBlockImported, http.StatusConflict},
+		{"forbidden", "12", myerror.ErrForbiddenAccess, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracer := otel.Tracer("test-tracer")
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockBookingService := mocks.NewMockBookingService(ctrl)
+			bookingHandler := NewBookingHandler(mockBookingService, tracer)
+
+			if tt.blockID == "12" {
+				mockBookingService.EXPECT().DeleteRoomBlock(gomock.Any(), int64(12), 1).Return(tt.serviceErr)
+			}
+
+			req := httptest.NewRequest(http.MethodDelete, "/bookings/hotels/blocks/"+tt.blockID, nil)
+			req.SetPathValue("id", tt.blockID)
+			req = req.WithContext(createContext(req.Context(), 1))
+			rr := httptest.NewRecorder()
+
+			bookingHandler.DeleteRoomBlock(rr, req)
+
+			assert.Equal(t, tt.expectedCode, rr.Code)
+		})
+	}
+}
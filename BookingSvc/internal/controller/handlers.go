@@ -5,25 +5,59 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/ical"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
-	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
 	"github.com/Quizert/room-reservation-system/Libs/metrics"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
+	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
+// MaxIdempotencyKeyLength - максимальная длина ключа идемпотентности из заголовка Idempotency-Key или запроса gRPC
+const MaxIdempotencyKeyLength = 255
+
+// Заголовки подписи вебхука PaymentSystem и предельный размер его тела
+const (
+	headerWebhookTimestamp = "X-Payment-Timestamp"
+	headerWebhookSignature = "X-Payment-Signature"
+	maxWebhookBodySize     = 64 << 10
+)
+
 //go:generate mockgen -source=handlers.go -destination=../mocks/service_mock.go -package=mocks
 type BookingService interface {
-	CreateBooking(ctx context.Context, bookingRequest *models.BookingRequest, user *models.User) error
-	GetBookingsByUserID(ctx context.Context, userID int) ([]*models.BookingInfo, error)
-	GetBookingsByHotelID(ctx context.Context, hotelID, userID int) ([]*models.BookingInfo, error)
-	GetAvailableRooms(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*hotelpb.Room, error)
-	UpdateBookingStatus(ctx context.Context, status string, bookingMessage *models.BookingMessage) error
+	CreateBooking(ctx context.Context, bookingRequest *models.BookingRequest, user *models.User) (*models.Booking, error)
+	CreateGroupBooking(ctx context.Context, groupRequest *models.GroupBookingRequest, user *models.User) (*models.GroupBooking, error)
+	GetBookingByID(ctx context.Context, bookingID, userID int) (*models.BookingDetails, error)
+	GetBookingsByUserID(ctx context.Context, userID int, filter *models.BookingFilter) (*models.BookingPage, error)
+	GetBookingsByHotelID(ctx context.Context, hotelID, userID int, filter *models.BookingFilter) (*models.BookingPage, error)
+	ExportBookingsByHotelID(ctx context.Context, hotelID, userID int, filter *models.BookingFilter, write func(*models.BookingExportRow) error) error
+	GetHotelReport(ctx context.Context, req *models.ReportRequest, userID int) (*models.HotelReport, error)
+	CreateRoomBlock(ctx context.Context, blockRequest *models.RoomBlockRequest, userID int) (*models.RoomBlock, error)
+	DeleteRoomBlock(ctx context.Context, blockID int64, userID int) error
+	GetOccupancyCalendar(ctx context.Context, hotelID, userID int, month time.Time) (*models.OccupancyCalendar, error)
+	GetAvailableRooms(ctx context.Context, hotelID int, startDate, endDate time.Time, guests models.Guests) ([]*models.AvailableRoom, error)
+	SearchHotels(ctx context.Context, searchRequest *models.HotelSearchRequest) ([]*models.HotelSearchResult, error)
+	UpdateBookingStatus(ctx context.Context, webhook *models.PaymentWebhook) error
+	CancelBooking(ctx context.Context, bookingID int, user *models.User) error
+	CheckInBooking(ctx context.Context, bookingID, userID int) (*models.Booking, error)
+	CheckOutBooking(ctx context.Context, bookingID, userID int) (*models.Booking, error)
+	MarkNoShow(ctx context.Context, bookingID, userID int, releaseRemainingNights bool) (*models.Booking, error)
+	ModifyBooking(ctx context.Context, bookingID int, updateRequest *models.BookingUpdateRequest, user *models.User) (*models.Booking, error)
+	JoinWaitlist(ctx context.Context, waitlistRequest *models.WaitlistRequest, user *models.User) (*models.WaitlistEntry, error)
+	ClaimWaitlistOffer(ctx context.Context, token string, claimRequest *models.WaitlistClaimRequest, user *models.User) (*models.Booking, error)
+	CreateICalFeed(ctx context.Context, feedRequest *models.ICalFeedRequest, userID int) (*models.ICalFeed, error)
+	RevokeICalFeed(ctx context.Context, token string, userID int) error
+	ExportICalFeed(ctx context.Context, token string) (*ical.Calendar, error)
+	CreateICalImport(ctx context.Context, importRequest *models.ICalImportRequest, userID int) (*models.ICalImport, error)
+	GetICalImports(ctx context.Context, hotelID, userID int) ([]*models.ICalImport, error)
+	DeleteICalImport(ctx context.Context, importID, userID int) error
 }
 
 type BookingHandler struct {
@@ -70,22 +104,392 @@ func (b *BookingHandler) CreateBooking(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := b.bookingService.CreateBooking(ctx, &bookingRequest, user); err != nil {
+	bookingRequest.IdempotencyKey = r.Header.Get("Idempotency-Key")
+	if len(bookingRequest.IdempotencyKey) > MaxIdempotencyKeyLength {
+		status = http.StatusBadRequest
+		http.Error(w, "invalid idempotency key", http.StatusBadRequest)
+		return
+	}
+
+	booking, err := b.bookingService.CreateBooking(ctx, &bookingRequest, user)
+	if err != nil {
 		span.RecordError(err)
-		if errors.Is(err, myerror.ErrBookingAlreadyExists) {
+		switch {
+		case errors.Is(err, myerror.ErrInvalidBookingData):
+			status = http.StatusBadRequest
+			http.Error(w, "invalid booking data", http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrRoomNotFound):
+			status = http.StatusBadRequest
+			http.Error(w, "room not found", http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrRoomCapacityExceeded):
+			status = http.StatusUnprocessableEntity
+			http.Error(w, myerror.ErrRoomCapacityExceeded.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, myerror.ErrRoomDataMismatch):
+			status = http.StatusConflict
+			http.Error(w, myerror.ErrRoomDataMismatch.Error(), http.StatusConflict)
+		case errors.Is(err, myerror.ErrBookingAlreadyExists):
 			status = http.StatusConflict
 			http.Error(w, myerror.ErrBookingAlreadyExists.Error(), http.StatusConflict)
-			return
+		case errors.Is(err, myerror.ErrIdempotencyKeyExists):
+			status = http.StatusConflict
+			http.Error(w, myerror.ErrIdempotencyKeyExists.Error(), http.StatusConflict)
+		case errors.Is(err, myerror.ErrIdempotencyKeyReused):
+			status = http.StatusUnprocessableEntity
+			http.Error(w, myerror.ErrIdempotencyKeyReused.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, myerror.ErrPromoCodeNotFound):
+			status = http.StatusBadRequest
+			http.Error(w, myerror.ErrPromoCodeNotFound.Error(), http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrPromoCodeNotApplicable):
+			status = http.StatusUnprocessableEntity
+			http.Error(w, myerror.ErrPromoCodeNotApplicable.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, myerror.ErrPromoCodeExhausted):
+			status = http.StatusConflict
+			http.Error(w, myerror.ErrPromoCodeExhausted.Error(), http.StatusConflict)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
 		}
-		status = http.StatusInternalServerError
-		http.Error(w, "server error", http.StatusInternalServerError)
 		return
 	}
+	// Повтор с тем же Idempotency-Key получает тот же ответ
 	status = http.StatusCreated
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(booking)
 	span.AddEvent("Booking created successfully")
 }
 
+// CreateGroupBooking бронирует несколько комнат одного отеля одним запросом и одним платежом
+func (b *BookingHandler) CreateGroupBooking(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.CreateGroupBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/groups", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int)
+	username := ctx.Value("username").(string)
+	chatID := ctx.Value("chat_id").(string)
+	span.SetAttributes(attribute.Int("user_id", userID))
+	user := models.NewUser(userID, username, chatID)
+
+	var groupRequest models.GroupBookingRequest
+	if err := json.NewDecoder(r.Body).Decode(&groupRequest); err != nil {
+		status = http.StatusBadRequest
+		span.RecordError(err)
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	group, err := b.bookingService.CreateGroupBooking(ctx, &groupRequest, user)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrInvalidBookingData):
+			status = http.StatusBadRequest
+			http.Error(w, "invalid booking data", http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrRoomNotFound):
+			status = http.StatusBadRequest
+			http.Error(w, "room not found", http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrRoomCapacityExceeded):
+			status = http.StatusUnprocessableEntity
+			http.Error(w, myerror.ErrRoomCapacityExceeded.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, myerror.ErrBookingAlreadyExists):
+			status = http.StatusConflict
+			http.Error(w, myerror.ErrBookingAlreadyExists.Error(), http.StatusConflict)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	status = http.StatusCreated
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(group)
+	span.AddEvent("Group booking created successfully")
+}
+
+// JoinWaitlist ставит гостя в очередь на комнату отеля, когда свободных на нужные даты нет
+func (b *BookingHandler) JoinWaitlist(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.JoinWaitlist")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/waitlist", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int)
+	username := ctx.Value("username").(string)
+	chatID := ctx.Value("chat_id").(string)
+	span.SetAttributes(attribute.Int("user_id", userID))
+	user := models.NewUser(userID, username, chatID)
+
+	var waitlistRequest models.WaitlistRequest
+	if err := json.NewDecoder(r.Body).Decode(&waitlistRequest); err != nil {
+		status = http.StatusBadRequest
+		span.RecordError(err)
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	entry, err := b.bookingService.JoinWaitlist(ctx, &waitlistRequest, user)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrInvalidBookingData):
+			status = http.StatusBadRequest
+			http.Error(w, "invalid waitlist data", http.StatusBadRequest)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	status = http.StatusCreated
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(entry)
+	span.AddEvent("Waitlist entry created successfully")
+}
+
+// ClaimWaitlistOffer бронирует комнату по ссылке из предложения листа ожидания
+func (b *BookingHandler) ClaimWaitlistOffer(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.ClaimWaitlistOffer")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/waitlist/claim", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int)
+	username := ctx.Value("username").(string)
+	chatID := ctx.Value("chat_id").(string)
+	span.SetAttributes(attribute.Int("user_id", userID))
+	user := models.NewUser(userID, username, chatID)
+
+	token := r.URL.Query().Get("token")
+	if token == "" {
+		status = http.StatusBadRequest
+		http.Error(w, "token is required", http.StatusBadRequest)
+		return
+	}
+
+	var claimRequest models.WaitlistClaimRequest
+	if err := json.NewDecoder(r.Body).Decode(&claimRequest); err != nil {
+		status = http.StatusBadRequest
+		span.RecordError(err)
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	booking, err := b.bookingService.ClaimWaitlistOffer(ctx, token, &claimRequest, user)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrWaitlistOfferUnavailable):
+			status = http.StatusGone
+			http.Error(w, myerror.ErrWaitlistOfferUnavailable.Error(), http.StatusGone)
+		case errors.Is(err, myerror.ErrInvalidBookingData):
+			status = http.StatusBadRequest
+			http.Error(w, "invalid booking data", http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrRoomNotFound):
+			status = http.StatusBadRequest
+			http.Error(w, "room not found", http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrRoomCapacityExceeded):
+			status = http.StatusUnprocessableEntity
+			http.Error(w, myerror.ErrRoomCapacityExceeded.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, myerror.ErrBookingAlreadyExists):
+			status = http.StatusConflict
+			http.Error(w, myerror.ErrBookingAlreadyExists.Error(), http.StatusConflict)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	status = http.StatusCreated
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(booking)
+	span.AddEvent("Waitlist offer claimed successfully")
+}
+
+func (b *BookingHandler) CancelBooking(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.CancelBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/{id}", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int)
+	username := ctx.Value("username").(string)
+	chatID := ctx.Value("chat_id").(string)
+	user := models.NewUser(userID, username, chatID)
+
+	bookingID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid booking id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("booking_id", bookingID))
+
+	if err = b.bookingService.CancelBooking(ctx, bookingID, user); err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrBookingNotFound):
+			status = http.StatusNotFound
+			http.Error(w, "booking not found", http.StatusNotFound)
+		case errors.Is(err, myerror.ErrForbiddenAccess):
+			status = http.StatusForbidden
+			http.Error(w, "forbidden access", http.StatusForbidden)
+		case errors.Is(err, myerror.ErrInvalidBookingStatus):
+			status = http.StatusConflict
+			http.Error(w, "booking can not be cancelled", http.StatusConflict)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	status = http.StatusNoContent
+	w.WriteHeader(http.StatusNoContent)
+	span.AddEvent("Booking cancelled successfully")
+}
+
+func (b *BookingHandler) ModifyBooking(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.ModifyBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/{id}", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int)
+	username := ctx.Value("username").(string)
+	chatID := ctx.Value("chat_id").(string)
+	user := models.NewUser(userID, username, chatID)
+
+	bookingID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid booking id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("booking_id", bookingID))
+
+	var updateRequest models.BookingUpdateRequest
+	if err = json.NewDecoder(r.Body).Decode(&updateRequest); err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	booking, err := b.bookingService.ModifyBooking(ctx, bookingID, &updateRequest, user)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrInvalidBookingData):
+			status = http.StatusBadRequest
+			http.Error(w, "invalid booking data", http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrRoomNotFound):
+			status = http.StatusBadRequest
+			http.Error(w, "room not found", http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrRoomCapacityExceeded):
+			status = http.StatusUnprocessableEntity
+			http.Error(w, myerror.ErrRoomCapacityExceeded.Error(), http.StatusUnprocessableEntity)
+		case errors.Is(err, myerror.ErrBookingNotFound):
+			status = http.StatusNotFound
+			http.Error(w, "booking not found", http.StatusNotFound)
+		case errors.Is(err, myerror.ErrForbiddenAccess):
+			status = http.StatusForbidden
+			http.Error(w, "forbidden access", http.StatusForbidden)
+		case errors.Is(err, myerror.ErrBookingAlreadyExists):
+			status = http.StatusConflict
+			http.Error(w, "room is not available for new dates", http.StatusConflict)
+		case errors.Is(err, myerror.ErrInvalidBookingStatus):
+			status = http.StatusConflict
+			http.Error(w, "booking can not be modified", http.StatusConflict)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	// Изменение с доплатой принято, но вступит в силу только после оплаты
+	if booking.PendingModification != nil {
+		status = http.StatusAccepted
+		w.WriteHeader(http.StatusAccepted)
+	}
+	json.NewEncoder(w).Encode(booking)
+	span.AddEvent("Booking modified successfully")
+}
+
+// GetBookingByID возвращает бронирование с историей статусов гостю или владельцу отеля
+func (b *BookingHandler) GetBookingByID(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.GetBookingByID")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/{id}", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int)
+	bookingID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid booking id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("booking_id", bookingID))
+
+	booking, err := b.bookingService.GetBookingByID(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrBookingNotFound):
+			status = http.StatusNotFound
+			http.Error(w, "booking not found", http.StatusNotFound)
+		case errors.Is(err, myerror.ErrForbiddenAccess):
+			status = http.StatusForbidden
+			http.Error(w, "forbidden access", http.StatusForbidden)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(booking)
+	span.AddEvent("Booking retrieved successfully")
+}
+
 func (b *BookingHandler) GetBookingByUserID(w http.ResponseWriter, r *http.Request) {
 	ctx, span := b.tracer.Start(r.Context(), "Handler.GetBookingByUserID")
 	defer span.End()
@@ -113,10 +517,23 @@ func (b *BookingHandler) GetBookingByUserID(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	bookings, err := b.bookingService.GetBookingsByUserID(ctx, userID)
+	filter, err := parseBookingFilter(r.URL.Query())
+	if err != nil {
+		status = http.StatusBadRequest
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bookings, err := b.bookingService.GetBookingsByUserID(ctx, userID, filter)
 	if err != nil {
-		status = http.StatusInternalServerError
 		span.RecordError(err)
+		if errors.Is(err, myerror.ErrInvalidBookingFilter) {
+			status = http.StatusBadRequest
+			http.Error(w, myerror.ErrInvalidBookingFilter.Error(), http.StatusBadRequest)
+			return
+		}
+		status = http.StatusInternalServerError
 		http.Error(w, "server error", http.StatusInternalServerError)
 		return
 	}
@@ -147,11 +564,23 @@ func (b *BookingHandler) GetBookingByHotelID(w http.ResponseWriter, r *http.Requ
 	}
 	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", hotelID))
 
-	bookings, err := b.bookingService.GetBookingsByHotelID(ctx, hotelID, userID)
+	filter, err := parseBookingFilter(r.URL.Query())
 	if err != nil {
+		status = http.StatusBadRequest
 		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 
-		if errors.Is(err, myerror.ErrForbiddenAccess) {
+	bookings, err := b.bookingService.GetBookingsByHotelID(ctx, hotelID, userID, filter)
+	if err != nil {
+		span.RecordError(err)
+
+		if errors.Is(err, myerror.ErrInvalidBookingFilter) {
+			status = http.StatusBadRequest
+			http.Error(w, myerror.ErrInvalidBookingFilter.Error(), http.StatusBadRequest)
+			return
+		} else if errors.Is(err, myerror.ErrForbiddenAccess) {
 			status = http.StatusForbidden
 			http.Error(w, "forbidden access", http.StatusForbidden)
 			return
@@ -169,6 +598,260 @@ func (b *BookingHandler) GetBookingByHotelID(w http.ResponseWriter, r *http.Requ
 	span.AddEvent("Bookings retrieved successfully")
 }
 
+func (b *BookingHandler) CheckInBooking(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.CheckInBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/{id}/check-in", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	bookingID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid booking id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("booking_id", bookingID))
+
+	booking, err := b.bookingService.CheckInBooking(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		status = writeStayError(w, err, "guest can not be checked in")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(booking)
+	span.AddEvent("Guest checked in successfully")
+}
+
+func (b *BookingHandler) CheckOutBooking(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.CheckOutBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/{id}/check-out", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	bookingID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid booking id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("booking_id", bookingID))
+
+	booking, err := b.bookingService.CheckOutBooking(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		status = writeStayError(w, err, "guest can not be checked out")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(booking)
+	span.AddEvent("Guest checked out successfully")
+}
+
+func (b *BookingHandler) MarkNoShow(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.MarkNoShow")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/{id}/no-show", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	bookingID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid booking id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("booking_id", bookingID))
+
+	// Тело необязательно: без него оставшиеся ночи остаются за гостем
+	var noShowRequest models.NoShowRequest
+	if err = json.NewDecoder(r.Body).Decode(&noShowRequest); err != nil && !errors.Is(err, io.EOF) {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+
+	booking, err := b.bookingService.MarkNoShow(ctx, bookingID, userID, noShowRequest.ReleaseRemainingNights)
+	if err != nil {
+		span.RecordError(err)
+		status = writeStayError(w, err, "booking can not be marked as no-show")
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(booking)
+	span.AddEvent("Booking marked as no-show successfully")
+}
+
+// GetArrivals возвращает владельцу отеля оплаченные бронирования с заездом в день date
+func (b *BookingHandler) GetArrivals(w http.ResponseWriter, r *http.Request) {
+	b.getStayList(w, r, "Handler.GetArrivals", "/bookings/hotels/arrivals", models.DateFilterArrivals, models.StatusConfirmed)
+}
+
+// GetDepartures возвращает владельцу отеля бронирования заселённых гостей с выездом в день date
+func (b *BookingHandler) GetDepartures(w http.ResponseWriter, r *http.Request) {
+	b.getStayList(w, r, "Handler.GetDepartures", "/bookings/hotels/departures", models.DateFilterDepartures, models.StatusCheckedIn)
+}
+
+// getStayList - список бронирований отеля в статусе bookingStatus с заездом или выездом (dateFilter) в один день.
+// День задаётся параметром date в формате YYYY-MM-DD по UTC, по умолчанию сегодня.
+func (b *BookingHandler) getStayList(w http.ResponseWriter, r *http.Request, spanName, route, dateFilter, bookingStatus string) {
+	ctx, span := b.tracer.Start(r.Context(), spanName)
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, route, http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	hotelID, err := strconv.Atoi(r.URL.Query().Get("hotel_id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid hotel_id", http.StatusBadRequest)
+		return
+	}
+	day := time.Now()
+	if date := r.URL.Query().Get("date"); date != "" {
+		day, err = time.Parse(models.CalendarDayLayout, date)
+		if err != nil {
+			span.RecordError(err)
+			status = http.StatusBadRequest
+			http.Error(w, "Invalid date, expected YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", hotelID))
+
+	filter, err := parseBookingFilter(r.URL.Query())
+	if err != nil {
+		status = http.StatusBadRequest
+		span.RecordError(err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	from, to := models.DayBounds(day)
+	filter.From, filter.To = &from, &to
+	filter.DateFilter = dateFilter
+	filter.Statuses = []string{bookingStatus}
+	if filter.Sort == "" {
+		filter.Sort = models.SortStartAsc
+	}
+
+	bookings, err := b.bookingService.GetBookingsByHotelID(ctx, hotelID, userID, filter)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrInvalidBookingFilter):
+			status = http.StatusBadRequest
+			http.Error(w, myerror.ErrInvalidBookingFilter.Error(), http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrForbiddenAccess):
+			status = http.StatusForbidden
+			http.Error(w, "forbidden access", http.StatusForbidden)
+		case errors.Is(err, myerror.ErrHotelNotFound):
+			status = http.StatusNotFound
+			http.Error(w, "hotel not found", http.StatusNotFound)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(bookings)
+	span.AddEvent("Bookings retrieved successfully")
+}
+
+// writeStayError отвечает на ошибку заселения, выезда или неявки и возвращает HTTP статус ответа
+func writeStayError(w http.ResponseWriter, err error, conflictMessage string) int {
+	switch {
+	case errors.Is(err, myerror.ErrBookingNotFound):
+		http.Error(w, "booking not found", http.StatusNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, myerror.ErrForbiddenAccess):
+		http.Error(w, "forbidden access", http.StatusForbidden)
+		return http.StatusForbidden
+	case errors.Is(err, myerror.ErrInvalidBookingStatus):
+		http.Error(w, conflictMessage, http.StatusConflict)
+		return http.StatusConflict
+	}
+	http.Error(w, "server error", http.StatusInternalServerError)
+	return http.StatusInternalServerError
+}
+
+// GetOccupancyCalendar возвращает владельцу отеля занятость комнат по дням месяца
+func (b *BookingHandler) GetOccupancyCalendar(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.GetOccupancyCalendar")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/calendar", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	hotelID, err := strconv.Atoi(r.URL.Query().Get("hotel_id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid hotel_id", http.StatusBadRequest)
+		return
+	}
+	month, err := time.Parse(models.CalendarMonthLayout, r.URL.Query().Get("month"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid month, expected YYYY-MM", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", hotelID))
+
+	calendar, err := b.bookingService.GetOccupancyCalendar(ctx, hotelID, userID, month)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrForbiddenAccess):
+			status = http.StatusForbidden
+			http.Error(w, "forbidden access", http.StatusForbidden)
+		case errors.Is(err, myerror.ErrHotelNotFound):
+			status = http.StatusNotFound
+			http.Error(w, "hotel not found", http.StatusNotFound)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(calendar)
+	span.AddEvent("Occupancy calendar retrieved successfully")
+}
+
 func (b *BookingHandler) GetAvailableRooms(w http.ResponseWriter, r *http.Request) {
 	ctx, span := b.tracer.Start(r.Context(), "Handler.GetAvailableRooms")
 	defer span.End()
@@ -205,9 +888,21 @@ func (b *BookingHandler) GetAvailableRooms(w http.ResponseWriter, r *http.Reques
 		http.Error(w, fmt.Sprintf("Invalid end_date: %v", err), http.StatusBadRequest)
 		return
 	}
-	availableRooms, err := b.bookingService.GetAvailableRooms(ctx, hotelId, startDate.UTC(), endDate.UTC())
+	// Гости задаются count_of_people или adults и children, по умолчанию один взрослый
+	guests, errParam := parseGuestsQuery(r.URL.Query())
+	if errParam != "" {
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid "+errParam, http.StatusBadRequest)
+		return
+	}
+	availableRooms, err := b.bookingService.GetAvailableRooms(ctx, hotelId, startDate.UTC(), endDate.UTC(), guests)
 	if err != nil {
 		span.RecordError(err)
+		if errors.Is(err, myerror.ErrInvalidBookingData) {
+			status = http.StatusBadRequest
+			http.Error(w, "invalid booking data", http.StatusBadRequest)
+			return
+		}
 		status = http.StatusInternalServerError
 		http.Error(w, "server error", http.StatusInternalServerError)
 		return
@@ -217,6 +912,113 @@ func (b *BookingHandler) GetAvailableRooms(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(availableRooms)
 }
 
+// parseGuestsQuery собирает состав гостей из параметров count_of_people, adults и children.
+// При ошибке возвращает имя неверного параметра.
+func parseGuestsQuery(query url.Values) (models.Guests, string) {
+	values := make(map[string]int, 3)
+	for _, param := range []string{"count_of_people", "adults", "children"} {
+		valueStr := query.Get(param)
+		if valueStr == "" {
+			continue
+		}
+		value, err := strconv.Atoi(valueStr)
+		if err != nil || value < 0 {
+			return models.Guests{}, param
+		}
+		values[param] = value
+	}
+	if len(values) == 0 {
+		return models.Guests{Adults: 1}, ""
+	}
+	guests, ok := models.NewGuests(values["count_of_people"], values["adults"], values["children"])
+	if !ok {
+		if values["adults"] == 0 && values["children"] == 0 {
+			return models.Guests{}, "count_of_people"
+		}
+		return models.Guests{}, "adults or children"
+	}
+	return guests, ""
+}
+
+// SearchHotels ищет отели города со свободными на даты комнатами, от самых дешёвых к дорогим
+func (b *BookingHandler) SearchHotels(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.SearchHotels")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/search", http.StatusText(status), duration)
+	}()
+	query := r.URL.Query()
+	startDate, err := time.Parse(time.RFC3339, query.Get("start_date"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, fmt.Sprintf("Invalid start_date: %v", err), http.StatusBadRequest)
+		return
+	}
+	endDate, err := time.Parse(time.RFC3339, query.Get("end_date"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, fmt.Sprintf("Invalid end_date: %v", err), http.StatusBadRequest)
+		return
+	}
+	searchRequest := &models.HotelSearchRequest{
+		City:      query.Get("city"),
+		StartDate: startDate.UTC(),
+		EndDate:   endDate.UTC(),
+		Guests:    1,
+	}
+	if guestsStr := query.Get("guests"); guestsStr != "" {
+		searchRequest.Guests, err = strconv.Atoi(guestsStr)
+		if err != nil || searchRequest.Guests <= 0 {
+			status = http.StatusBadRequest
+			http.Error(w, "Invalid guests", http.StatusBadRequest)
+			return
+		}
+	}
+	// Сколько из guests гостей - дети, хотя бы один гость должен быть взрослым
+	if childrenStr := query.Get("children"); childrenStr != "" {
+		searchRequest.Children, err = strconv.Atoi(childrenStr)
+		if err != nil || searchRequest.Children < 0 || searchRequest.Children >= searchRequest.Guests {
+			status = http.StatusBadRequest
+			http.Error(w, "Invalid children", http.StatusBadRequest)
+			return
+		}
+	}
+	for param, price := range map[string]*int{"min_price": &searchRequest.MinPrice, "max_price": &searchRequest.MaxPrice} {
+		priceStr := query.Get(param)
+		if priceStr == "" {
+			continue
+		}
+		*price, err = strconv.Atoi(priceStr)
+		if err != nil || *price < 0 {
+			status = http.StatusBadRequest
+			http.Error(w, "Invalid "+param, http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, err := b.bookingService.SearchHotels(ctx, searchRequest)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrInvalidBookingData) {
+			status = http.StatusBadRequest
+			http.Error(w, "invalid search request", http.StatusBadRequest)
+			return
+		}
+		status = http.StatusInternalServerError
+		http.Error(w, "server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	span.AddEvent("Search hotels success")
+	json.NewEncoder(w).Encode(results)
+}
+
 func (b *BookingHandler) HandlePaymentWebHook(w http.ResponseWriter, r *http.Request) {
 	ctx, span := b.tracer.Start(r.Context(), "Handler.HandlePaymentWebHook")
 	defer span.End()
@@ -227,29 +1029,116 @@ func (b *BookingHandler) HandlePaymentWebHook(w http.ResponseWriter, r *http.Req
 		duration := time.Since(start).Seconds()
 		metrics.RecordHttpMetrics(r.Method, "/bookings/payment/response", http.StatusText(status), duration)
 	}()
+	// Заказ задан в адресе вебхука, который подписан вместе с телом
+	webhook := &models.PaymentWebhook{
+		Timestamp:  r.Header.Get(headerWebhookTimestamp),
+		Signature:  r.Header.Get(headerWebhookSignature),
+		RequestURI: r.RequestURI,
+	}
+	query := r.URL.Query()
+	for name, target := range map[string]*int{"booking_id": &webhook.BookingID, "group_id": &webhook.GroupID, "modification_id": &webhook.ModificationID} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		id, err := strconv.Atoi(value)
+		if err != nil || id <= 0 {
+			status = http.StatusBadRequest
+			http.Error(w, "invalid "+name, http.StatusBadRequest)
+			return
+		}
+		*target = id
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBodySize))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
 	var paymentResponse models.PaymentResponse
-	if err := json.NewDecoder(r.Body).Decode(&paymentResponse); err != nil {
+	if err = json.Unmarshal(body, &paymentResponse); err != nil {
 		span.RecordError(err)
-
+		status = http.StatusBadRequest
 		http.Error(w, "invalid request", http.StatusBadRequest)
 		return
 	}
+	webhook.Status = paymentResponse.Status
+	webhook.Body = body
 
-	log.Println(paymentResponse.MetaData)
-	err := b.bookingService.UpdateBookingStatus(ctx, paymentResponse.Status, paymentResponse.MetaData)
-
-	switch paymentResponse.Status {
-	case "success":
-		if err != nil {
-			span.RecordError(err)
-
+	err = b.bookingService.UpdateBookingStatus(ctx, webhook)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrInvalidWebhook):
+			status = http.StatusUnauthorized
+			http.Error(w, myerror.ErrInvalidWebhook.Error(), http.StatusUnauthorized)
+		case errors.Is(err, myerror.ErrWebhookReplayed):
+			status = http.StatusConflict
+			http.Error(w, myerror.ErrWebhookReplayed.Error(), http.StatusConflict)
+		case errors.Is(err, myerror.ErrInvalidPaymentStatus):
+			status = http.StatusBadRequest
+			http.Error(w, myerror.ErrInvalidPaymentStatus.Error(), http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrBookingNotFound):
+			status = http.StatusNotFound
+			http.Error(w, myerror.ErrBookingNotFound.Error(), http.StatusNotFound)
+		default:
 			status = http.StatusInternalServerError
-			log.Println("handler UpdateBookingStatusSuccess: ", err.Error())
+			log.Println("handler UpdateBookingStatus: ", err.Error())
 			http.Error(w, "server error", http.StatusInternalServerError)
-			return
 		}
+		return
+	}
 
-		w.WriteHeader(http.StatusOK)
+	w.WriteHeader(http.StatusOK)
+	if paymentResponse.Status == models.PaymentSuccess {
 		w.Write([]byte("success booking!"))
 	}
 }
+
+// parseBookingFilter разбирает query-параметры фильтров и страницы списков бронирований.
+// Значения проверяются в сервисе, здесь только разбор форматов.
+func parseBookingFilter(query url.Values) (*models.BookingFilter, error) {
+	filter := &models.BookingFilter{
+		DateFilter: query.Get("date_filter"),
+		Sort:       query.Get("sort"),
+	}
+	if statuses := query.Get("status"); statuses != "" {
+		filter.Statuses = strings.Split(statuses, ",")
+	}
+	for name, target := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", name, err)
+		}
+		date = date.UTC()
+		*target = &date
+	}
+	if roomID := query.Get("room_id"); roomID != "" {
+		id, err := strconv.Atoi(roomID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid room_id")
+		}
+		filter.RoomID = id
+	}
+	if limit := query.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit")
+		}
+		filter.Limit = value
+	}
+	if cursor := query.Get("cursor"); cursor != "" {
+		decoded, err := models.DecodeBookingCursor(cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.Cursor = decoded
+	}
+	return filter, nil
+}
@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/ical"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CreateICalFeed выдаёт владельцу отеля секретную ссылку на .ics с занятостью отеля или комнаты
+func (b *BookingHandler) CreateICalFeed(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.CreateICalFeed")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusCreated
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/ical/feeds", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	var feedRequest models.ICalFeedRequest
+	if err := json.NewDecoder(r.Body).Decode(&feedRequest); err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", feedRequest.HotelID))
+
+	feed, err := b.bookingService.CreateICalFeed(ctx, &feedRequest, userID)
+	if err != nil {
+		span.RecordError(err)
+		status = writeICalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(feed)
+	span.AddEvent("ICal feed created successfully")
+}
+
+func (b *BookingHandler) RevokeICalFeed(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.RevokeICalFeed")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusNoContent
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/ical/feeds/{token}", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	span.SetAttributes(attribute.Int("user_id", userID))
+
+	if err := b.bookingService.RevokeICalFeed(ctx, r.PathValue("token"), userID); err != nil {
+		span.RecordError(err)
+		status = writeICalError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+	span.AddEvent("ICal feed revoked successfully")
+}
+
+// ExportICalFeed отдаёт календарь .ics по секретной ссылке без JWT: его запрашивают другие площадки
+func (b *BookingHandler) ExportICalFeed(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.ExportICalFeed")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/ical/{token}", http.StatusText(status), duration)
+	}()
+
+	// Площадки часто ждут ссылку с расширением .ics
+	token := strings.TrimSuffix(r.PathValue("token"), ".ics")
+	calendar, err := b.bookingService.ExportICalFeed(ctx, token)
+	if err != nil {
+		span.RecordError(err)
+		status = writeICalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", `inline; filename="bookings.ics"`)
+	if err = ical.Write(w, calendar); err != nil {
+		span.RecordError(err)
+		return
+	}
+	span.AddEvent("ICal feed exported successfully")
+}
+
+// CreateICalImport подписывает комнату на календарь другой площадки
+func (b *BookingHandler) CreateICalImport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.CreateICalImport")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusCreated
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/ical/imports", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	var importRequest models.ICalImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&importRequest); err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "bad request", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", importRequest.HotelID),
+		attribute.Int("room_id", importRequest.RoomID))
+
+	icalImport, err := b.bookingService.CreateICalImport(ctx, &importRequest, userID)
+	if err != nil {
+		span.RecordError(err)
+		status = writeICalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(icalImport)
+	span.AddEvent("ICal import created successfully")
+}
+
+func (b *BookingHandler) GetICalImports(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.GetICalImports")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/ical/imports", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	hotelID, err := strconv.Atoi(r.URL.Query().Get("hotel_id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid hotel_id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", hotelID))
+
+	imports, err := b.bookingService.GetICalImports(ctx, hotelID, userID)
+	if err != nil {
+		span.RecordError(err)
+		status = writeICalError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(imports)
+	span.AddEvent("ICal imports retrieved successfully")
+}
+
+func (b *BookingHandler) DeleteICalImport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.DeleteICalImport")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusNoContent
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/ical/imports/{id}", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	importID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, "Invalid import id", http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("import_id", importID))
+
+	if err = b.bookingService.DeleteICalImport(ctx, importID, userID); err != nil {
+		span.RecordError(err)
+		status = writeICalError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+	span.AddEvent("ICal import deleted successfully")
+}
+
+// writeICalError отвечает на ошибку выгрузки или импорта календаря и возвращает HTTP статус ответа
+func writeICalError(w http.ResponseWriter, err error) int {
+	switch {
+	case errors.Is(err, myerror.ErrInvalidBookingData), errors.Is(err, myerror.ErrInvalidICalImport):
+		http.Error(w, "invalid hotel, room or calendar url", http.StatusBadRequest)
+		return http.StatusBadRequest
+	case errors.Is(err, myerror.ErrForbiddenAccess):
+		http.Error(w, "forbidden access", http.StatusForbidden)
+		return http.StatusForbidden
+	case errors.Is(err, myerror.ErrHotelNotFound):
+		http.Error(w, "hotel not found", http.StatusNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, myerror.ErrRoomNotFound):
+		http.Error(w, "room not found", http.StatusNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, myerror.ErrICalFeedNotFound):
+		http.Error(w, "calendar not found", http.StatusNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, myerror.ErrICalImportNotFound):
+		http.Error(w, "calendar import not found", http.StatusNotFound)
+		return http.StatusNotFound
+	case errors.Is(err, myerror.ErrICalImportExists):
+		http.Error(w, myerror.ErrICalImportExists.Error(), http.StatusConflict)
+		return http.StatusConflict
+	}
+	http.Error(w, "server error", http.StatusInternalServerError)
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetHotelReport возвращает владельцу отеля загрузку, ADR, RevPAR и долю отмен за период
+func (b *BookingHandler) GetHotelReport(w http.ResponseWriter, r *http.Request) {
+	ctx, span := b.tracer.Start(r.Context(), "Handler.GetHotelReport")
+	defer span.End()
+
+	start := time.Now()
+	status := http.StatusOK
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordHttpMetrics(r.Method, "/bookings/hotels/reports", http.StatusText(status), duration)
+	}()
+
+	userID := ctx.Value("user_id").(int) // Должен быть Владелец отеля
+	reportRequest, err := parseReportRequest(r.URL.Query())
+	if err != nil {
+		span.RecordError(err)
+		status = http.StatusBadRequest
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	span.SetAttributes(attribute.Int("user_id", userID), attribute.Int("hotel_id", reportRequest.HotelID))
+
+	report, err := b.bookingService.GetHotelReport(ctx, reportRequest, userID)
+	if err != nil {
+		span.RecordError(err)
+		switch {
+		case errors.Is(err, myerror.ErrInvalidReportRequest):
+			status = http.StatusBadRequest
+			http.Error(w, myerror.ErrInvalidReportRequest.Error(), http.StatusBadRequest)
+		case errors.Is(err, myerror.ErrForbiddenAccess):
+			status = http.StatusForbidden
+			http.Error(w, "forbidden access", http.StatusForbidden)
+		case errors.Is(err, myerror.ErrHotelNotFound):
+			status = http.StatusNotFound
+			http.Error(w, "hotel not found", http.StatusNotFound)
+		default:
+			status = http.StatusInternalServerError
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(report)
+	span.AddEvent("Hotel report retrieved successfully")
+}
+
+// parseReportRequest читает hotel_id, период from-to в формате YYYY-MM-DD и разбивку group_by через запятую
+func parseReportRequest(query url.Values) (*models.ReportRequest, error) {
+	hotelID, err := strconv.Atoi(query.Get("hotel_id"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid hotel_id")
+	}
+	req := &models.ReportRequest{HotelID: hotelID}
+	for name, target := range map[string]*time.Time{"from": &req.From, "to": &req.To} {
+		date, err := time.Parse(models.CalendarDayLayout, query.Get(name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s, expected YYYY-MM-DD", name)
+		}
+		*target = date
+	}
+	if groupBy := query.Get("group_by"); groupBy != "" {
+		req.GroupBy = strings.Split(groupBy, ",")
+	}
+	return req, nil
+}
@@ -5,15 +5,50 @@ import (
 	"net/http"
 )
 
+// JWTSecret - секрет подписи JWT, общий с AuthSvc и gRPC API
+const JWTSecret = "LUIGI"
+
 func SetupRoutes(bookingHandler *BookingHandler) *http.ServeMux {
 	mux := http.NewServeMux()
 
-	middlewareHandler := middleware.NewMiddleware("LUIGI")
-	mux.HandleFunc("/bookings", middlewareHandler.Auth(bookingHandler.CreateBooking, false))             // POST - Создается новое бронирование
-	mux.HandleFunc("/bookings/users", middlewareHandler.Auth(bookingHandler.GetBookingByUserID, false))  // GET - получаем все бронирования пользователя
-	mux.HandleFunc("/bookings/hotels", middlewareHandler.Auth(bookingHandler.GetBookingByHotelID, true)) // Get - получаем все бронирования отельера
+	middlewareHandler := middleware.NewMiddleware(JWTSecret)
+	mux.HandleFunc("POST /bookings", middlewareHandler.Auth(bookingHandler.CreateBooking, false))            // POST - Создается новое бронирование
+	mux.HandleFunc("GET /bookings/users", middlewareHandler.Auth(bookingHandler.GetBookingByUserID, false))  // GET - получаем все бронирования пользователя
+	mux.HandleFunc("GET /bookings/hotels", middlewareHandler.Auth(bookingHandler.GetBookingByHotelID, true)) // Get - получаем все бронирования отельера
+	mux.HandleFunc("DELETE /bookings/{id}", middlewareHandler.Auth(bookingHandler.CancelBooking, false))     // DELETE - отмена бронирования гостем
+	mux.HandleFunc("PATCH /bookings/{id}", middlewareHandler.Auth(bookingHandler.ModifyBooking, false))      // PATCH - изменение дат, комнаты или числа гостей
+	mux.HandleFunc("GET /bookings/{id}", middlewareHandler.Auth(bookingHandler.GetBookingByID, false))       // GET - бронирование гостя с историей статусов
+	mux.HandleFunc("GET /bookings/hotels/{id}", middlewareHandler.Auth(bookingHandler.GetBookingByID, true)) // GET - то же бронирование для владельца отеля
+
+	mux.HandleFunc("POST /bookings/groups", middlewareHandler.Auth(bookingHandler.CreateGroupBooking, false)) // POST - групповое бронирование нескольких комнат
+
+	mux.HandleFunc("POST /bookings/waitlist", middlewareHandler.Auth(bookingHandler.JoinWaitlist, false))             // POST - встать в лист ожидания
+	mux.HandleFunc("POST /bookings/waitlist/claim", middlewareHandler.Auth(bookingHandler.ClaimWaitlistOffer, false)) // POST - забронировать предложенную комнату по токену
+
+	mux.HandleFunc("POST /bookings/hotels/{id}/check-in", middlewareHandler.Auth(bookingHandler.CheckInBooking, true))   // POST - гость заселился
+	mux.HandleFunc("POST /bookings/hotels/{id}/check-out", middlewareHandler.Auth(bookingHandler.CheckOutBooking, true)) // POST - гость выехал
+	mux.HandleFunc("POST /bookings/hotels/{id}/no-show", middlewareHandler.Auth(bookingHandler.MarkNoShow, true))        // POST - гость не приехал
+	mux.HandleFunc("GET /bookings/hotels/arrivals", middlewareHandler.Auth(bookingHandler.GetArrivals, true))            // GET - заезды отеля за день
+	mux.HandleFunc("GET /bookings/hotels/departures", middlewareHandler.Auth(bookingHandler.GetDepartures, true))        // GET - выезды отеля за день
+
+	mux.HandleFunc("POST /bookings/hotels/blocks", middlewareHandler.Auth(bookingHandler.CreateRoomBlock, true))        // POST - снять комнату с продажи на период
+	mux.HandleFunc("DELETE /bookings/hotels/blocks/{id}", middlewareHandler.Auth(bookingHandler.DeleteRoomBlock, true)) // DELETE - вернуть комнату в продажу
+
+	mux.HandleFunc("POST /bookings/hotels/ical/feeds", middlewareHandler.Auth(bookingHandler.CreateICalFeed, true))            // POST - ссылка на выгрузку занятости в .ics
+	mux.HandleFunc("DELETE /bookings/hotels/ical/feeds/{token}", middlewareHandler.Auth(bookingHandler.RevokeICalFeed, true))  // DELETE - отзыв ссылки на выгрузку
+	mux.HandleFunc("POST /bookings/hotels/ical/imports", middlewareHandler.Auth(bookingHandler.CreateICalImport, true))        // POST - подписка комнаты на календарь другой площадки
+	mux.HandleFunc("GET /bookings/hotels/ical/imports", middlewareHandler.Auth(bookingHandler.GetICalImports, true))           // GET - подписки отеля с результатом синхронизации
+	mux.HandleFunc("DELETE /bookings/hotels/ical/imports/{id}", middlewareHandler.Auth(bookingHandler.DeleteICalImport, true)) // DELETE - отмена подписки
+	mux.HandleFunc("GET /bookings/ical/{token}", bookingHandler.ExportICalFeed)                                                // GET - выгрузка .ics по секретной ссылке, без JWT
+
+	mux.HandleFunc("GET /bookings/hotels/export", middlewareHandler.Auth(bookingHandler.ExportHotelBookings, true)) // GET - выгрузка бронирований отеля в CSV или NDJSON
+	mux.HandleFunc("GET /bookings/hotels/reports", middlewareHandler.Auth(bookingHandler.GetHotelReport, true))     // GET - загрузка, ADR, RevPAR и доля отмен отеля
+
+	mux.HandleFunc("GET /bookings/hotels/calendar", middlewareHandler.Auth(bookingHandler.GetOccupancyCalendar, true)) // GET - занятость комнат отеля по дням месяца
+
+	mux.HandleFunc("GET /bookings/search", bookingHandler.SearchHotels) // GET - поиск свободных комнат по городу, датам и цене
 
-	mux.HandleFunc("/bookings/hotels/rooms", bookingHandler.GetAvailableRooms) //Тут добавить сортировку по времени
-	mux.HandleFunc("/bookings/payment/response", bookingHandler.HandlePaymentWebHook)
+	mux.HandleFunc("GET /bookings/hotels/rooms", bookingHandler.GetAvailableRooms) //Тут добавить сортировку по времени
+	mux.HandleFunc("POST /bookings/payment/response", bookingHandler.HandlePaymentWebHook)
 	return mux
 }
@@ -0,0 +1,302 @@
+// Package ical читает и пишет календари iCalendar (RFC 5545) в объёме, нужном для обмена занятостью
+// комнат с другими площадками: только события VEVENT с датами, идентификатором и названием.
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+	// Строки длиннее переносятся, RFC 5545 3.1
+	maxLineOctets = 75
+	// Предельная длина развёрнутой строки при разборе
+	maxLineSize = 1 << 20
+)
+
+var ErrNotCalendar = errors.New("not an iCalendar document")
+
+// Event - событие календаря, период [Start, End). У событий на весь день (AllDay) даты в полночь UTC.
+type Event struct {
+	UID       string
+	Summary   string
+	Start     time.Time
+	End       time.Time
+	AllDay    bool
+	Cancelled bool // STATUS:CANCELLED, площадка отменила бронирование
+}
+
+// Calendar - календарь для выгрузки. Stamp попадает в DTSTAMP всех событий.
+type Calendar struct {
+	Name   string
+	Stamp  time.Time
+	Events []Event
+}
+
+// Parse читает события VEVENT календаря. Ошибка в любом событии - ошибка всего календаря,
+// чтобы частично прочитанная выгрузка не освободила занятые даты.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0)
+	var (
+		event      *Event
+		isCalendar bool
+		complete   bool // Есть END:VCALENDAR, календарь не обрезан
+		nested     int  // Вложенные в VEVENT компоненты, например VALARM
+		endSet     bool
+		duration   time.Duration
+	)
+	for number, line := range lines {
+		if line == "" {
+			continue
+		}
+		name, params, value, err := parseLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number+1, err)
+		}
+		switch {
+		case name == "BEGIN" && value == "VCALENDAR":
+			isCalendar = true
+		case name == "BEGIN" && value == "VEVENT":
+			event, endSet, duration = &Event{}, false, 0
+		case event == nil:
+			if name == "END" && value == "VCALENDAR" {
+				complete = true
+			}
+		case name == "BEGIN":
+			nested++
+		case name == "END" && nested > 0:
+			nested--
+		case nested > 0:
+		case name == "END" && value == "VEVENT":
+			if err = finishEvent(event, endSet, duration); err != nil {
+				return nil, fmt.Errorf("event %q: %w", event.UID, err)
+			}
+			events = append(events, *event)
+			event = nil
+		case name == "UID":
+			event.UID = value
+		case name == "SUMMARY":
+			event.Summary = unescapeText(value)
+		case name == "STATUS":
+			event.Cancelled = strings.EqualFold(value, "CANCELLED")
+		case name == "DTSTART":
+			event.Start, event.AllDay, err = parseTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTSTART: %w", number+1, err)
+			}
+		case name == "DTEND":
+			event.End, _, err = parseTime(params, value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DTEND: %w", number+1, err)
+			}
+			endSet = true
+		case name == "DURATION":
+			duration, err = parseDuration(value)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: DURATION: %w", number+1, err)
+			}
+		}
+	}
+	if !isCalendar {
+		return nil, ErrNotCalendar
+	}
+	if event != nil {
+		return nil, fmt.Errorf("event %q: missing END:VEVENT", event.UID)
+	}
+	if !complete {
+		return nil, errors.New("missing END:VCALENDAR")
+	}
+	return events, nil
+}
+
+// Write записывает календарь с переводами строк CRLF. События на весь день пишутся датами.
+func Write(w io.Writer, calendar *Calendar) error {
+	buf := bufio.NewWriter(w)
+	write := func(line string) {
+		buf.WriteString(fold(line))
+	}
+
+	write("BEGIN:VCALENDAR")
+	write("VERSION:2.0")
+	write("PRODID:-//room-reservation-system//BookingSvc//EN")
+	write("CALSCALE:GREGORIAN")
+	write("METHOD:PUBLISH")
+	if calendar.Name != "" {
+		write("X-WR-CALNAME:" + escapeText(calendar.Name))
+	}
+	stamp := calendar.Stamp.UTC().Format(dateTimeLayout) + "Z"
+	for _, event := range calendar.Events {
+		write("BEGIN:VEVENT")
+		write("UID:" + event.UID)
+		write("DTSTAMP:" + stamp)
+		if event.AllDay {
+			write("DTSTART;VALUE=DATE:" + event.Start.UTC().Format(dateLayout))
+			write("DTEND;VALUE=DATE:" + event.End.UTC().Format(dateLayout))
+		} else {
+			write("DTSTART:" + event.Start.UTC().Format(dateTimeLayout) + "Z")
+			write("DTEND:" + event.End.UTC().Format(dateTimeLayout) + "Z")
+		}
+		if event.Summary != "" {
+			write("SUMMARY:" + escapeText(event.Summary))
+		}
+		if event.Cancelled {
+			write("STATUS:CANCELLED")
+		}
+		write("END:VEVENT")
+	}
+	write("END:VCALENDAR")
+	return buf.Flush()
+}
+
+// unfold собирает перенесённые строки: продолжение начинается с пробела или табуляции
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineSize)
+	lines := make([]string, 0)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseLine разбирает строку вида NAME;PARAM=VALUE:value. Двоеточие в кавычках параметра значение не начинает.
+func parseLine(line string) (string, map[string]string, string, error) {
+	quoted := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			quoted = !quoted
+		} else if r == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return "", nil, "", fmt.Errorf("malformed content line %q", line)
+	}
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:], nil
+}
+
+// parseTime разбирает DATE или DATE-TIME. Время без Z переводится из TZID, а без неё считается UTC.
+func parseTime(params map[string]string, value string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		date, err := time.Parse(dateLayout, value)
+		return date, true, err
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeLayout, strings.TrimSuffix(value, "Z"))
+		return t, false, err
+	}
+	location := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, location)
+	return t.UTC(), false, err
+}
+
+// parseDuration разбирает длительность вида P1W, P2D, PT3H30M, P1DT12H
+func parseDuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(strings.TrimPrefix(value, "+"), "P")
+	if !ok || rest == "" {
+		return 0, fmt.Errorf("malformed duration %q", value)
+	}
+	units := map[byte]time.Duration{'W': 7 * 24 * time.Hour, 'D': 24 * time.Hour}
+	var total time.Duration
+	for rest != "" {
+		if rest[0] == 'T' {
+			units = map[byte]time.Duration{'H': time.Hour, 'M': time.Minute, 'S': time.Second}
+			rest = rest[1:]
+			continue
+		}
+		end := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if end <= 0 {
+			return 0, fmt.Errorf("malformed duration %q", value)
+		}
+		count, err := strconv.Atoi(rest[:end])
+		unit, known := units[rest[end]]
+		if err != nil || !known {
+			return 0, fmt.Errorf("malformed duration %q", value)
+		}
+		total += time.Duration(count) * unit
+		rest = rest[end+1:]
+	}
+	return total, nil
+}
+
+// finishEvent проверяет даты события. Без DTEND событие на весь день длится сутки, иначе DURATION.
+func finishEvent(event *Event, endSet bool, duration time.Duration) error {
+	if event.Start.IsZero() {
+		return errors.New("missing DTSTART")
+	}
+	if !endSet {
+		if duration == 0 && event.AllDay {
+			duration = 24 * time.Hour
+		}
+		event.End = event.Start.Add(duration)
+	}
+	if event.End.Before(event.Start) {
+		return errors.New("DTEND before DTSTART")
+	}
+	return nil
+}
+
+func fold(line string) string {
+	var b strings.Builder
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		// Не разрываем многобайтовый символ UTF-8
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		limit = maxLineOctets - 1 // Пробел в начале продолжения тоже считается
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
+
+var (
+	textEscaper   = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`)
+	textUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+)
+
+func escapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+func unescapeText(text string) string {
+	return textUnescaper.Replace(text)
+}
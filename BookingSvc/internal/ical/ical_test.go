@@ -0,0 +1,94 @@
+package ical
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse_ExternalFeed(t *testing.T) {
+	file, err := os.Open("testdata/external.ics")
+	require.NoError(t, err)
+	defer file.Close()
+
+	events, err := Parse(file)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	// Событие на весь день, с перенесённым названием и вложенным VALARM
+	assert.Equal(t, Event{
+		UID:     "a1b2c3@other-platform",
+		Summary: "Reserved, guest from other platform",
+		Start:   time.Date(2026, 11, 10, 0, 0, 0, 0, time.UTC),
+		End:     time.Date(2026, 11, 13, 0, 0, 0, 0, time.UTC),
+		AllDay:  true,
+	}, events[0])
+
+	// Время в часовом поясе TZID и длительность вместо DTEND
+	assert.Equal(t, time.Date(2026, 11, 20, 11, 0, 0, 0, time.UTC), events[1].Start)
+	assert.Equal(t, time.Date(2026, 11, 22, 9, 0, 0, 0, time.UTC), events[1].End)
+	assert.False(t, events[1].AllDay)
+
+	// Без DTEND событие на весь день длится сутки
+	assert.True(t, events[2].Cancelled)
+	assert.Equal(t, events[2].Start.AddDate(0, 0, 1), events[2].End)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"not a calendar", "<html>maintenance</html>\n"},
+		{"truncated", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20261110\nEND:VEVENT\n"},
+		{"open event", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20261110\nEND:VCALENDAR\n"},
+		{"missing start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:x\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"malformed date", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2026-11-10\nEND:VEVENT\nEND:VCALENDAR\n"},
+		{"end before start", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20261110\nDTEND;VALUE=DATE:20261101\nEND:VEVENT\nEND:VCALENDAR\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWrite_RoundTrip(t *testing.T) {
+	calendar := &Calendar{
+		Name:  "Hotel 1; room 5",
+		Stamp: time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+		Events: []Event{
+			{
+				UID:     "booking-7@booking-svc",
+				Summary: "Booked, " + strings.Repeat("очень длинное название ", 5),
+				Start:   time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC),
+				End:     time.Date(2026, 11, 4, 0, 0, 0, 0, time.UTC),
+				AllDay:  true,
+			},
+			{
+				UID:   "block-3@booking-svc",
+				Start: time.Date(2026, 11, 5, 14, 0, 0, 0, time.UTC),
+				End:   time.Date(2026, 11, 6, 12, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, calendar))
+
+	for _, line := range strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+	}
+	assert.Contains(t, buf.String(), "DTSTART;VALUE=DATE:20261101\r\n")
+	assert.Contains(t, buf.String(), "X-WR-CALNAME:Hotel 1\\; room 5\r\n")
+
+	events, err := Parse(&buf)
+	require.NoError(t, err)
+	assert.Equal(t, calendar.Events, events)
+}
@@ -9,8 +9,8 @@ import (
 	reflect "reflect"
 	time "time"
 
+	ical "github.com/Quizert/room-reservation-system/BookingSvc/internal/ical"
 	models "github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
-	hotelpb "github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
 	gomock "github.com/golang/mock/gomock"
 )
 
@@ -37,75 +37,386 @@ func (m *MockBookingService) EXPECT() *MockBookingServiceMockRecorder {
 	return m.recorder
 }
 
-// CreateBooking mocks base method.
-func (m *MockBookingService) CreateBooking(ctx context.Context, bookingRequest *models.BookingRequest, user *models.User) error {
+// CancelBooking mocks base method.
+func (m *MockBookingService) CancelBooking(ctx context.Context, bookingID int, user *models.User) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateBooking", ctx, bookingRequest, user)
+	ret := m.ctrl.Call(m, "CancelBooking", ctx, bookingID, user)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
+// CancelBooking indicates an expected call of CancelBooking.
+func (mr *MockBookingServiceMockRecorder) CancelBooking(ctx, bookingID, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockBookingService)(nil).CancelBooking), ctx, bookingID, user)
+}
+
+// CheckInBooking mocks base method.
+func (m *MockBookingService) CheckInBooking(ctx context.Context, bookingID, userID int) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckInBooking", ctx, bookingID, userID)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckInBooking indicates an expected call of CheckInBooking.
+func (mr *MockBookingServiceMockRecorder) CheckInBooking(ctx, bookingID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckInBooking", reflect.TypeOf((*MockBookingService)(nil).CheckInBooking), ctx, bookingID, userID)
+}
+
+// CheckOutBooking mocks base method.
+func (m *MockBookingService) CheckOutBooking(ctx context.Context, bookingID, userID int) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOutBooking", ctx, bookingID, userID)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckOutBooking indicates an expected call of CheckOutBooking.
+func (mr *MockBookingServiceMockRecorder) CheckOutBooking(ctx, bookingID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOutBooking", reflect.TypeOf((*MockBookingService)(nil).CheckOutBooking), ctx, bookingID, userID)
+}
+
+// ClaimWaitlistOffer mocks base method.
+func (m *MockBookingService) ClaimWaitlistOffer(ctx context.Context, token string, claimRequest *models.WaitlistClaimRequest, user *models.User) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWaitlistOffer", ctx, token, claimRequest, user)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWaitlistOffer indicates an expected call of ClaimWaitlistOffer.
+func (mr *MockBookingServiceMockRecorder) ClaimWaitlistOffer(ctx, token, claimRequest, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWaitlistOffer", reflect.TypeOf((*MockBookingService)(nil).ClaimWaitlistOffer), ctx, token, claimRequest, user)
+}
+
+// CreateBooking mocks base method.
+func (m *MockBookingService) CreateBooking(ctx context.Context, bookingRequest *models.BookingRequest, user *models.User) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBooking", ctx, bookingRequest, user)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
 // CreateBooking indicates an expected call of CreateBooking.
 func (mr *MockBookingServiceMockRecorder) CreateBooking(ctx, bookingRequest, user interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBooking", reflect.TypeOf((*MockBookingService)(nil).CreateBooking), ctx, bookingRequest, user)
 }
 
+// CreateGroupBooking mocks base method.
+func (m *MockBookingService) CreateGroupBooking(ctx context.Context, groupRequest *models.GroupBookingRequest, user *models.User) (*models.GroupBooking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupBooking", ctx, groupRequest, user)
+	ret0, _ := ret[0].(*models.GroupBooking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGroupBooking indicates an expected call of CreateGroupBooking.
+func (mr *MockBookingServiceMockRecorder) CreateGroupBooking(ctx, groupRequest, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupBooking", reflect.TypeOf((*MockBookingService)(nil).CreateGroupBooking), ctx, groupRequest, user)
+}
+
+// CreateICalFeed mocks base method.
+func (m *MockBookingService) CreateICalFeed(ctx context.Context, feedRequest *models.ICalFeedRequest, userID int) (*models.ICalFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateICalFeed", ctx, feedRequest, userID)
+	ret0, _ := ret[0].(*models.ICalFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateICalFeed indicates an expected call of CreateICalFeed.
+func (mr *MockBookingServiceMockRecorder) CreateICalFeed(ctx, feedRequest, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateICalFeed", reflect.TypeOf((*MockBookingService)(nil).CreateICalFeed), ctx, feedRequest, userID)
+}
+
+// CreateICalImport mocks base method.
+func (m *MockBookingService) CreateICalImport(ctx context.Context, importRequest *models.ICalImportRequest, userID int) (*models.ICalImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateICalImport", ctx, importRequest, userID)
+	ret0, _ := ret[0].(*models.ICalImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateICalImport indicates an expected call of CreateICalImport.
+func (mr *MockBookingServiceMockRecorder) CreateICalImport(ctx, importRequest, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateICalImport", reflect.TypeOf((*MockBookingService)(nil).CreateICalImport), ctx, importRequest, userID)
+}
+
+// CreateRoomBlock mocks base method.
+func (m *MockBookingService) CreateRoomBlock(ctx context.Context, blockRequest *models.RoomBlockRequest, userID int) (*models.RoomBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRoomBlock", ctx, blockRequest, userID)
+	ret0, _ := ret[0].(*models.RoomBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRoomBlock indicates an expected call of CreateRoomBlock.
+func (mr *MockBookingServiceMockRecorder) CreateRoomBlock(ctx, blockRequest, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoomBlock", reflect.TypeOf((*MockBookingService)(nil).CreateRoomBlock), ctx, blockRequest, userID)
+}
+
+// DeleteICalImport mocks base method.
+func (m *MockBookingService) DeleteICalImport(ctx context.Context, importID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteICalImport", ctx, importID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteICalImport indicates an expected call of DeleteICalImport.
+func (mr *MockBookingServiceMockRecorder) DeleteICalImport(ctx, importID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteICalImport", reflect.TypeOf((*MockBookingService)(nil).DeleteICalImport), ctx, importID, userID)
+}
+
+// DeleteRoomBlock mocks base method.
+func (m *MockBookingService) DeleteRoomBlock(ctx context.Context, blockID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoomBlock", ctx, blockID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoomBlock indicates an expected call of DeleteRoomBlock.
+func (mr *MockBookingServiceMockRecorder) DeleteRoomBlock(ctx, blockID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoomBlock", reflect.TypeOf((*MockBookingService)(nil).DeleteRoomBlock), ctx, blockID, userID)
+}
+
+// ExportBookingsByHotelID mocks base method.
+func (m *MockBookingService) ExportBookingsByHotelID(ctx context.Context, hotelID, userID int, filter *models.BookingFilter, write func(*models.BookingExportRow) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportBookingsByHotelID", ctx, hotelID, userID, filter, write)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportBookingsByHotelID indicates an expected call of ExportBookingsByHotelID.
+func (mr *MockBookingServiceMockRecorder) ExportBookingsByHotelID(ctx, hotelID, userID, filter, write interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportBookingsByHotelID", reflect.TypeOf((*MockBookingService)(nil).ExportBookingsByHotelID), ctx, hotelID, userID, filter, write)
+}
+
+// ExportICalFeed mocks base method.
+func (m *MockBookingService) ExportICalFeed(ctx context.Context, token string) (*ical.Calendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportICalFeed", ctx, token)
+	ret0, _ := ret[0].(*ical.Calendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportICalFeed indicates an expected call of ExportICalFeed.
+func (mr *MockBookingServiceMockRecorder) ExportICalFeed(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportICalFeed", reflect.TypeOf((*MockBookingService)(nil).ExportICalFeed), ctx, token)
+}
+
 // GetAvailableRooms mocks base method.
-func (m *MockBookingService) GetAvailableRooms(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*hotelpb.Room, error) {
+func (m *MockBookingService) GetAvailableRooms(ctx context.Context, hotelID int, startDate, endDate time.Time, guests models.Guests) ([]*models.AvailableRoom, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAvailableRooms", ctx, hotelID, startDate, endDate)
-	ret0, _ := ret[0].([]*hotelpb.Room)
+	ret := m.ctrl.Call(m, "GetAvailableRooms", ctx, hotelID, startDate, endDate, guests)
+	ret0, _ := ret[0].([]*models.AvailableRoom)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAvailableRooms indicates an expected call of GetAvailableRooms.
-func (mr *MockBookingServiceMockRecorder) GetAvailableRooms(ctx, hotelID, startDate, endDate interface{}) *gomock.Call {
+func (mr *MockBookingServiceMockRecorder) GetAvailableRooms(ctx, hotelID, startDate, endDate, guests interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableRooms", reflect.TypeOf((*MockBookingService)(nil).GetAvailableRooms), ctx, hotelID, startDate, endDate, guests)
+}
+
+// GetBookingByID mocks base method.
+func (m *MockBookingService) GetBookingByID(ctx context.Context, bookingID, userID int) (*models.BookingDetails, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingByID", ctx, bookingID, userID)
+	ret0, _ := ret[0].(*models.BookingDetails)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingByID indicates an expected call of GetBookingByID.
+func (mr *MockBookingServiceMockRecorder) GetBookingByID(ctx, bookingID, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAvailableRooms", reflect.TypeOf((*MockBookingService)(nil).GetAvailableRooms), ctx, hotelID, startDate, endDate)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingByID", reflect.TypeOf((*MockBookingService)(nil).GetBookingByID), ctx, bookingID, userID)
 }
 
 // GetBookingsByHotelID mocks base method.
-func (m *MockBookingService) GetBookingsByHotelID(ctx context.Context, hotelID, userID int) ([]*models.BookingInfo, error) {
+func (m *MockBookingService) GetBookingsByHotelID(ctx context.Context, hotelID, userID int, filter *models.BookingFilter) (*models.BookingPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBookingsByHotelID", ctx, hotelID, userID)
-	ret0, _ := ret[0].([]*models.BookingInfo)
+	ret := m.ctrl.Call(m, "GetBookingsByHotelID", ctx, hotelID, userID, filter)
+	ret0, _ := ret[0].(*models.BookingPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBookingsByHotelID indicates an expected call of GetBookingsByHotelID.
-func (mr *MockBookingServiceMockRecorder) GetBookingsByHotelID(ctx, hotelID, userID interface{}) *gomock.Call {
+func (mr *MockBookingServiceMockRecorder) GetBookingsByHotelID(ctx, hotelID, userID, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByHotelID", reflect.TypeOf((*MockBookingService)(nil).GetBookingsByHotelID), ctx, hotelID, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByHotelID", reflect.TypeOf((*MockBookingService)(nil).GetBookingsByHotelID), ctx, hotelID, userID, filter)
 }
 
 // GetBookingsByUserID mocks base method.
-func (m *MockBookingService) GetBookingsByUserID(ctx context.Context, userID int) ([]*models.BookingInfo, error) {
+func (m *MockBookingService) GetBookingsByUserID(ctx context.Context, userID int, filter *models.BookingFilter) (*models.BookingPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetBookingsByUserID", ctx, userID)
-	ret0, _ := ret[0].([]*models.BookingInfo)
+	ret := m.ctrl.Call(m, "GetBookingsByUserID", ctx, userID, filter)
+	ret0, _ := ret[0].(*models.BookingPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetBookingsByUserID indicates an expected call of GetBookingsByUserID.
-func (mr *MockBookingServiceMockRecorder) GetBookingsByUserID(ctx, userID interface{}) *gomock.Call {
+func (mr *MockBookingServiceMockRecorder) GetBookingsByUserID(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByUserID", reflect.TypeOf((*MockBookingService)(nil).GetBookingsByUserID), ctx, userID, filter)
+}
+
+// GetHotelReport mocks base method.
+func (m *MockBookingService) GetHotelReport(ctx context.Context, req *models.ReportRequest, userID int) (*models.HotelReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotelReport", ctx, req, userID)
+	ret0, _ := ret[0].(*models.HotelReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotelReport indicates an expected call of GetHotelReport.
+func (mr *MockBookingServiceMockRecorder) GetHotelReport(ctx, req, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelReport", reflect.TypeOf((*MockBookingService)(nil).GetHotelReport), ctx, req, userID)
+}
+
+// GetICalImports mocks base method.
+func (m *MockBookingService) GetICalImports(ctx context.Context, hotelID, userID int) ([]*models.ICalImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetICalImports", ctx, hotelID, userID)
+	ret0, _ := ret[0].([]*models.ICalImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetICalImports indicates an expected call of GetICalImports.
+func (mr *MockBookingServiceMockRecorder) GetICalImports(ctx, hotelID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetICalImports", reflect.TypeOf((*MockBookingService)(nil).GetICalImports), ctx, hotelID, userID)
+}
+
+// GetOccupancyCalendar mocks base method.
+func (m *MockBookingService) GetOccupancyCalendar(ctx context.Context, hotelID, userID int, month time.Time) (*models.OccupancyCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOccupancyCalendar", ctx, hotelID, userID, month)
+	ret0, _ := ret[0].(*models.OccupancyCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOccupancyCalendar indicates an expected call of GetOccupancyCalendar.
+func (mr *MockBookingServiceMockRecorder) GetOccupancyCalendar(ctx, hotelID, userID, month interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOccupancyCalendar", reflect.TypeOf((*MockBookingService)(nil).GetOccupancyCalendar), ctx, hotelID, userID, month)
+}
+
+// JoinWaitlist mocks base method.
+func (m *MockBookingService) JoinWaitlist(ctx context.Context, waitlistRequest *models.WaitlistRequest, user *models.User) (*models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "JoinWaitlist", ctx, waitlistRequest, user)
+	ret0, _ := ret[0].(*models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// JoinWaitlist indicates an expected call of JoinWaitlist.
+func (mr *MockBookingServiceMockRecorder) JoinWaitlist(ctx, waitlistRequest, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "JoinWaitlist", reflect.TypeOf((*MockBookingService)(nil).JoinWaitlist), ctx, waitlistRequest, user)
+}
+
+// MarkNoShow mocks base method.
+func (m *MockBookingService) MarkNoShow(ctx context.Context, bookingID, userID int, releaseRemainingNights bool) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkNoShow", ctx, bookingID, userID, releaseRemainingNights)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkNoShow indicates an expected call of MarkNoShow.
+func (mr *MockBookingServiceMockRecorder) MarkNoShow(ctx, bookingID, userID, releaseRemainingNights interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNoShow", reflect.TypeOf((*MockBookingService)(nil).MarkNoShow), ctx, bookingID, userID, releaseRemainingNights)
+}
+
+// ModifyBooking mocks base method.
+func (m *MockBookingService) ModifyBooking(ctx context.Context, bookingID int, updateRequest *models.BookingUpdateRequest, user *models.User) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModifyBooking", ctx, bookingID, updateRequest, user)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModifyBooking indicates an expected call of ModifyBooking.
+func (mr *MockBookingServiceMockRecorder) ModifyBooking(ctx, bookingID, updateRequest, user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModifyBooking", reflect.TypeOf((*MockBookingService)(nil).ModifyBooking), ctx, bookingID, updateRequest, user)
+}
+
+// RevokeICalFeed mocks base method.
+func (m *MockBookingService) RevokeICalFeed(ctx context.Context, token string, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeICalFeed", ctx, token, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeICalFeed indicates an expected call of RevokeICalFeed.
+func (mr *MockBookingServiceMockRecorder) RevokeICalFeed(ctx, token, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeICalFeed", reflect.TypeOf((*MockBookingService)(nil).RevokeICalFeed), ctx, token, userID)
+}
+
+// SearchHotels mocks base method.
+func (m *MockBookingService) SearchHotels(ctx context.Context, searchRequest *models.HotelSearchRequest) ([]*models.HotelSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchHotels", ctx, searchRequest)
+	ret0, _ := ret[0].([]*models.HotelSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchHotels indicates an expected call of SearchHotels.
+func (mr *MockBookingServiceMockRecorder) SearchHotels(ctx, searchRequest interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByUserID", reflect.TypeOf((*MockBookingService)(nil).GetBookingsByUserID), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchHotels", reflect.TypeOf((*MockBookingService)(nil).SearchHotels), ctx, searchRequest)
 }
 
 // UpdateBookingStatus mocks base method.
-func (m *MockBookingService) UpdateBookingStatus(ctx context.Context, status string, bookingMessage *models.BookingMessage) error {
+func (m *MockBookingService) UpdateBookingStatus(ctx context.Context, webhook *models.PaymentWebhook) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateBookingStatus", ctx, status, bookingMessage)
+	ret := m.ctrl.Call(m, "UpdateBookingStatus", ctx, webhook)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // UpdateBookingStatus indicates an expected call of UpdateBookingStatus.
-func (mr *MockBookingServiceMockRecorder) UpdateBookingStatus(ctx, status, bookingMessage interface{}) *gomock.Call {
+func (mr *MockBookingServiceMockRecorder) UpdateBookingStatus(ctx, webhook interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingStatus", reflect.TypeOf((*MockBookingService)(nil).UpdateBookingStatus), ctx, status, bookingMessage)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingStatus", reflect.TypeOf((*MockBookingService)(nil).UpdateBookingStatus), ctx, webhook)
 }
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// Источник блокировки комнаты
+const (
+	BlockSourceICal        = "ical"        // Занято на другой площадке, пришло из импорта iCal
+	BlockSourceMaintenance = "maintenance" // Владелец снял комнату с продажи: ремонт или личное использование
+)
+
+const MaxBlockReasonLength = 500
+
+// RoomBlock - период [StartDate, EndDate), когда комната недоступна без бронирования в BookingSvc
+type RoomBlock struct {
+	ID          int64     `json:"id"`
+	HotelID     int       `json:"hotel_id"`
+	RoomID      int       `json:"room_id"`
+	StartDate   time.Time `json:"start_date"`
+	EndDate     time.Time `json:"end_date"`
+	Source      string    `json:"source"`
+	ImportID    int       `json:"import_id,omitempty"`
+	ExternalUID string    `json:"external_uid,omitempty"`
+	Summary     string    `json:"summary,omitempty"`
+	Reason      string    `json:"reason,omitempty"` // Причина блокировки владельцем
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RoomBlockRequest - снятие комнаты с продажи владельцем отеля на период [StartDate, EndDate)
+type RoomBlockRequest struct {
+	HotelID   int       `json:"hotel_id"`
+	RoomID    int       `json:"room_id"`
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Reason    string    `json:"reason"`
+}
+
+// Validate проверяет период и причину. Блокировка должна закончиться в будущем.
+func (req *RoomBlockRequest) Validate(now time.Time) error {
+	if req.HotelID <= 0 || req.RoomID <= 0 {
+		return fmt.Errorf("invalid hotel or room id")
+	}
+	if !req.EndDate.After(req.StartDate) {
+		return fmt.Errorf("empty period")
+	}
+	if !req.EndDate.After(now) {
+		return fmt.Errorf("period is in the past")
+	}
+	if req.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if utf8.RuneCountInString(req.Reason) > MaxBlockReasonLength {
+		return fmt.Errorf("reason is longer than %d characters", MaxBlockReasonLength)
+	}
+	return nil
+}
+
+func (req *RoomBlockRequest) ToRoomBlock() *RoomBlock {
+	return &RoomBlock{
+		HotelID:   req.HotelID,
+		RoomID:    req.RoomID,
+		StartDate: req.StartDate.UTC(),
+		EndDate:   req.EndDate.UTC(),
+		Source:    BlockSourceMaintenance,
+		Reason:    req.Reason,
+	}
+}
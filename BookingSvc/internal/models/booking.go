@@ -11,19 +11,78 @@ type BookingRequest struct {
 	RoomBasePrice   int    `json:"room_base_price"`
 
 	CardNumber    string    `json:"card_number"`
-	CountOfPeople int       `json:"count_of_people"`
+	CountOfPeople int       `json:"count_of_people"` // Все гости, можно не указывать вместе с adults и children
+	Adults        int       `json:"adults"`
+	Children      int       `json:"children"`
 	StartDate     time.Time `json:"start_date"`
 	EndDate       time.Time `json:"end_date"`
 
-	Amount int `json:"-"`
+	PromoCode string `json:"promo_code"`
+
+	Amount         int    `json:"-"`
+	IdempotencyKey string `json:"-"` // Из заголовка Idempotency-Key
+}
+
+// Booking - полная запись о бронировании из хранилища
+type Booking struct {
+	ID              int         `json:"id"`
+	UserID          int         `json:"user_id"`
+	RoomID          int         `json:"room_id"`
+	HotelID         int         `json:"hotel_id"`
+	Status          string      `json:"status"`
+	Amount          int         `json:"amount"`
+	CountOfPeople   int         `json:"count_of_people"`
+	Children        int         `json:"children"` // Сколько из CountOfPeople гостей - дети
+	HotelName       string      `json:"hotel_name"`
+	RoomDescription string      `json:"room_description"`
+	RoomNumber      int         `json:"room_number"`
+	Username        string      `json:"username"`
+	ChatID          string      `json:"-"`
+	StartDate       time.Time   `json:"start_date"`
+	EndDate         time.Time   `json:"end_date"`
+	CreatedAt       time.Time   `json:"created_at"`
+	HoldExpiresAt   *time.Time  `json:"hold_expires_at,omitempty"` // Срок ожидания оплаты для статуса pending_payment
+	Price           *PriceQuote `json:"price,omitempty"`
+	IdempotencyKey  string      `json:"-"`
+	PromoCodeID     int         `json:"-"` // ID промокода в HotelSvc, 0 если код не применялся
+	GroupID         int         `json:"group_id,omitempty"`
+	UpdatedAt       *time.Time  `json:"updated_at,omitempty"`        // Последнее изменение дат, комнаты или статуса
+	CancelledAt     *time.Time  `json:"cancelled_at,omitempty"`      // Время отмены гостем
+	StatusChangedAt *time.Time  `json:"status_changed_at,omitempty"` // Последняя смена статуса
+
+	PendingModification *BookingModification `json:"pending_modification,omitempty"` // Изменение, ожидающее доплаты
+}
+
+// Guests - состав гостей бронирования, все гости кроме детей - взрослые
+func (booking *Booking) Guests() Guests {
+	return Guests{Adults: booking.CountOfPeople - booking.Children, Children: booking.Children}
+}
+
+// Nights - число ночей проживания по календарным датам UTC, день выезда не считается
+func (booking *Booking) Nights() int {
+	return int(truncateToDate(booking.EndDate).Sub(truncateToDate(booking.StartDate)) / (24 * time.Hour))
 }
 
-type BookingInfo struct {
-	UserID    int       `json:"user_id"`
-	RoomID    int       `json:"room_id"`
-	HotelID   int       `json:"hotel_id"`
-	StartDate time.Time `json:"start_date"`
-	EndDate   time.Time `json:"end_date"`
+// BookingDetails - бронирование с историей статусов для гостя и владельца отеля
+type BookingDetails struct {
+	*Booking
+	StatusHistory []*StatusChange `json:"status_history"`
+}
+
+// BookingUpdateRequest - изменение бронирования, незаданные поля остаются прежними
+type BookingUpdateRequest struct {
+	RoomID        *int       `json:"room_id"`
+	CountOfPeople *int       `json:"count_of_people"`
+	Adults        *int       `json:"adults"`
+	Children      *int       `json:"children"`
+	StartDate     *time.Time `json:"start_date"`
+	EndDate       *time.Time `json:"end_date"`
+	CardNumber    string     `json:"card_number"` // Нужен, если новая стоимость выше оплаченной
+}
+
+// NoShowRequest - отметка о неявке гостя. ReleaseRemainingNights возвращает в продажу ночи после первой.
+type NoShowRequest struct {
+	ReleaseRemainingNights bool `json:"release_remaining_nights"`
 }
 
 type User struct {
@@ -32,14 +91,91 @@ type User struct {
 	ChatID   string `json:"chat_id"`
 }
 
-func (req *BookingRequest) ToBookingInfo(userID int) *BookingInfo {
-	return &BookingInfo{
-		UserID:    userID,
-		RoomID:    req.RoomID,
-		HotelID:   req.HotelID,
-		StartDate: req.StartDate,
-		EndDate:   req.EndDate,
+func (req *BookingRequest) ToBooking(user *User) *Booking {
+	return &Booking{
+		UserID:          user.UserID,
+		Username:        user.Username,
+		ChatID:          user.ChatID,
+		RoomID:          req.RoomID,
+		HotelID:         req.HotelID,
+		Status:          StatusPendingPayment,
+		Amount:          req.Amount,
+		CountOfPeople:   req.CountOfPeople,
+		Children:        req.Children,
+		HotelName:       req.HotelName,
+		RoomDescription: req.RoomDescription,
+		RoomNumber:      req.RoomNumber,
+		StartDate:       req.StartDate,
+		EndDate:         req.EndDate,
+		IdempotencyKey:  req.IdempotencyKey,
+	}
+}
+
+// NormalizeGuests заполняет CountOfPeople, Adults и Children согласованно, см. NewGuests
+func (req *BookingRequest) NormalizeGuests() bool {
+	guests, ok := NewGuests(req.CountOfPeople, req.Adults, req.Children)
+	if !ok {
+		return false
+	}
+	req.CountOfPeople, req.Adults, req.Children = guests.Total(), guests.Adults, guests.Children
+	return true
+}
+
+// MatchesRequest проверяет, что повторный запрос с тем же ключом идемпотентности бронирует то же самое.
+// Postgres хранит время с точностью до микросекунд, поэтому даты запроса округляются.
+func (booking *Booking) MatchesRequest(req *BookingRequest) bool {
+	return booking.RoomID == req.RoomID &&
+		booking.HotelID == req.HotelID &&
+		booking.CountOfPeople == req.CountOfPeople &&
+		booking.Children == req.Children &&
+		booking.StartDate.Equal(req.StartDate.Round(time.Microsecond)) &&
+		booking.EndDate.Equal(req.EndDate.Round(time.Microsecond)) &&
+		SameCode(booking.PromoCodeOf(), req.PromoCode)
+}
+
+// Apply возвращает копию бронирования с применёнными изменениями
+func (req *BookingUpdateRequest) Apply(booking *Booking) *Booking {
+	updated := *booking
+	if req.RoomID != nil {
+		updated.RoomID = *req.RoomID
+	}
+	if req.Adults != nil || req.Children != nil {
+		guests := booking.Guests()
+		if req.Adults != nil {
+			guests.Adults = *req.Adults
+		}
+		if req.Children != nil {
+			guests.Children = *req.Children
+		}
+		updated.CountOfPeople, updated.Children = guests.Total(), guests.Children
+	} else if req.CountOfPeople != nil {
+		// Задано только общее число гостей: дети остаются, меняется число взрослых
+		updated.CountOfPeople = *req.CountOfPeople
+	}
+	if req.StartDate != nil {
+		updated.StartDate = req.StartDate.UTC()
+	}
+	if req.EndDate != nil {
+		updated.EndDate = req.EndDate.UTC()
+	}
+	return &updated
+}
+
+// NewBookingDetails дополняет бронирование историей его статусов
+func NewBookingDetails(booking *Booking, history []*StatusChange) *BookingDetails {
+	if history == nil {
+		history = []*StatusChange{}
+	}
+	return &BookingDetails{Booking: booking, StatusHistory: history}
+}
+
+// DetailsList дополняет каждое бронирование списка его историей статусов из history
+func DetailsList(bookings []*Booking, history map[int][]*StatusChange) []*BookingDetails {
+	details := make([]*BookingDetails, 0, len(bookings))
+	for _, booking := range bookings {
+		details = append(details, NewBookingDetails(booking, history[booking.ID]))
 	}
+	return details
 }
 
 func NewUser(userID int, username string, chatID string) *User {
@@ -0,0 +1,105 @@
+package models
+
+import "time"
+
+// Состояние комнаты в день календаря занятости
+const (
+	CellFree    = "free"
+	CellBooked  = "booked"  // Оплаченное бронирование
+	CellHeld    = "held"    // Бронирование ждёт оплату
+	CellBlocked = "blocked" // Комната снята с продажи
+)
+
+const (
+	CalendarDayLayout   = "2006-01-02"
+	CalendarMonthLayout = "2006-01"
+)
+
+// CalendarCell - состояние комнаты в один день (ночь с Date на следующий день)
+type CalendarCell struct {
+	Date      string `json:"date"`
+	State     string `json:"state"`
+	BookingID int    `json:"booking_id,omitempty"`
+}
+
+type CalendarRoom struct {
+	RoomID      int            `json:"room_id"`
+	RoomNumber  int            `json:"room_number"`
+	Description string         `json:"description"`
+	Days        []CalendarCell `json:"days"`
+}
+
+// OccupancyCalendar - занятость всех комнат отеля по дням месяца
+type OccupancyCalendar struct {
+	HotelID int             `json:"hotel_id"`
+	Month   string          `json:"month"`
+	Rooms   []*CalendarRoom `json:"rooms"`
+}
+
+// NewOccupancyCalendar заполняет все дни месяца month свободными для каждой комнаты rooms
+func NewOccupancyCalendar(hotelID int, month time.Time, rooms []*CalendarRoom) *OccupancyCalendar {
+	start, end := MonthBounds(month)
+	for _, room := range rooms {
+		room.Days = make([]CalendarCell, 0, 31)
+		for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+			room.Days = append(room.Days, CalendarCell{Date: day.Format(CalendarDayLayout), State: CellFree})
+		}
+	}
+	return &OccupancyCalendar{HotelID: hotelID, Month: start.Format(CalendarMonthLayout), Rooms: rooms}
+}
+
+// MonthBounds - первый день месяца и первый день следующего месяца в UTC
+func MonthBounds(month time.Time) (time.Time, time.Time) {
+	month = month.UTC()
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// DayBounds - начало дня day и начало следующего дня в UTC
+func DayBounds(day time.Time) (time.Time, time.Time) {
+	start := truncateToDate(day)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// Occupy отмечает ночи бронирования в календаре. День выезда остаётся свободным для следующего гостя.
+func (calendar *OccupancyCalendar) Occupy(booking *Booking, state string) {
+	calendar.mark(booking.RoomID, booking.ID, booking.StartDate, booking.EndDate, state)
+}
+
+// Block отмечает ночи блокировки комнаты как снятые с продажи
+func (calendar *OccupancyCalendar) Block(block *RoomBlock) {
+	calendar.mark(block.RoomID, 0, block.StartDate, block.EndDate, CellBlocked)
+}
+
+func (calendar *OccupancyCalendar) mark(roomID, bookingID int, startDate, endDate time.Time, state string) {
+	month, err := time.Parse(CalendarMonthLayout, calendar.Month)
+	if err != nil {
+		return
+	}
+	start, end := MonthBounds(month)
+	first, last := truncateToDate(startDate), truncateToDate(endDate)
+	if !last.After(first) {
+		last = first.AddDate(0, 0, 1)
+	}
+	for _, room := range calendar.Rooms {
+		if room.RoomID != roomID {
+			continue
+		}
+		for day := first; day.Before(last); day = day.AddDate(0, 0, 1) {
+			if day.Before(start) || !day.Before(end) {
+				continue
+			}
+			index := int(day.Sub(start).Hours() / 24)
+			room.Days[index].State = state
+			room.Days[index].BookingID = bookingID
+		}
+	}
+}
+
+// CalendarState - состояние ячейки для активного бронирования
+func (booking *Booking) CalendarState() string {
+	if booking.Status == StatusPendingPayment {
+		return CellHeld
+	}
+	return CellBooked
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Форматы выгрузки бронирований
+const (
+	ExportFormatCSV    = "csv"
+	ExportFormatNDJSON = "ndjson" // Один JSON объект на строку
+)
+
+// BookingExportHeader - заголовок CSV выгрузки, порядок совпадает с BookingExportRow.CSVRecord
+var BookingExportHeader = []string{
+	"booking_id", "guest_name", "room_number", "start_date", "end_date", "nights",
+	"amount", "status", "payment_reference", "created_at",
+}
+
+// BookingExportRow - бронирование в выгрузке для бухгалтерии.
+// PaymentReference - номер заказа в PaymentSystem, у групповых бронирований общий на группу.
+type BookingExportRow struct {
+	BookingID        int       `json:"booking_id"`
+	GuestName        string    `json:"guest_name"`
+	RoomNumber       int       `json:"room_number"`
+	StartDate        time.Time `json:"start_date"`
+	EndDate          time.Time `json:"end_date"`
+	Nights           int       `json:"nights"`
+	Amount           int       `json:"amount"`
+	Status           string    `json:"status"`
+	PaymentReference string    `json:"payment_reference"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func NewBookingExportRow(booking *Booking) *BookingExportRow {
+	return &BookingExportRow{
+		BookingID:        booking.ID,
+		GuestName:        booking.Username,
+		RoomNumber:       booking.RoomNumber,
+		StartDate:        booking.StartDate.UTC(),
+		EndDate:          booking.EndDate.UTC(),
+		Nights:           booking.Nights(),
+		Amount:           booking.Amount,
+		Status:           booking.Status,
+		PaymentReference: booking.OrderID(),
+		CreatedAt:        booking.CreatedAt.UTC(),
+	}
+}
+
+// CSVRecord - строка CSV выгрузки в порядке BookingExportHeader
+func (row *BookingExportRow) CSVRecord() []string {
+	return []string{
+		strconv.Itoa(row.BookingID),
+		csvText(row.GuestName),
+		strconv.Itoa(row.RoomNumber),
+		row.StartDate.Format(time.RFC3339),
+		row.EndDate.Format(time.RFC3339),
+		strconv.Itoa(row.Nights),
+		strconv.Itoa(row.Amount),
+		row.Status,
+		row.PaymentReference,
+		row.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// csvText не даёт табличным редакторам принять текст гостя за формулу
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// NormalizeExport проверяет фильтр выгрузки: период обязателен, выгрузка идёт целиком без страниц
+func (filter *BookingFilter) NormalizeExport() error {
+	if filter.From == nil || filter.To == nil {
+		return fmt.Errorf("export needs from and to")
+	}
+	if filter.Limit != 0 || filter.Cursor != nil {
+		return fmt.Errorf("export is not paginated")
+	}
+	if filter.Sort == "" {
+		filter.Sort = SortStartAsc
+	}
+	if err := filter.Normalize(); err != nil {
+		return err
+	}
+	// Без LIMIT хранилище читает все бронирования периода курсором
+	filter.Limit = 0
+	return nil
+}
@@ -0,0 +1,131 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Порядок сортировки списков бронирований
+const (
+	SortCreatedDesc = "created_desc" // Новые первыми, по умолчанию
+	SortCreatedAsc  = "created_asc"
+	SortStartAsc    = "start_asc" // Ближайшие заезды первыми
+	SortStartDesc   = "start_desc"
+)
+
+// Как период From-To фильтрует бронирования
+const (
+	DateFilterOverlap    = ""           // Проживание пересекается с периодом
+	DateFilterArrivals   = "arrivals"   // Заезд в периоде
+	DateFilterDepartures = "departures" // Выезд в периоде
+	DateFilterInHouse    = "in_house"   // Гость проживает весь период, без To - в момент From
+)
+
+const (
+	DefaultBookingPageSize = 50
+	MaxBookingPageSize     = 200
+)
+
+// BookingFilter - фильтры и страница списка бронирований. Пустые поля не фильтруют.
+type BookingFilter struct {
+	Statuses   []string
+	From       *time.Time
+	To         *time.Time
+	DateFilter string
+	RoomID     int
+	Sort       string
+	Limit      int
+	Cursor     *BookingCursor // Последнее бронирование предыдущей страницы
+}
+
+// BookingCursor - позиция в списке: значение ключа сортировки и ID последнего бронирования страницы
+type BookingCursor struct {
+	Sort string    `json:"s"`
+	At   time.Time `json:"t"`
+	ID   int       `json:"id"`
+}
+
+// BookingPage - страница списка бронирований. NextCursor пустой на последней странице.
+// Blocks - снятые с продажи периоды комнат, только в списке отеля.
+type BookingPage struct {
+	Bookings   []*BookingDetails `json:"bookings"`
+	Blocks     []*RoomBlock      `json:"blocks,omitempty"`
+	NextCursor string            `json:"next_cursor,omitempty"`
+}
+
+// Normalize подставляет значения по умолчанию и проверяет фильтр
+func (filter *BookingFilter) Normalize() error {
+	if filter.Sort == "" {
+		filter.Sort = SortCreatedDesc
+	}
+	switch filter.Sort {
+	case SortCreatedDesc, SortCreatedAsc, SortStartAsc, SortStartDesc:
+	default:
+		return fmt.Errorf("unknown sort %q", filter.Sort)
+	}
+	switch filter.DateFilter {
+	case DateFilterOverlap, DateFilterArrivals, DateFilterDepartures, DateFilterInHouse:
+	default:
+		return fmt.Errorf("unknown date filter %q", filter.DateFilter)
+	}
+	if filter.DateFilter == DateFilterInHouse && filter.From == nil {
+		return fmt.Errorf("date filter %q needs from", filter.DateFilter)
+	}
+	if filter.DateFilter != DateFilterOverlap && filter.From == nil && filter.To == nil {
+		return fmt.Errorf("date filter %q needs from or to", filter.DateFilter)
+	}
+	if filter.From != nil && filter.To != nil && !filter.To.After(*filter.From) {
+		return fmt.Errorf("empty period")
+	}
+	for _, status := range filter.Statuses {
+		if !IsBookingStatus(status) {
+			return fmt.Errorf("unknown status %q", status)
+		}
+	}
+	if filter.RoomID < 0 {
+		return fmt.Errorf("invalid room id")
+	}
+	if filter.Limit == 0 {
+		filter.Limit = DefaultBookingPageSize
+	}
+	if filter.Limit < 0 || filter.Limit > MaxBookingPageSize {
+		return fmt.Errorf("limit must be between 1 and %d", MaxBookingPageSize)
+	}
+	if filter.Cursor != nil && filter.Cursor.Sort != filter.Sort {
+		return fmt.Errorf("cursor was issued for sort %q", filter.Cursor.Sort)
+	}
+	return nil
+}
+
+// SortKey - значение, по которому бронирование упорядочено при сортировке sort
+func (booking *Booking) SortKey(sort string) time.Time {
+	if sort == SortStartAsc || sort == SortStartDesc {
+		return booking.StartDate
+	}
+	return booking.CreatedAt
+}
+
+// NewBookingCursor - курсор на следующую страницу после booking
+func NewBookingCursor(sort string, booking *Booking) *BookingCursor {
+	return &BookingCursor{Sort: sort, At: booking.SortKey(sort), ID: booking.ID}
+}
+
+// Encode упаковывает курсор в непрозрачную строку для query-параметра cursor
+func (cursor *BookingCursor) Encode() string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeBookingCursor(value string) (*BookingCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	var cursor BookingCursor
+	if err = json.Unmarshal(data, &cursor); err != nil || cursor.ID <= 0 {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &cursor, nil
+}
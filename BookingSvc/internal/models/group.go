@@ -0,0 +1,116 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// GroupRoomRequest - комната в групповом бронировании
+type GroupRoomRequest struct {
+	RoomID        int `json:"room_id"`
+	CountOfPeople int `json:"count_of_people"`
+	Adults        int `json:"adults"`
+	Children      int `json:"children"`
+}
+
+// NormalizeGuests заполняет CountOfPeople, Adults и Children согласованно, см. NewGuests
+func (room *GroupRoomRequest) NormalizeGuests() bool {
+	guests, ok := NewGuests(room.CountOfPeople, room.Adults, room.Children)
+	if !ok {
+		return false
+	}
+	room.CountOfPeople, room.Adults, room.Children = guests.Total(), guests.Adults, guests.Children
+	return true
+}
+
+// Guests - состав гостей комнаты после NormalizeGuests
+func (room *GroupRoomRequest) Guests() Guests {
+	return Guests{Adults: room.Adults, Children: room.Children}
+}
+
+// GroupBookingRequest - бронирование нескольких комнат одного отеля на одни даты
+type GroupBookingRequest struct {
+	HotelID    int                `json:"hotel_id"`
+	Rooms      []GroupRoomRequest `json:"rooms"`
+	CardNumber string             `json:"card_number"`
+	StartDate  time.Time          `json:"start_date"`
+	EndDate    time.Time          `json:"end_date"`
+}
+
+// GroupBooking - бронирования группы, создаются и оплачиваются вместе
+type GroupBooking struct {
+	ID            int        `json:"id"`
+	UserID        int        `json:"user_id"`
+	HotelID       int        `json:"hotel_id"`
+	Amount        int        `json:"amount"`
+	StartDate     time.Time  `json:"start_date"`
+	EndDate       time.Time  `json:"end_date"`
+	HoldExpiresAt *time.Time `json:"hold_expires_at,omitempty"`
+	Bookings      []*Booking `json:"bookings"`
+}
+
+func (req *GroupBookingRequest) ToBooking(room GroupRoomRequest, user *User) *Booking {
+	return &Booking{
+		UserID:        user.UserID,
+		Username:      user.Username,
+		ChatID:        user.ChatID,
+		RoomID:        room.RoomID,
+		HotelID:       req.HotelID,
+		Status:        StatusPendingPayment,
+		CountOfPeople: room.CountOfPeople,
+		Children:      room.Children,
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+	}
+}
+
+// NewGroupBooking собирает группу из сохранённых бронирований, все они на одни даты в одном отеле
+func NewGroupBooking(groupID int, bookings []*Booking) *GroupBooking {
+	group := &GroupBooking{ID: groupID, Bookings: bookings}
+	for _, booking := range bookings {
+		group.UserID = booking.UserID
+		group.HotelID = booking.HotelID
+		group.StartDate = booking.StartDate
+		group.EndDate = booking.EndDate
+		group.HoldExpiresAt = booking.HoldExpiresAt
+		group.Amount += booking.Amount
+	}
+	return group
+}
+
+// ToBookingMessage - одно уведомление на всю группу со списком комнат
+func (group *GroupBooking) ToBookingMessage(event, username, chatID, startDate, endDate string) *BookingMessage {
+	message := &BookingMessage{
+		Event:     event,
+		GroupID:   group.ID,
+		HotelID:   group.HotelID,
+		Username:  username,
+		ChatID:    chatID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		Rooms:     make([]BookingMessageRoom, 0, len(group.Bookings)),
+	}
+	for _, booking := range group.Bookings {
+		message.HotelName = booking.HotelName
+		message.Rooms = append(message.Rooms, BookingMessageRoom{
+			BookingID:       booking.ID,
+			RoomDescription: booking.RoomDescription,
+			RoomNumber:      booking.RoomNumber,
+			CountOfPeople:   booking.CountOfPeople,
+		})
+	}
+	return message
+}
+
+// GroupOrderID - номер общего заказа группы в платёжной системе
+func GroupOrderID(groupID int) string {
+	return "group-" + strconv.Itoa(groupID)
+}
+
+// OrderID - номер заказа в платёжной системе, по которому оплачено бронирование
+func (booking *Booking) OrderID() string {
+	if booking.GroupID != 0 {
+		return GroupOrderID(booking.GroupID)
+	}
+	return strconv.Itoa(booking.ID)
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ICalFeedRequest - запрос ссылки на выгрузку .ics. Без RoomID выгружается весь отель.
+type ICalFeedRequest struct {
+	HotelID int `json:"hotel_id"`
+	RoomID  int `json:"room_id,omitempty"`
+}
+
+// ICalFeed - секретная ссылка на выгрузку занятости отеля или комнаты, открывается без JWT
+type ICalFeed struct {
+	Token     string    `json:"token"`
+	HotelID   int       `json:"hotel_id"`
+	RoomID    int       `json:"room_id,omitempty"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ICalImportRequest - подписка комнаты на календарь другой площадки
+type ICalImportRequest struct {
+	HotelID int    `json:"hotel_id"`
+	RoomID  int    `json:"room_id"`
+	URL     string `json:"url"`
+}
+
+// ICalImport - календарь другой площадки, события которого блокируют комнату.
+// LastError пустой, если последняя синхронизация прошла успешно.
+type ICalImport struct {
+	ID           int        `json:"id"`
+	HotelID      int        `json:"hotel_id"`
+	RoomID       int        `json:"room_id"`
+	URL          string     `json:"url"`
+	CreatedAt    time.Time  `json:"created_at"`
+	LastSyncedAt *time.Time `json:"last_synced_at,omitempty"`
+	LastError    string     `json:"last_error,omitempty"`
+}
+
+func (req *ICalImportRequest) ToICalImport() *ICalImport {
+	return &ICalImport{
+		HotelID: req.HotelID,
+		RoomID:  req.RoomID,
+		URL:     req.URL,
+	}
+}
@@ -1,6 +1,16 @@
 package models
 
+// События бронирования, передаются в NotificationSvc
+const (
+	EventBookingCreated   = "created"
+	EventBookingCancelled = "cancelled"
+	EventBookingModified  = "modified"
+	EventBookingExpired   = "expired"
+	EventWaitlistOffer    = "waitlist_offer" // Освободилась комната для гостя из листа ожидания
+)
+
 type BookingMessage struct {
+	Event           string `json:"event"`
 	BookingID       int    `json:"booking_id"`
 	HotelID         int    `json:"hotel_id"`
 	HotelName       string `json:"hotel_name"`
@@ -10,10 +20,27 @@ type BookingMessage struct {
 	ChatID          string `json:"chat_id"`
 	StartDate       string `json:"start_date"`
 	EndDate         string `json:"end_date"`
+
+	// Для группового бронирования - ID группы и все её комнаты
+	GroupID int                  `json:"group_id,omitempty"`
+	Rooms   []BookingMessageRoom `json:"rooms,omitempty"`
+
+	// Для предложения из листа ожидания - ссылка на бронирование и срок её действия
+	ClaimURL       string `json:"claim_url,omitempty"`
+	ClaimExpiresAt string `json:"claim_expires_at,omitempty"`
+}
+
+// BookingMessageRoom - комната группового бронирования в уведомлении
+type BookingMessageRoom struct {
+	BookingID       int    `json:"booking_id"`
+	RoomDescription string `json:"room_description"`
+	RoomNumber      int    `json:"room_number"`
+	CountOfPeople   int    `json:"count_of_people"`
 }
 
 func (req *BookingRequest) ToBookingMessage(bookingID int, username, chatID, startDate, endDate string) *BookingMessage {
 	return &BookingMessage{
+		Event:           EventBookingCreated,
 		BookingID:       bookingID,
 		HotelID:         req.HotelID,
 		HotelName:       req.HotelName,
@@ -26,9 +53,26 @@ func (req *BookingRequest) ToBookingMessage(bookingID int, username, chatID, sta
 	}
 }
 
+func (booking *Booking) ToBookingMessage(event, username, chatID, startDate, endDate string) *BookingMessage {
+	return &BookingMessage{
+		Event:           event,
+		BookingID:       booking.ID,
+		HotelID:         booking.HotelID,
+		HotelName:       booking.HotelName,
+		RoomDescription: booking.RoomDescription,
+		RoomNumber:      booking.RoomNumber,
+		StartDate:       startDate,
+		EndDate:         endDate,
+		Username:        username,
+		ChatID:          chatID,
+	}
+}
+
 func (message *BookingMessage) ToHotelierMessage(hotelierName string, hotelierChatID string) *BookingMessage {
 	return &BookingMessage{
+		Event:           message.Event,
 		BookingID:       message.BookingID,
+		HotelID:         message.HotelID,
 		HotelName:       message.HotelName,
 		RoomDescription: message.RoomDescription,
 		RoomNumber:      message.RoomNumber,
@@ -36,5 +80,7 @@ func (message *BookingMessage) ToHotelierMessage(hotelierName string, hotelierCh
 		EndDate:         message.EndDate,
 		Username:        hotelierName,
 		ChatID:          hotelierChatID,
+		GroupID:         message.GroupID,
+		Rooms:           message.Rooms,
 	}
 }
@@ -0,0 +1,78 @@
+package models
+
+import (
+	"strconv"
+	"time"
+)
+
+// Статусы изменения бронирования, ожидающего доплаты
+const (
+	ModificationPending       = "pending"        // Доплата ещё не пришла
+	ModificationApplied       = "applied"        // Доплата прошла, новые условия сохранены в бронировании
+	ModificationPaymentFailed = "payment_failed" // Доплата не прошла, бронирование осталось на прежних условиях
+	ModificationExpired       = "expired"        // Доплата не пришла за срок ожидания
+	ModificationRefunded      = "refunded"       // Доплата пришла, но изменение уже нельзя применить, доплата возвращена
+)
+
+// BookingModification - изменение оплаченного бронирования, которое требует доплаты.
+// Пока доплата не пройдёт, бронирование остаётся подтверждённым на прежних условиях, а новые хранятся здесь.
+type BookingModification struct {
+	ID              int         `json:"id"`
+	BookingID       int         `json:"booking_id"`
+	Status          string      `json:"status"`
+	RoomID          int         `json:"room_id"`
+	RoomDescription string      `json:"room_description"`
+	RoomNumber      int         `json:"room_number"`
+	CountOfPeople   int         `json:"count_of_people"`
+	Children        int         `json:"children"`
+	StartDate       time.Time   `json:"start_date"`
+	EndDate         time.Time   `json:"end_date"`
+	Amount          int         `json:"amount"`    // Новая стоимость бронирования
+	Surcharge       int         `json:"surcharge"` // Доплата к уже оплаченной стоимости
+	Price           *PriceQuote `json:"price,omitempty"`
+	PromoCodeID     int         `json:"-"`
+	HoldExpiresAt   time.Time   `json:"hold_expires_at"` // До этого времени ожидается доплата
+	CreatedAt       time.Time   `json:"created_at"`
+}
+
+// NewBookingModification откладывает новые условия updated до доплаты surcharge
+func NewBookingModification(updated *Booking, surcharge int, holdExpiresAt time.Time) *BookingModification {
+	return &BookingModification{
+		BookingID:       updated.ID,
+		Status:          ModificationPending,
+		RoomID:          updated.RoomID,
+		RoomDescription: updated.RoomDescription,
+		RoomNumber:      updated.RoomNumber,
+		CountOfPeople:   updated.CountOfPeople,
+		Children:        updated.Children,
+		StartDate:       updated.StartDate,
+		EndDate:         updated.EndDate,
+		Amount:          updated.Amount,
+		Surcharge:       surcharge,
+		Price:           updated.Price,
+		PromoCodeID:     updated.PromoCodeID,
+		HoldExpiresAt:   holdExpiresAt,
+	}
+}
+
+// Apply возвращает копию бронирования с новыми условиями, само бронирование не меняется
+func (modification *BookingModification) Apply(booking *Booking) *Booking {
+	updated := *booking
+	updated.RoomID = modification.RoomID
+	updated.RoomDescription = modification.RoomDescription
+	updated.RoomNumber = modification.RoomNumber
+	updated.CountOfPeople = modification.CountOfPeople
+	updated.Children = modification.Children
+	updated.StartDate = modification.StartDate
+	updated.EndDate = modification.EndDate
+	updated.Amount = modification.Amount
+	updated.Price = modification.Price
+	updated.PromoCodeID = modification.PromoCodeID
+	updated.PendingModification = nil
+	return &updated
+}
+
+// OrderID - заказ доплаты в PaymentSystem. Меняются только одиночные бронирования, их заказ - ID бронирования.
+func (modification *BookingModification) OrderID() string {
+	return strconv.Itoa(modification.BookingID)
+}
@@ -0,0 +1,17 @@
+package models
+
+// Получатели сообщений outbox
+const (
+	RecipientUser          = "user"
+	RecipientHotelier      = "hotelier"
+	RecipientPaymentSystem = "payment_system" // Возврат средств, Payload - RefundRequest
+)
+
+// OutboxMessage - событие, сохранённое в одной транзакции с изменением бронирования
+// и ожидающее отправки в Kafka
+type OutboxMessage struct {
+	ID        int64
+	Recipient string
+	Payload   []byte
+	Attempts  int
+}
@@ -1,8 +1,12 @@
 package models
 
-import "strconv"
+import (
+	"strconv"
+	"time"
+)
 
 type PaymentRequest struct {
+	OrderID    string `json:"order_id"`
 	CardNumber string `json:"card_number"`
 	Amount     int    `json:"amount"`
 	WebHookURL string `json:"web_hook_url"`
@@ -10,14 +14,47 @@ type PaymentRequest struct {
 	MetaData *BookingMessage `json:"meta_data"` //Это в meta data
 }
 
+// PaymentResponse - тело вебхука PaymentSystem. Присланные обратно meta data не используются:
+// заказ берётся из адреса вебхука, а данные бронирования - из базы
 type PaymentResponse struct {
 	Status string `json:"status"`
+}
 
-	MetaData *BookingMessage `json:"meta_data"` //Это в meta data
+// PaymentWebhook - вебхук PaymentSystem вместе с подписью.
+// Подписаны время отправки, путь с query-параметрами и тело запроса.
+type PaymentWebhook struct {
+	Status         string
+	BookingID      int // Из query-параметра booking_id, для групповой оплаты 0
+	GroupID        int // Из query-параметра group_id
+	ModificationID int // Из query-параметра modification_id, если это доплата за изменение бронирования
+	Timestamp      string
+	Signature      string
+	RequestURI     string
+	Body           []byte
+}
+
+// PaymentStatus - платёж по заказу в PaymentSystem. Status относится к последней попытке оплаты:
+// processing, success, failed или refunded
+type PaymentStatus struct {
+	OrderID   string    `json:"order_id"`
+	Amount    int       `json:"amount"`
+	Refunded  int       `json:"refunded"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RefundRequest - возврат средств по ранее оплаченному заказу.
+// Нулевая сумма означает возврат всего оплаченного остатка.
+// Повтор возврата с тем же RefundID PaymentSystem не исполняет второй раз.
+type RefundRequest struct {
+	OrderID  string `json:"order_id"`
+	Amount   int    `json:"amount"`
+	RefundID string `json:"refund_id,omitempty"`
 }
 
 func ToPaymentRequest(bookingMessage *BookingMessage, cardNumber string, amount int) *PaymentRequest {
 	return &PaymentRequest{
+		OrderID:    strconv.Itoa(bookingMessage.BookingID),
 		CardNumber: cardNumber,
 		Amount:     amount,
 		WebHookURL: "http://booking-service:8080/bookings/payment/response?booking_id=" + strconv.Itoa(bookingMessage.BookingID),
@@ -25,3 +62,30 @@ func ToPaymentRequest(bookingMessage *BookingMessage, cardNumber string, amount
 		MetaData: bookingMessage,
 	}
 }
+
+// ToSurchargePaymentRequest - доплата за изменение бронирования. Заказ тот же, что у бронирования,
+// а вебхук называет изменение, к которому относится доплата.
+func ToSurchargePaymentRequest(bookingMessage *BookingMessage, cardNumber string, modificationID, amount int) *PaymentRequest {
+	paymentRequest := ToPaymentRequest(bookingMessage, cardNumber, amount)
+	paymentRequest.WebHookURL += "&modification_id=" + strconv.Itoa(modificationID)
+	return paymentRequest
+}
+
+// ToGroupPaymentRequest - общий платёж за все комнаты группы
+func ToGroupPaymentRequest(bookingMessage *BookingMessage, cardNumber string, amount int) *PaymentRequest {
+	return &PaymentRequest{
+		OrderID:    GroupOrderID(bookingMessage.GroupID),
+		CardNumber: cardNumber,
+		Amount:     amount,
+		WebHookURL: "http://booking-service:8080/bookings/payment/response?group_id=" + strconv.Itoa(bookingMessage.GroupID),
+
+		MetaData: bookingMessage,
+	}
+}
+
+func ToRefundRequest(orderID string, amount int) *RefundRequest {
+	return &RefundRequest{
+		OrderID: orderID,
+		Amount:  amount,
+	}
+}
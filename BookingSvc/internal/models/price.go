@@ -0,0 +1,87 @@
+package models
+
+// NightPrice - стоимость одной ночи проживания
+type NightPrice struct {
+	Date            string `json:"date"`
+	Rate            string `json:"rate"` // base, weekend или название сезона
+	Price           int    `json:"price"`
+	ExtraGuestPrice int    `json:"extra_guest_price"`
+}
+
+// PriceQuote - расчёт стоимости проживания из HotelSvc
+type PriceQuote struct {
+	Nights          []NightPrice `json:"nights"`
+	Subtotal        int          `json:"subtotal"`
+	DiscountPercent int          `json:"discount_percent"`
+	Discount        int          `json:"discount"`
+	PromoCode       string       `json:"promo_code,omitempty"`
+	PromoDiscount   int          `json:"promo_discount,omitempty"`
+	Total           int          `json:"total"`
+}
+
+// ApplyPromo уменьшает итоговую стоимость на скидку по промокоду
+func (price *PriceQuote) ApplyPromo(promo *PromoCode) {
+	price.PromoCode = promo.Code
+	price.PromoDiscount = promo.Discount(price.Total)
+	price.Total -= price.PromoDiscount
+}
+
+// PromoCodeOf возвращает промокод, применённый к бронированию, или пустую строку
+func (booking *Booking) PromoCodeOf() string {
+	if booking.Price == nil {
+		return ""
+	}
+	return booking.Price.PromoCode
+}
+
+// RoomCapacity - вместимость типа комнаты из HotelSvc
+type RoomCapacity struct {
+	MaxAdults        int    `json:"max_adults"`
+	MaxChildren      int    `json:"max_children"`
+	BedConfiguration string `json:"bed_configuration"`
+}
+
+// Fits проверяет, что гости помещаются в комнате. Дети могут занимать места взрослых, но не наоборот,
+// поэтому взрослые ограничены MaxAdults, а все гости вместе - общей вместимостью.
+// Нулевая вместимость означает, что HotelSvc её не передал, и не ограничивает бронирование.
+func (capacity RoomCapacity) Fits(guests Guests) bool {
+	total := capacity.MaxAdults + capacity.MaxChildren
+	return total == 0 || (guests.Adults <= capacity.MaxAdults && guests.Total() <= total)
+}
+
+// Guests - состав гостей: взрослые и дети
+type Guests struct {
+	Adults   int
+	Children int
+}
+
+// NewGuests собирает состав гостей из запроса. Если взрослые и дети не указаны, все countOfPeople гостей
+// считаются взрослыми, иначе countOfPeople можно не указывать, но если он указан, то должен совпадать с их суммой.
+// Без взрослых состав недопустим.
+func NewGuests(countOfPeople, adults, children int) (Guests, bool) {
+	if adults == 0 && children == 0 {
+		adults = countOfPeople
+	}
+	guests := Guests{Adults: adults, Children: children}
+	if guests.Adults <= 0 || guests.Children < 0 || (countOfPeople != 0 && countOfPeople != guests.Total()) {
+		return Guests{}, false
+	}
+	return guests, true
+}
+
+// Total - число всех гостей, на него считается стоимость проживания
+func (guests Guests) Total() int {
+	return guests.Adults + guests.Children
+}
+
+// AvailableRoom - свободная на выбранные даты комната со стоимостью проживания
+type AvailableRoom struct {
+	ID          int         `json:"id"`
+	HotelID     int         `json:"hotel_id"`
+	RoomTypeID  int         `json:"room_type_id"`
+	Number      int         `json:"number"`
+	Description string      `json:"description"`
+	BasePrice   int         `json:"base_price"`
+	Price       *PriceQuote `json:"price"`
+	RoomCapacity
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRoomCapacity_Fits(t *testing.T) {
+	tests := []struct {
+		name     string
+		capacity RoomCapacity
+		guests   Guests
+		fits     bool
+	}{
+		{"adults and children", RoomCapacity{MaxAdults: 2, MaxChildren: 2}, Guests{Adults: 2, Children: 2}, true},
+		{"children take adult places", RoomCapacity{MaxAdults: 2, MaxChildren: 1}, Guests{Adults: 1, Children: 2}, true},
+		{"adults do not take child places", RoomCapacity{MaxAdults: 2, MaxChildren: 2}, Guests{Adults: 4}, false},
+		{"too many guests", RoomCapacity{MaxAdults: 2, MaxChildren: 1}, Guests{Adults: 2, Children: 2}, false},
+		{"capacity unknown", RoomCapacity{}, Guests{Adults: 12}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.fits, tt.capacity.Fits(tt.guests))
+		})
+	}
+}
+
+func TestNewGuests(t *testing.T) {
+	tests := []struct {
+		name                            string
+		countOfPeople, adults, children int
+		guests                          Guests
+		ok                              bool
+	}{
+		{"only count of people", 3, 0, 0, Guests{Adults: 3}, true},
+		{"adults and children", 0, 2, 1, Guests{Adults: 2, Children: 1}, true},
+		{"count matches", 3, 2, 1, Guests{Adults: 2, Children: 1}, true},
+		{"count mismatch", 4, 2, 1, Guests{}, false},
+		{"no adults", 0, 0, 2, Guests{}, false},
+		{"negative children", 0, 2, -1, Guests{}, false},
+		{"no guests", 0, 0, 0, Guests{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			guests, ok := NewGuests(tt.countOfPeople, tt.adults, tt.children)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.guests, guests)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Типы скидки промокода
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+// PromoCode - промокод отеля из HotelSvc. Нулевые UsageLimit и PerUserLimit означают отсутствие лимита.
+type PromoCode struct {
+	ID               int
+	HotelID          int
+	Code             string
+	DiscountType     string
+	Value            int
+	ValidFrom        time.Time
+	ValidUntil       time.Time
+	StayFrom         *time.Time // Первая ночь, на которую действует код
+	StayUntil        *time.Time // Ночь с этой даты уже не входит
+	UsageLimit       int
+	PerUserLimit     int
+	FirstBookingOnly bool
+}
+
+// Applicable проверяет, что код можно применить в момент now к проживанию с startDate по endDate.
+// Ночи считаются по календарным датам UTC, как в HotelSvc.
+func (promo *PromoCode) Applicable(now, startDate, endDate time.Time) bool {
+	if now.Before(promo.ValidFrom) || !now.Before(promo.ValidUntil) {
+		return false
+	}
+	return promo.CoversStay(startDate, endDate)
+}
+
+// CoversStay проверяет, что все ночи проживания попадают в период действия кода
+func (promo *PromoCode) CoversStay(startDate, endDate time.Time) bool {
+	if promo.StayFrom != nil && truncateToDate(startDate).Before(*promo.StayFrom) {
+		return false
+	}
+	if promo.StayUntil != nil && truncateToDate(endDate).After(*promo.StayUntil) {
+		return false
+	}
+	return true
+}
+
+// Discount - скидка по коду для суммы amount, не больше самой суммы
+func (promo *PromoCode) Discount(amount int) int {
+	if promo.DiscountType == DiscountPercent {
+		return amount * promo.Value / 100
+	}
+	return min(promo.Value, amount)
+}
+
+// SameCode сравнивает коды так же, как HotelSvc: без учёта регистра и пробелов по краям
+func SameCode(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+func truncateToDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,280 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Разбивка отчёта по показателям
+const (
+	ReportGroupRoomType = "room_type"
+	ReportGroupMonth    = "month"
+)
+
+// MaxReportPeriod - самый длинный период одного отчёта
+const MaxReportPeriod = 2 * 366 * 24 * time.Hour
+
+// ReportSoldStatuses - статусы, ночи которых считаются проданными: гость оплатил проживание.
+// При неявке деньги не возвращаются, поэтому её ночи тоже проданы.
+var ReportSoldStatuses = []string{StatusConfirmed, StatusCheckedIn, StatusCheckedOut, StatusNoShow}
+
+// ReportRequest - отчёт по отелю за период [From, To) по календарным датам UTC
+type ReportRequest struct {
+	HotelID int
+	From    time.Time
+	To      time.Time
+	GroupBy []string
+}
+
+// ReportRoom - комната из HotelSvc, её ночи составляют доступный фонд отеля
+type ReportRoom struct {
+	RoomID     int
+	RoomTypeID int
+}
+
+// KPIRow - показатели отеля, типа комнаты или месяца.
+// Ночи выезда не считаются, выручка бронирования делится поровну между его ночами.
+type KPIRow struct {
+	RoomTypeID int    `json:"room_type_id,omitempty"`
+	Month      string `json:"month,omitempty"`
+
+	Rooms           int `json:"rooms"`            // Комнат в фонде
+	AvailableNights int `json:"available_nights"` // Комнато-ночей в фонде за период без снятых с продажи
+	SoldNights      int `json:"sold_nights"`
+	Revenue         int `json:"revenue"`
+	Reservations    int `json:"reservations"` // Бронирования с заездом в периоде, оплаченные или отменённые
+	Cancellations   int `json:"cancellations"`
+
+	OccupancyPercent float64 `json:"occupancy_percent"`
+	ADR              float64 `json:"adr"`    // Средняя цена проданной ночи
+	RevPAR           float64 `json:"revpar"` // Выручка на доступную комнато-ночь
+	CancellationRate float64 `json:"cancellation_rate"`
+}
+
+// HotelReport - показатели отеля за период: итог и строки по разбивке GroupBy
+type HotelReport struct {
+	HotelID int       `json:"hotel_id"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	GroupBy []string  `json:"group_by"`
+	Total   *KPIRow   `json:"total"`
+	Rows    []*KPIRow `json:"rows"`
+}
+
+// Validate приводит период к датам UTC и проверяет разбивку
+func (req *ReportRequest) Validate() error {
+	req.From, req.To = truncateToDate(req.From), truncateToDate(req.To)
+	if !req.To.After(req.From) {
+		return fmt.Errorf("empty period")
+	}
+	if req.To.Sub(req.From) > MaxReportPeriod {
+		return fmt.Errorf("period is longer than %d days", MaxReportPeriod/(24*time.Hour))
+	}
+	seen := make(map[string]bool, len(req.GroupBy))
+	for _, group := range req.GroupBy {
+		if group != ReportGroupRoomType && group != ReportGroupMonth {
+			return fmt.Errorf("unknown group %q", group)
+		}
+		if seen[group] {
+			return fmt.Errorf("duplicate group %q", group)
+		}
+		seen[group] = true
+	}
+	return nil
+}
+
+func (req *ReportRequest) groupedBy(group string) bool {
+	for _, g := range req.GroupBy {
+		if g == group {
+			return true
+		}
+	}
+	return false
+}
+
+type reportKey struct {
+	roomTypeID int
+	month      string
+}
+
+type reportRoomNight struct {
+	roomID int
+	night  time.Time
+}
+
+// ReportBuilder считает показатели отеля по комнатам фонда и бронированиям
+type ReportBuilder struct {
+	req       *ReportRequest
+	roomTypes map[int]int // ID комнаты -> ID типа
+	total     *KPIRow
+	rows      map[reportKey]*KPIRow
+	removed   map[reportRoomNight]struct{} // Ночи, снятые с продажи
+}
+
+// NewReportBuilder заполняет доступный фонд: каждая комната доступна каждую ночь периода
+func NewReportBuilder(req *ReportRequest, rooms []*ReportRoom) *ReportBuilder {
+	builder := &ReportBuilder{
+		req:       req,
+		roomTypes: make(map[int]int, len(rooms)),
+		total:     &KPIRow{},
+		rows:      make(map[reportKey]*KPIRow),
+		removed:   make(map[reportRoomNight]struct{}),
+	}
+	roomsByKey := make(map[reportKey]map[int]struct{})
+	for _, room := range rooms {
+		builder.roomTypes[room.RoomID] = room.RoomTypeID
+		builder.total.Rooms++
+		for night := req.From; night.Before(req.To); night = night.AddDate(0, 0, 1) {
+			key := builder.key(room.RoomID, night)
+			builder.row(key).AvailableNights++
+			builder.total.AvailableNights++
+			if roomsByKey[key] == nil {
+				roomsByKey[key] = make(map[int]struct{})
+			}
+			roomsByKey[key][room.RoomID] = struct{}{}
+		}
+	}
+	for key, roomIDs := range roomsByKey {
+		builder.rows[key].Rooms = len(roomIDs)
+	}
+	return builder
+}
+
+// RemoveFromInventory исключает из доступного фонда ночи, когда владелец снял комнату с продажи.
+// Пересекающиеся блокировки одной комнаты исключают ночь один раз.
+func (builder *ReportBuilder) RemoveFromInventory(block *RoomBlock) {
+	if _, ok := builder.roomTypes[block.RoomID]; !ok {
+		return
+	}
+	first, last := truncateToDate(block.StartDate), truncateToDate(block.EndDate)
+	for night := first; night.Before(last); night = night.AddDate(0, 0, 1) {
+		if night.Before(builder.req.From) || !night.Before(builder.req.To) {
+			continue
+		}
+		roomNight := reportRoomNight{roomID: block.RoomID, night: night}
+		if _, removed := builder.removed[roomNight]; removed {
+			continue
+		}
+		builder.removed[roomNight] = struct{}{}
+		builder.row(builder.key(block.RoomID, night)).AvailableNights--
+		builder.total.AvailableNights--
+	}
+}
+
+// Add учитывает бронирование: проданные ночи и выручку в периоде, а заезд в периоде - в числе бронирований.
+// Бронирования в других статусах не учитываются.
+func (builder *ReportBuilder) Add(booking *Booking) {
+	sold := isSoldStatus(booking.Status)
+	if !sold && booking.Status != StatusCancelled {
+		return
+	}
+
+	arrival := truncateToDate(booking.StartDate)
+	if !arrival.Before(builder.req.From) && arrival.Before(builder.req.To) {
+		row := builder.row(builder.key(booking.RoomID, arrival))
+		row.Reservations++
+		builder.total.Reservations++
+		if !sold {
+			row.Cancellations++
+			builder.total.Cancellations++
+		}
+	}
+	if !sold {
+		return
+	}
+
+	nights := booking.Nights()
+	for i := 0; i < nights; i++ {
+		night := arrival.AddDate(0, 0, i)
+		if night.Before(builder.req.From) || !night.Before(builder.req.To) {
+			continue
+		}
+		// Доля i-й ночи: сумма долей всех ночей в точности равна стоимости бронирования
+		revenue := booking.Amount*(i+1)/nights - booking.Amount*i/nights
+		row := builder.row(builder.key(booking.RoomID, night))
+		row.SoldNights++
+		row.Revenue += revenue
+		builder.total.SoldNights++
+		builder.total.Revenue += revenue
+	}
+}
+
+// Build рассчитывает показатели. Строки упорядочены по месяцу, затем по типу комнаты.
+func (builder *ReportBuilder) Build() *HotelReport {
+	report := &HotelReport{
+		HotelID: builder.req.HotelID,
+		From:    builder.req.From.Format(CalendarDayLayout),
+		To:      builder.req.To.Format(CalendarDayLayout),
+		GroupBy: builder.req.GroupBy,
+		Total:   builder.total.withRates(),
+		Rows:    make([]*KPIRow, 0, len(builder.rows)),
+	}
+	if report.GroupBy == nil {
+		report.GroupBy = []string{}
+	}
+	if len(builder.req.GroupBy) == 0 {
+		return report
+	}
+	for _, row := range builder.rows {
+		report.Rows = append(report.Rows, row.withRates())
+	}
+	sort.Slice(report.Rows, func(i, j int) bool {
+		if report.Rows[i].Month != report.Rows[j].Month {
+			return report.Rows[i].Month < report.Rows[j].Month
+		}
+		return report.Rows[i].RoomTypeID < report.Rows[j].RoomTypeID
+	})
+	return report
+}
+
+// key - строка отчёта для ночи комнаты. Комната, которой уже нет в фонде, попадает в тип 0.
+func (builder *ReportBuilder) key(roomID int, night time.Time) reportKey {
+	var key reportKey
+	if builder.req.groupedBy(ReportGroupRoomType) {
+		key.roomTypeID = builder.roomTypes[roomID]
+	}
+	if builder.req.groupedBy(ReportGroupMonth) {
+		key.month = night.Format(CalendarMonthLayout)
+	}
+	return key
+}
+
+func (builder *ReportBuilder) row(key reportKey) *KPIRow {
+	row, ok := builder.rows[key]
+	if !ok {
+		row = &KPIRow{RoomTypeID: key.roomTypeID, Month: key.month}
+		builder.rows[key] = row
+	}
+	return row
+}
+
+func (row *KPIRow) withRates() *KPIRow {
+	row.OccupancyPercent = percent(row.SoldNights, row.AvailableNights)
+	row.ADR = ratio(row.Revenue, row.SoldNights)
+	row.RevPAR = ratio(row.Revenue, row.AvailableNights)
+	row.CancellationRate = percent(row.Cancellations, row.Reservations)
+	return row
+}
+
+func isSoldStatus(status string) bool {
+	for _, sold := range ReportSoldStatuses {
+		if status == sold {
+			return true
+		}
+	}
+	return false
+}
+
+// ratio - частное с точностью до копеек, 0 при пустом знаменателе
+func ratio(numerator, denominator int) float64 {
+	if denominator == 0 {
+		return 0
+	}
+	return math.Round(float64(numerator)/float64(denominator)*100) / 100
+}
+
+func percent(part, whole int) float64 {
+	return ratio(part*100, whole)
+}
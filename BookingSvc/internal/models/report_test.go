@@ -0,0 +1,127 @@
+package models
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func reportBookings() []*Booking {
+	day := func(month time.Month, d, hour int) time.Time {
+		return time.Date(2026, month, d, hour, 0, 0, 0, time.UTC)
+	}
+	return []*Booking{
+		// Заезд до периода: в периоде только две последние ночи из трёх
+		{ID: 1, RoomID: 1, Status: StatusConfirmed, Amount: 1000, StartDate: day(10, 29, 14), EndDate: day(11, 1, 12)},
+		{ID: 2, RoomID: 2, Status: StatusCheckedOut, Amount: 3000, StartDate: day(10, 31, 14), EndDate: day(11, 2, 12)},
+		{ID: 3, RoomID: 1, Status: StatusCancelled, Amount: 2000, StartDate: day(11, 1, 14), EndDate: day(11, 3, 12)},
+		{ID: 4, RoomID: 2, Status: StatusPendingPayment, Amount: 500, StartDate: day(10, 30, 14), EndDate: day(10, 31, 12)},
+	}
+}
+
+func buildReport(t *testing.T, groupBy ...string) *HotelReport {
+	req := &ReportRequest{
+		HotelID: 7,
+		From:    time.Date(2026, 10, 30, 15, 0, 0, 0, time.UTC),
+		To:      time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+		GroupBy: groupBy,
+	}
+	require.NoError(t, req.Validate())
+	builder := NewReportBuilder(req, []*ReportRoom{{RoomID: 1, RoomTypeID: 10}, {RoomID: 2, RoomTypeID: 20}})
+	for _, booking := range reportBookings() {
+		builder.Add(booking)
+	}
+	return builder.Build()
+}
+
+func TestReportBuilder_Total(t *testing.T) {
+	report := buildReport(t)
+
+	assert.Equal(t, "2026-10-30", report.From)
+	assert.Equal(t, "2026-11-02", report.To)
+	assert.Empty(t, report.Rows)
+	assert.Equal(t, &KPIRow{
+		Rooms:            2,
+		AvailableNights:  6,
+		SoldNights:       4,
+		Revenue:          333 + 334 + 3000,
+		Reservations:     2,
+		Cancellations:    1,
+		OccupancyPercent: 66.67,
+		ADR:              916.75,
+		RevPAR:           611.17,
+		CancellationRate: 50,
+	}, report.Total)
+}
+
+func TestReportBuilder_Groups(t *testing.T) {
+	byMonth := buildReport(t, ReportGroupMonth)
+	require.Len(t, byMonth.Rows, 2)
+	assert.Equal(t, "2026-10", byMonth.Rows[0].Month)
+	assert.Equal(t, []int{2, 4, 3, 2167, 1, 0},
+		[]int{byMonth.Rows[0].Rooms, byMonth.Rows[0].AvailableNights, byMonth.Rows[0].SoldNights,
+			byMonth.Rows[0].Revenue, byMonth.Rows[0].Reservations, byMonth.Rows[0].Cancellations})
+	assert.Equal(t, "2026-11", byMonth.Rows[1].Month)
+	assert.Equal(t, 50.0, byMonth.Rows[1].OccupancyPercent)
+	assert.Equal(t, 100.0, byMonth.Rows[1].CancellationRate)
+
+	byType := buildReport(t, ReportGroupRoomType)
+	require.Len(t, byType.Rows, 2)
+	assert.Equal(t, 10, byType.Rows[0].RoomTypeID)
+	assert.Equal(t, 667, byType.Rows[0].Revenue)
+	assert.Equal(t, 333.5, byType.Rows[0].ADR)
+	assert.Equal(t, 20, byType.Rows[1].RoomTypeID)
+	assert.Equal(t, 1000.0, byType.Rows[1].RevPAR)
+
+	// Итог не зависит от разбивки
+	assert.Equal(t, buildReport(t).Total, buildReport(t, ReportGroupRoomType, ReportGroupMonth).Total)
+	assert.Len(t, buildReport(t, ReportGroupRoomType, ReportGroupMonth).Rows, 4)
+}
+
+func TestReportBuilder_RemoveFromInventory(t *testing.T) {
+	req := &ReportRequest{
+		From:    time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC),
+		To:      time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC),
+		GroupBy: []string{ReportGroupRoomType},
+	}
+	require.NoError(t, req.Validate())
+	builder := NewReportBuilder(req, []*ReportRoom{{RoomID: 1, RoomTypeID: 10}, {RoomID: 2, RoomTypeID: 20}})
+
+	// Ремонт с заходом за начало периода и пересекающаяся с ним блокировка: ночь снимается один раз
+	builder.RemoveFromInventory(&RoomBlock{RoomID: 1, Source: BlockSourceMaintenance,
+		StartDate: time.Date(2026, 10, 28, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)})
+	builder.RemoveFromInventory(&RoomBlock{RoomID: 1, Source: BlockSourceMaintenance,
+		StartDate: time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 10, 31, 0, 0, 0, 0, time.UTC)})
+	// Комнаты нет в фонде
+	builder.RemoveFromInventory(&RoomBlock{RoomID: 9, Source: BlockSourceMaintenance,
+		StartDate: time.Date(2026, 10, 30, 0, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 11, 2, 0, 0, 0, 0, time.UTC)})
+	builder.Add(&Booking{RoomID: 1, Status: StatusConfirmed, Amount: 100,
+		StartDate: time.Date(2026, 11, 1, 14, 0, 0, 0, time.UTC), EndDate: time.Date(2026, 11, 2, 12, 0, 0, 0, time.UTC)})
+
+	report := builder.Build()
+	assert.Equal(t, 4, report.Total.AvailableNights)
+	require.Len(t, report.Rows, 2)
+	assert.Equal(t, 1, report.Rows[0].AvailableNights)
+	assert.Equal(t, 100.0, report.Rows[0].OccupancyPercent)
+	assert.Equal(t, 3, report.Rows[1].AvailableNights)
+}
+
+func TestReportRequest_Validate(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		req  ReportRequest
+	}{
+		{"empty period", ReportRequest{From: from, To: from}},
+		{"too long", ReportRequest{From: from, To: from.AddDate(3, 0, 0)}},
+		{"unknown group", ReportRequest{From: from, To: from.AddDate(0, 1, 0), GroupBy: []string{"guest"}}},
+		{"duplicate group", ReportRequest{From: from, To: from.AddDate(0, 1, 0), GroupBy: []string{"month", "month"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.req.Validate())
+		})
+	}
+}
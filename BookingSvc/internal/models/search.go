@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// HotelSearchRequest - поиск свободных комнат в отелях города. Нулевые MinPrice и MaxPrice не ограничивают цену.
+type HotelSearchRequest struct {
+	City      string
+	StartDate time.Time
+	EndDate   time.Time
+	Guests    int // Все гости, из них Children - дети
+	Children  int
+	MinPrice  int // Границы итоговой стоимости всего проживания
+	MaxPrice  int
+}
+
+// AvailableRoomType - свободные комнаты одного типа. RoomID - комната, которую можно бронировать.
+type AvailableRoomType struct {
+	RoomTypeID     int         `json:"room_type_id"`
+	RoomID         int         `json:"room_id"`
+	RoomNumber     int         `json:"room_number"`
+	Description    string      `json:"description"`
+	AvailableRooms int         `json:"available_rooms"`
+	Price          *PriceQuote `json:"price"`
+	RoomCapacity
+}
+
+// HotelSearchResult - отель с самым дешёвым свободным типом комнаты в диапазоне цены
+type HotelSearchResult struct {
+	HotelID      int                `json:"hotel_id"`
+	HotelName    string             `json:"hotel_name"`
+	City         string             `json:"city"`
+	Address      string             `json:"address"`
+	CheapestRoom *AvailableRoomType `json:"cheapest_room"`
+}
+
+// PriceInRange проверяет итоговую стоимость по границам запроса
+func (req *HotelSearchRequest) PriceInRange(total int) bool {
+	if req.MinPrice > 0 && total < req.MinPrice {
+		return false
+	}
+	return req.MaxPrice <= 0 || total <= req.MaxPrice
+}
@@ -0,0 +1,116 @@
+package models
+
+import "time"
+
+// Статусы бронирования
+const (
+	StatusPendingPayment = "pending_payment"
+	StatusConfirmed      = "confirmed"
+	StatusPaymentFailed  = "payment_failed"
+	StatusCancelled      = "cancelled"
+	StatusExpired        = "expired"
+	StatusCheckedIn      = "checked_in"
+	StatusCheckedOut     = "checked_out"
+	StatusNoShow         = "no_show"
+)
+
+// ActiveStatuses - статусы, при которых бронирование занимает комнату.
+// Должны совпадать с условием ограничения bookings_room_period_excl в миграциях.
+// Неявившийся гость оплатил проживание, поэтому комната остаётся за ним, пока владелец не освободит оставшиеся ночи.
+var ActiveStatuses = []string{StatusPendingPayment, StatusConfirmed, StatusCheckedIn, StatusNoShow}
+
+// PromoUsageStatuses - статусы, при которых бронирование расходует промокод и считается у гостя для FirstBookingOnly.
+// Завершённое проживание остаётся использованием, возвращают промокод только отмена, истечение брони и неудачная оплата.
+var PromoUsageStatuses = []string{StatusPendingPayment, StatusConfirmed, StatusCheckedIn, StatusCheckedOut, StatusNoShow}
+
+// statusTransitions - допустимые переходы между статусами. Статусы без переходов конечные.
+// Изменение с доплатой статус не меняет: оно ждёт оплаты в BookingModification.
+var statusTransitions = map[string][]string{
+	StatusPendingPayment: {StatusConfirmed, StatusPaymentFailed, StatusCancelled, StatusExpired},
+	StatusConfirmed:      {StatusCancelled, StatusCheckedIn, StatusNoShow},
+	StatusCheckedIn:      {StatusCheckedOut},
+}
+
+// Кто перевёл бронирование в новый статус
+const (
+	ActorGuest         = "guest"
+	ActorHotelier      = "hotelier"
+	ActorPaymentSystem = "payment_system"
+	ActorSystem        = "system"
+)
+
+// Причины переходов в истории статусов
+const (
+	ReasonBookingCreated   = "booking created"
+	ReasonPaymentSucceeded = "payment succeeded"
+	ReasonPaymentFailed    = "payment failed"
+	ReasonPaymentNotSent   = "payment request failed"
+	ReasonCancelledByGuest = "cancelled by guest"
+	ReasonHoldExpired      = "payment hold expired"
+	ReasonGuestCheckedIn   = "guest checked in"
+	ReasonGuestCheckedOut  = "guest checked out"
+	ReasonGuestNoShow      = "guest did not arrive"
+)
+
+// Статусы платежа в вебхуке PaymentSystem
+const (
+	PaymentSuccess = "success"
+	PaymentFailed  = "failed"
+	paymentFail    = "fail" // Так раньше ожидал BookingSvc, принимается для совместимости
+)
+
+// StatusTransition - переход бронирования из статуса From в To с автором и причиной для истории.
+// ActorID - ID пользователя для гостя и владельца отеля, 0 для платёжной системы и самого сервиса.
+type StatusTransition struct {
+	From    string
+	To      string
+	Actor   string
+	ActorID int
+	Reason  string
+}
+
+// StatusChange - запись истории статусов бронирования. From пустой у записи о создании.
+type StatusChange struct {
+	From    string    `json:"from,omitempty"`
+	Status  string    `json:"status"`
+	Actor   string    `json:"actor"`
+	ActorID int       `json:"actor_id,omitempty"`
+	Reason  string    `json:"reason,omitempty"`
+	At      time.Time `json:"at"`
+}
+
+// IsBookingStatus проверяет, что status - один из статусов бронирования
+func IsBookingStatus(status string) bool {
+	if status == StatusPendingPayment {
+		return true
+	}
+	for _, targets := range statusTransitions {
+		for _, target := range targets {
+			if target == status {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// CanTransition проверяет, что из статуса from можно перейти в to
+func CanTransition(from, to string) bool {
+	for _, target := range statusTransitions[from] {
+		if target == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusFromPayment переводит статус платежа из вебхука в статус бронирования
+func StatusFromPayment(paymentStatus string) (string, bool) {
+	switch paymentStatus {
+	case PaymentSuccess:
+		return StatusConfirmed, true
+	case PaymentFailed, paymentFail:
+		return StatusPaymentFailed, true
+	}
+	return "", false
+}
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Статусы записи в листе ожидания
+const (
+	WaitlistWaiting = "waiting" // В очереди
+	WaitlistOffered = "offered" // Гостю отправлена ссылка на освободившуюся комнату
+	WaitlistClaimed = "claimed" // Гость забронировал комнату по ссылке
+	WaitlistExpired = "expired" // Ссылка не использована вовремя или даты уже прошли
+)
+
+// WaitlistRequest - запрос на постановку в лист ожидания. Нулевой RoomTypeID - подходит любая комната отеля
+type WaitlistRequest struct {
+	HotelID       int       `json:"hotel_id"`
+	RoomTypeID    int       `json:"room_type_id"`
+	StartDate     time.Time `json:"start_date"`
+	EndDate       time.Time `json:"end_date"`
+	CountOfPeople int       `json:"count_of_people"`
+}
+
+type WaitlistEntry struct {
+	ID             int        `json:"id"`
+	UserID         int        `json:"user_id"`
+	Username       string     `json:"-"`
+	ChatID         string     `json:"-"`
+	HotelID        int        `json:"hotel_id"`
+	RoomTypeID     int        `json:"room_type_id,omitempty"`
+	StartDate      time.Time  `json:"start_date"`
+	EndDate        time.Time  `json:"end_date"`
+	CountOfPeople  int        `json:"count_of_people"`
+	Status         string     `json:"status"`
+	OfferedRoomID  int        `json:"offered_room_id,omitempty"`
+	ClaimToken     string     `json:"-"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// WaitlistClaimRequest - бронирование предложенной комнаты по ссылке
+type WaitlistClaimRequest struct {
+	CardNumber string `json:"card_number"`
+}
+
+func (req *WaitlistRequest) ToWaitlistEntry(user *User) *WaitlistEntry {
+	return &WaitlistEntry{
+		UserID:        user.UserID,
+		Username:      user.Username,
+		ChatID:        user.ChatID,
+		HotelID:       req.HotelID,
+		RoomTypeID:    req.RoomTypeID,
+		StartDate:     req.StartDate.UTC(),
+		EndDate:       req.EndDate.UTC(),
+		CountOfPeople: req.CountOfPeople,
+		Status:        WaitlistWaiting,
+	}
+}
+
+// ToBookingRequest - бронирование предложенной комнаты на даты из листа ожидания
+func (entry *WaitlistEntry) ToBookingRequest(cardNumber string) *BookingRequest {
+	return &BookingRequest{
+		RoomID:        entry.OfferedRoomID,
+		HotelID:       entry.HotelID,
+		CardNumber:    cardNumber,
+		CountOfPeople: entry.CountOfPeople,
+		StartDate:     entry.StartDate,
+		EndDate:       entry.EndDate,
+	}
+}
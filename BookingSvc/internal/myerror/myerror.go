@@ -6,4 +6,40 @@ var (
 	ErrForbiddenAccess      = errors.New("forbidden access")
 	ErrHotelNotFound        = errors.New("hotel Not Found")
 	ErrBookingAlreadyExists = errors.New("booking already exists")
+	ErrBookingNotFound      = errors.New("booking not found")
+	ErrModificationNotFound = errors.New("booking modification not found")
+	ErrInvalidBookingStatus = errors.New("invalid booking status")
+	ErrInvalidPaymentStatus = errors.New("unknown payment status")
+	ErrInvalidWebhook       = errors.New("invalid payment webhook signature")
+	ErrWebhookReplayed      = errors.New("payment webhook already processed")
+	ErrPaymentNotFound      = errors.New("payment not found")
+	ErrInvalidBookingData   = errors.New("invalid booking data")
+	ErrRoomNotFound         = errors.New("room not found")
+	ErrRoomDataMismatch     = errors.New("room data mismatch")
+	ErrRoomCapacityExceeded = errors.New("too many guests for the room")
+	ErrInvalidBookingFilter = errors.New("invalid booking list filter")
+	ErrInvalidReportRequest = errors.New("invalid report period or grouping")
+
+	ErrIdempotencyKeyExists = errors.New("idempotency key already used")
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with different request")
+
+	ErrPromoCodeNotFound      = errors.New("promo code not found")
+	ErrPromoCodeNotApplicable = errors.New("promo code is not applicable")
+	ErrPromoCodeExhausted     = errors.New("promo code usage limit reached")
+
+	ErrWaitlistOfferUnavailable = errors.New("waitlist offer expired or already claimed")
+
+	ErrICalFeedNotFound   = errors.New("ical feed not found")
+	ErrICalImportNotFound = errors.New("ical import not found")
+	ErrICalImportExists   = errors.New("room already imports this calendar")
+	ErrInvalidICalImport  = errors.New("invalid ical import")
+
+	ErrICalFeedForbidden   = errors.New("calendar address is not allowed")
+	ErrICalFeedUnavailable = errors.New("calendar could not be downloaded")
+	ErrICalFeedInvalid     = errors.New("calendar could not be read")
+
+	ErrRoomBlockNotFound = errors.New("room block not found")
+	ErrRoomBlockConflict = errors.New("room is booked for this period")
+	ErrRoomBlockImported = errors.New("room block is managed by calendar import")
+	ErrInvalidRoomBlock  = errors.New("invalid room block")
 )
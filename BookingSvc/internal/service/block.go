@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"go.uber.org/zap"
+	"time"
+)
+
+// CreateRoomBlock снимает комнату с продажи на период: ремонт или личное использование владельцем.
+// На занятые бронированием даты блокировку создать нельзя, гостя сначала нужно переселить или отменить.
+func (b *BookingServiceImpl) CreateRoomBlock(ctx context.Context, blockRequest *models.RoomBlockRequest, userID int) (*models.RoomBlock, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.CreateRoomBlock")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: CreateRoomBlock"),
+		zap.Int("hotel id", blockRequest.HotelID),
+		zap.Int("room id", blockRequest.RoomID),
+		zap.Int("user id", userID),
+	).Info("Received request to create room block")
+
+	if err := blockRequest.Validate(time.Now()); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service CreateRoomBlock: %w: %v", myerror.ErrInvalidRoomBlock, err)
+	}
+	if err := b.checkHotelRoom(ctx, blockRequest.HotelID, blockRequest.RoomID, userID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service CreateRoomBlock: %w", err)
+	}
+
+	block := blockRequest.ToRoomBlock()
+	if err := b.storage.CreateRoomBlock(ctx, block, userID); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrRoomBlockConflict) {
+			b.log.Warn("in service CreateRoomBlock", zap.Error(err))
+		} else {
+			b.log.Error("in service CreateRoomBlock", zap.Error(err))
+		}
+		return nil, fmt.Errorf("in service CreateRoomBlock: %w", err)
+	}
+
+	span.AddEvent("room block created")
+	return block, nil
+}
+
+// DeleteRoomBlock возвращает комнату в продажу и предлагает освободившиеся даты листу ожидания.
+// Блокировки импорта iCal снимаются отключением календаря.
+func (b *BookingServiceImpl) DeleteRoomBlock(ctx context.Context, blockID int64, userID int) error {
+	ctx, span := b.tracer.Start(ctx, "BookingService.DeleteRoomBlock")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: DeleteRoomBlock"),
+		zap.Int64("block id", blockID),
+		zap.Int("user id", userID),
+	).Info("Received request to delete room block")
+
+	block, err := b.storage.GetRoomBlock(ctx, blockID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("in service DeleteRoomBlock: %w", err)
+	}
+	if err = b.checkHotelOwner(ctx, block.HotelID, userID); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrHotelNotFound) {
+			return fmt.Errorf("in service DeleteRoomBlock: %w", myerror.ErrForbiddenAccess)
+		}
+		return fmt.Errorf("in service DeleteRoomBlock: %w", err)
+	}
+	if block.Source != models.BlockSourceMaintenance {
+		span.RecordError(myerror.ErrRoomBlockImported)
+		return fmt.Errorf("in service DeleteRoomBlock: %w", myerror.ErrRoomBlockImported)
+	}
+	if err = b.storage.DeleteRoomBlock(ctx, blockID); err != nil {
+		span.RecordError(err)
+		b.log.Error("in service DeleteRoomBlock", zap.Error(err))
+		return fmt.Errorf("in service DeleteRoomBlock: %w", err)
+	}
+
+	// Прошедшие ночи уже не продать
+	releaseFrom := block.StartDate
+	if now := time.Now(); now.After(releaseFrom) {
+		releaseFrom = now
+	}
+	if block.EndDate.After(releaseFrom) {
+		b.offerFreedRoom(ctx, block.HotelID, block.RoomID, releaseFrom, block.EndDate)
+	}
+
+	span.AddEvent("room block deleted")
+	return nil
+}
+
+// hotelRoomBlocks - блокировки для списка бронирований отеля. Они показываются на первой странице
+// списка по пересечению с периодом, при фильтре по заездам, выездам или проживающим их нет.
+func (b *BookingServiceImpl) hotelRoomBlocks(ctx context.Context, hotelID int, filter *models.BookingFilter) ([]*models.RoomBlock, error) {
+	if filter.Cursor != nil || filter.DateFilter != models.DateFilterOverlap {
+		return nil, nil
+	}
+	from := filter.From
+	if from == nil && filter.To == nil {
+		// Без периода - текущие и будущие блокировки
+		now := time.Now()
+		from = &now
+	}
+	return b.storage.GetRoomBlocks(ctx, hotelID, filter.RoomID, from, filter.To)
+}
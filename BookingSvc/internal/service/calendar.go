@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	hotelpb "github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"time"
+)
+
+// GetOccupancyCalendar возвращает владельцу отеля занятость каждой комнаты по дням месяца month
+func (b *BookingServiceImpl) GetOccupancyCalendar(ctx context.Context, hotelID, userID int, month time.Time) (*models.OccupancyCalendar, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.GetOccupancyCalendar")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: GetOccupancyCalendar"),
+		zap.Int("hotel id", hotelID),
+		zap.Int("user id", userID),
+		zap.String("month", month.Format(models.CalendarMonthLayout)),
+	).Info("Received request to get occupancy calendar")
+
+	if err := b.checkHotelOwner(ctx, hotelID, userID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error in service GetOccupancyCalendar: %w", err)
+	}
+
+	response, err := b.hotelSvcClient.GetRoomsByHotelId(ctx, &hotelpb.GetRoomsRequest{HotelId: int32(hotelID)})
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service gRPC GetRoomsByHotelId:", zap.Error(err))
+		return nil, fmt.Errorf("error in gRPC request GetRoomsByHotelID: %w", err)
+	}
+	rooms := make([]*models.CalendarRoom, 0, len(response.Rooms))
+	for _, room := range response.Rooms {
+		rooms = append(rooms, &models.CalendarRoom{
+			RoomID:      int(room.Id),
+			RoomNumber:  int(room.Number),
+			Description: room.Description,
+		})
+	}
+	calendar := models.NewOccupancyCalendar(hotelID, month, rooms)
+
+	start, end := models.MonthBounds(month)
+	bookings, err := b.storage.GetActiveBookingsByHotelPeriod(ctx, hotelID, start, end)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service GetOccupancyCalendar:", zap.Error(err))
+		return nil, fmt.Errorf("error in service GetOccupancyCalendar: %w", err)
+	}
+	blocks, err := b.storage.GetRoomBlocksByHotelPeriod(ctx, hotelID, start, end)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service GetOccupancyCalendar:", zap.Error(err))
+		return nil, fmt.Errorf("error in service GetOccupancyCalendar: %w", err)
+	}
+	// Бронирование поверх блокировки важнее: его видно по booking_id
+	for _, block := range blocks {
+		calendar.Block(block)
+	}
+	for _, booking := range bookings {
+		calendar.Occupy(booking, booking.CalendarState())
+	}
+
+	span.SetAttributes(attribute.Int("calendar.rooms", len(rooms)), attribute.Int("calendar.bookings", len(bookings)),
+		attribute.Int("calendar.blocks", len(blocks)))
+	span.AddEvent("get occupancy calendar success")
+	return calendar, nil
+}
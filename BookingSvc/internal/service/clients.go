@@ -6,10 +6,14 @@ import (
 	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
 )
 
-//go:generate mockgen -source=hotel.go -destination=mocks/hotel_mock.go -package=mocks
+//go:generate mockgen -source=clients.go -destination=mocks/clients_mock.go -package=mocks
 type HotelClient interface {
 	GetRoomsByHotelId(ctx context.Context, req *hotelpb.GetRoomsRequest) (*hotelpb.GetRoomsResponse, error)
 	GetOwnerIdByHotelId(ctx context.Context, req *hotelpb.GetOwnerIdRequest) (*hotelpb.GetOwnerIdResponse, error)
+	GetRoomDetails(ctx context.Context, req *hotelpb.GetRoomDetailsRequest) (*hotelpb.GetRoomDetailsResponse, error)
+	GetPriceQuotes(ctx context.Context, req *hotelpb.GetPriceQuotesRequest) (*hotelpb.GetPriceQuotesResponse, error)
+	GetPromoCode(ctx context.Context, req *hotelpb.GetPromoCodeRequest) (*hotelpb.GetPromoCodeResponse, error)
+	SearchHotels(ctx context.Context, req *hotelpb.SearchHotelsRequest) (*hotelpb.SearchHotelsResponse, error)
 }
 
 type AuthSvcClient interface {
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"go.uber.org/zap"
+)
+
+// ExportBookingsByHotelID передаёт в write по одному бронирования отеля за период для бухгалтерии.
+// Права и фильтр проверяются до первой строки, ошибка write прерывает выгрузку.
+func (b *BookingServiceImpl) ExportBookingsByHotelID(ctx context.Context, hotelID, userID int, filter *models.BookingFilter, write func(*models.BookingExportRow) error) error {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ExportBookingsByHotelID")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: ExportBookingsByHotelID"),
+		zap.Int("user id", userID),
+		zap.Int("hotel id", hotelID)).Info("Received request to export hotel bookings")
+
+	if err := filter.NormalizeExport(); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("error in service ExportBookingsByHotelID: %w: %v", myerror.ErrInvalidBookingFilter, err)
+	}
+	if err := b.checkHotelOwner(ctx, hotelID, userID); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("error in service ExportBookingsByHotelID: %w", err)
+	}
+
+	exported := 0
+	err := b.storage.StreamBookingsByHotelID(ctx, hotelID, filter, func(booking *models.Booking) error {
+		exported++
+		return write(models.NewBookingExportRow(booking))
+	})
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service ExportBookingsByHotelID:", zap.Error(err), zap.Int("exported", exported))
+		return fmt.Errorf("error in service ExportBookingsByHotelID: %w", err)
+	}
+
+	b.log.Info("in service export bookings by hotel id end successfully", zap.Int("exported", exported))
+	span.AddEvent("export bookings success")
+	return nil
+}
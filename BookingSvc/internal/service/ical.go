@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/ical"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"net/url"
+	"time"
+)
+
+// Период выгрузки .ics относительно текущего дня
+const (
+	icalExportPast  = 30 * 24 * time.Hour
+	icalExportAhead = 2 * 365 * 24 * time.Hour
+)
+
+type ICalFeedClient interface {
+	CheckFeedURL(ctx context.Context, feedURL string) error
+	FetchEvents(ctx context.Context, feedURL string) ([]ical.Event, error)
+}
+
+// CreateICalFeed создаёт владельцу отеля секретную ссылку на выгрузку занятости отеля или одной комнаты
+func (b *BookingServiceImpl) CreateICalFeed(ctx context.Context, feedRequest *models.ICalFeedRequest, userID int) (*models.ICalFeed, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.CreateICalFeed")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: CreateICalFeed"),
+		zap.Int("hotel id", feedRequest.HotelID),
+		zap.Int("room id", feedRequest.RoomID),
+		zap.Int("user id", userID),
+	).Info("Received request to create ical feed")
+
+	if feedRequest.HotelID <= 0 || feedRequest.RoomID < 0 {
+		span.RecordError(myerror.ErrInvalidBookingData)
+		return nil, fmt.Errorf("in service CreateICalFeed: %w", myerror.ErrInvalidBookingData)
+	}
+	if err := b.checkHotelRoom(ctx, feedRequest.HotelID, feedRequest.RoomID, userID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service CreateICalFeed: %w", err)
+	}
+
+	token, err := newClaimToken()
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("failed to generate ical feed token", zap.Error(err))
+		return nil, fmt.Errorf("in service CreateICalFeed: %w", err)
+	}
+	feed := &models.ICalFeed{
+		Token:   token,
+		HotelID: feedRequest.HotelID,
+		RoomID:  feedRequest.RoomID,
+		URL:     b.icalFeedURL + url.PathEscape(token),
+	}
+	if err = b.storage.CreateICalFeed(ctx, feed, userID); err != nil {
+		span.RecordError(err)
+		b.log.Error("in service CreateICalFeed", zap.Error(err))
+		return nil, fmt.Errorf("in service CreateICalFeed: %w", err)
+	}
+
+	span.AddEvent("ical feed created")
+	return feed, nil
+}
+
+// RevokeICalFeed отзывает ссылку на выгрузку, после этого она отвечает 404
+func (b *BookingServiceImpl) RevokeICalFeed(ctx context.Context, token string, userID int) error {
+	ctx, span := b.tracer.Start(ctx, "BookingService.RevokeICalFeed")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: RevokeICalFeed"),
+		zap.Int("user id", userID),
+	).Info("Received request to revoke ical feed")
+
+	feed, err := b.storage.GetICalFeed(ctx, token)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("in service RevokeICalFeed: %w", err)
+	}
+	if err = b.checkHotelOwner(ctx, feed.HotelID, userID); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("in service RevokeICalFeed: %w", err)
+	}
+	if err = b.storage.DeleteICalFeed(ctx, token); err != nil {
+		span.RecordError(err)
+		b.log.Error("in service RevokeICalFeed", zap.Error(err))
+		return fmt.Errorf("in service RevokeICalFeed: %w", err)
+	}
+
+	span.AddEvent("ical feed revoked")
+	return nil
+}
+
+// ExportICalFeed строит календарь занятости по токену ссылки: активные бронирования и блокировки комнат.
+// Данные гостей в календарь не попадают, а блокировки из импорта не выгружаются, чтобы площадки
+// не возвращали друг другу собственные даты.
+func (b *BookingServiceImpl) ExportICalFeed(ctx context.Context, token string) (*ical.Calendar, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ExportICalFeed")
+	defer span.End()
+
+	feed, err := b.storage.GetICalFeed(ctx, token)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrICalFeedNotFound) {
+			b.log.Warn("in service ExportICalFeed", zap.Error(err))
+		} else {
+			b.log.Error("in service ExportICalFeed", zap.Error(err))
+		}
+		return nil, fmt.Errorf("in service ExportICalFeed: %w", err)
+	}
+	span.SetAttributes(attribute.Int("ical.hotel_id", feed.HotelID), attribute.Int("ical.room_id", feed.RoomID))
+
+	now := time.Now()
+	from, _ := models.DayBounds(now.Add(-icalExportPast))
+	to, _ := models.DayBounds(now.Add(icalExportAhead))
+	bookings, err := b.storage.GetActiveBookingsByHotelPeriod(ctx, feed.HotelID, from, to)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("in service ExportICalFeed", zap.Error(err))
+		return nil, fmt.Errorf("in service ExportICalFeed: %w", err)
+	}
+	blocks, err := b.storage.GetRoomBlocksByHotelPeriod(ctx, feed.HotelID, from, to)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("in service ExportICalFeed", zap.Error(err))
+		return nil, fmt.Errorf("in service ExportICalFeed: %w", err)
+	}
+
+	calendar := &ical.Calendar{Name: fmt.Sprintf("Hotel %d", feed.HotelID), Stamp: now}
+	if feed.RoomID != 0 {
+		calendar.Name = fmt.Sprintf("Hotel %d, room %d", feed.HotelID, feed.RoomID)
+	}
+	for _, booking := range bookings {
+		if feed.RoomID != 0 && booking.RoomID != feed.RoomID {
+			continue
+		}
+		summary := "Booked"
+		if feed.RoomID == 0 {
+			summary = fmt.Sprintf("Room %d: booked", booking.RoomNumber)
+		}
+		calendar.Events = append(calendar.Events, nightsEvent(fmt.Sprintf("booking-%d", booking.ID), summary, booking.StartDate, booking.EndDate))
+	}
+	for _, block := range blocks {
+		if block.Source == models.BlockSourceICal || (feed.RoomID != 0 && block.RoomID != feed.RoomID) {
+			continue
+		}
+		summary := "Blocked"
+		if feed.RoomID == 0 {
+			summary = fmt.Sprintf("Room ID %d: blocked", block.RoomID)
+		}
+		calendar.Events = append(calendar.Events, nightsEvent(fmt.Sprintf("block-%d", block.ID), summary, block.StartDate, block.EndDate))
+	}
+
+	span.SetAttributes(attribute.Int("ical.events", len(calendar.Events)))
+	span.AddEvent("ical feed exported")
+	return calendar, nil
+}
+
+// CreateICalImport подписывает комнату на календарь другой площадки и сразу синхронизирует его.
+// Ошибка первой синхронизации не отменяет подписку, она видна в LastError.
+func (b *BookingServiceImpl) CreateICalImport(ctx context.Context, importRequest *models.ICalImportRequest, userID int) (*models.ICalImport, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.CreateICalImport")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: CreateICalImport"),
+		zap.Int("hotel id", importRequest.HotelID),
+		zap.Int("room id", importRequest.RoomID),
+		zap.Int("user id", userID),
+	).Info("Received request to create ical import")
+
+	feedURL, err := url.Parse(importRequest.URL)
+	if err != nil || (feedURL.Scheme != "http" && feedURL.Scheme != "https") || feedURL.Host == "" ||
+		importRequest.HotelID <= 0 || importRequest.RoomID <= 0 {
+		span.RecordError(myerror.ErrInvalidICalImport)
+		return nil, fmt.Errorf("in service CreateICalImport: %w", myerror.ErrInvalidICalImport)
+	}
+	if err = b.checkHotelRoom(ctx, importRequest.HotelID, importRequest.RoomID, userID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service CreateICalImport: %w", err)
+	}
+	// Календарь на внутреннем адресе не принимается, подробности остаются в логах
+	if err = b.icalFeedClient.CheckFeedURL(ctx, importRequest.URL); err != nil {
+		span.RecordError(err)
+		b.log.Warn("in service CreateICalImport", zap.Error(err))
+		return nil, fmt.Errorf("in service CreateICalImport: %w", myerror.ErrInvalidICalImport)
+	}
+
+	icalImport := importRequest.ToICalImport()
+	if err = b.storage.CreateICalImport(ctx, icalImport, userID); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrICalImportExists) {
+			b.log.Warn("in service CreateICalImport", zap.Error(err))
+		} else {
+			b.log.Error("in service CreateICalImport", zap.Error(err))
+		}
+		return nil, fmt.Errorf("in service CreateICalImport: %w", err)
+	}
+
+	syncedAt := time.Now()
+	icalImport.LastSyncedAt = &syncedAt
+	if err = b.syncICalImport(ctx, icalImport); err != nil {
+		icalImport.LastError = syncErrorReason(err)
+	}
+
+	span.AddEvent("ical import created")
+	return icalImport, nil
+}
+
+// GetICalImports возвращает владельцу отеля подписки его комнат с результатом последней синхронизации
+func (b *BookingServiceImpl) GetICalImports(ctx context.Context, hotelID, userID int) ([]*models.ICalImport, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.GetICalImports")
+	defer span.End()
+
+	if err := b.checkHotelOwner(ctx, hotelID, userID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service GetICalImports: %w", err)
+	}
+	imports, err := b.storage.GetICalImports(ctx, hotelID)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("in service GetICalImports", zap.Error(err))
+		return nil, fmt.Errorf("in service GetICalImports: %w", err)
+	}
+	return imports, nil
+}
+
+// DeleteICalImport отменяет подписку, её блокировки удаляются вместе с ней
+func (b *BookingServiceImpl) DeleteICalImport(ctx context.Context, importID, userID int) error {
+	ctx, span := b.tracer.Start(ctx, "BookingService.DeleteICalImport")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: DeleteICalImport"),
+		zap.Int("import id", importID),
+		zap.Int("user id", userID),
+	).Info("Received request to delete ical import")
+
+	icalImport, err := b.storage.GetICalImport(ctx, importID)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("in service DeleteICalImport: %w", err)
+	}
+	if err = b.checkHotelOwner(ctx, icalImport.HotelID, userID); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("in service DeleteICalImport: %w", err)
+	}
+	if err = b.storage.DeleteICalImport(ctx, importID); err != nil {
+		span.RecordError(err)
+		b.log.Error("in service DeleteICalImport", zap.Error(err))
+		return fmt.Errorf("in service DeleteICalImport: %w", err)
+	}
+
+	span.AddEvent("ical import deleted")
+	return nil
+}
+
+// SyncICalImports синхронизирует все подписки и возвращает число успешных.
+// Ошибка одной подписки сохраняется в ней и не останавливает остальные.
+func (b *BookingServiceImpl) SyncICalImports(ctx context.Context) (int, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.SyncICalImports")
+	defer span.End()
+
+	imports, err := b.storage.GetICalImports(ctx, 0)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("in service SyncICalImports: %w", err)
+	}
+	synced := 0
+	for _, icalImport := range imports {
+		if ctx.Err() != nil {
+			break
+		}
+		if err = b.syncICalImport(ctx, icalImport); err == nil {
+			synced++
+		}
+	}
+
+	span.SetAttributes(attribute.Int("ical.imports", len(imports)), attribute.Int("ical.synced", synced))
+	return synced, nil
+}
+
+// syncICalImport загружает календарь подписки и заменяет её блокировки будущими событиями календаря.
+// При ошибке загрузки прежние блокировки остаются, а причина сохраняется в подписке.
+func (b *BookingServiceImpl) syncICalImport(ctx context.Context, icalImport *models.ICalImport) error {
+	log := b.log.With(zap.Int("import id", icalImport.ID), zap.Int("room id", icalImport.RoomID))
+
+	events, err := b.icalFeedClient.FetchEvents(ctx, icalImport.URL)
+	if err != nil {
+		log.Warn("failed to fetch ical feed", zap.Error(err))
+		if markErr := b.storage.MarkICalImportFailed(ctx, icalImport.ID, syncErrorReason(err)); markErr != nil {
+			log.Error("failed to save ical sync error", zap.Error(markErr))
+		}
+		return err
+	}
+
+	now := time.Now()
+	blocks := make([]*models.RoomBlock, 0, len(events))
+	for _, event := range events {
+		if event.Cancelled || !event.End.After(event.Start) || !event.End.After(now) {
+			continue
+		}
+		blocks = append(blocks, &models.RoomBlock{
+			HotelID:     icalImport.HotelID,
+			RoomID:      icalImport.RoomID,
+			StartDate:   event.Start,
+			EndDate:     event.End,
+			Source:      models.BlockSourceICal,
+			ImportID:    icalImport.ID,
+			ExternalUID: event.UID,
+			Summary:     event.Summary,
+		})
+	}
+	if err = b.storage.ReplaceImportedBlocks(ctx, icalImport.ID, blocks); err != nil {
+		log.Error("failed to save imported blocks", zap.Error(err))
+		return err
+	}
+	log.Debug("ical import synced", zap.Int("blocks", len(blocks)))
+	return nil
+}
+
+// checkHotelRoom проверяет, что отель принадлежит userID, а комната roomID (если задана) - этому отелю
+func (b *BookingServiceImpl) checkHotelRoom(ctx context.Context, hotelID, roomID, userID int) error {
+	if err := b.checkHotelOwner(ctx, hotelID, userID); err != nil {
+		return err
+	}
+	if roomID == 0 {
+		return nil
+	}
+	_, err := b.getRoomDetails(ctx, hotelID, roomID)
+	return err
+}
+
+// nightsEvent - событие на весь день по ночам периода: день выезда в событие не входит
+func nightsEvent(uid, summary string, startDate, endDate time.Time) ical.Event {
+	start, _ := models.DayBounds(startDate)
+	end, _ := models.DayBounds(endDate)
+	if !end.After(start) {
+		end = start.AddDate(0, 0, 1)
+	}
+	return ical.Event{UID: uid + "@booking-svc", Summary: summary, Start: start, End: end, AllDay: true}
+}
+
+// syncErrorReason - причина ошибки синхронизации для владельца отеля. Подробности загрузки остаются только в логах,
+// чтобы по ответам на адреса календарей нельзя было изучать сеть сервиса.
+func syncErrorReason(err error) string {
+	for _, reason := range []error{myerror.ErrICalFeedForbidden, myerror.ErrICalFeedUnavailable, myerror.ErrICalFeedInvalid} {
+		if errors.Is(err, reason) {
+			return reason.Error()
+		}
+	}
+	return "calendar sync failed"
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// Ответы внутренних адресов не должны попадать в last_error
+func TestSyncErrorReason(t *testing.T) {
+	dialErr := fmt.Errorf("dial tcp 10.0.0.5:6379: %w", myerror.ErrICalFeedForbidden)
+	assert.Equal(t, "calendar address is not allowed",
+		syncErrorReason(fmt.Errorf("sending request: %w: %w", myerror.ErrICalFeedUnavailable, dialErr)))
+	assert.Equal(t, "calendar could not be downloaded",
+		syncErrorReason(fmt.Errorf("ical feed status 500 Internal Server Error: %w", myerror.ErrICalFeedUnavailable)))
+	assert.Equal(t, "calendar could not be read",
+		syncErrorReason(fmt.Errorf("parsing ical feed: %w: line 3", myerror.ErrICalFeedInvalid)))
+	assert.Equal(t, "calendar sync failed", syncErrorReason(errors.New("failed to replace imported blocks")))
+}
@@ -0,0 +1,165 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: clients.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	authpb "github.com/Quizert/room-reservation-system/AuthSvc/pkj/authpb"
+	hotelpb "github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockHotelClient is a mock of HotelClient interface.
+type MockHotelClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockHotelClientMockRecorder
+}
+
+// MockHotelClientMockRecorder is the mock recorder for MockHotelClient.
+type MockHotelClientMockRecorder struct {
+	mock *MockHotelClient
+}
+
+// NewMockHotelClient creates a new mock instance.
+func NewMockHotelClient(ctrl *gomock.Controller) *MockHotelClient {
+	mock := &MockHotelClient{ctrl: ctrl}
+	mock.recorder = &MockHotelClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHotelClient) EXPECT() *MockHotelClientMockRecorder {
+	return m.recorder
+}
+
+// GetOwnerIdByHotelId mocks base method.
+func (m *MockHotelClient) GetOwnerIdByHotelId(ctx context.Context, req *hotelpb.GetOwnerIdRequest) (*hotelpb.GetOwnerIdResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOwnerIdByHotelId", ctx, req)
+	ret0, _ := ret[0].(*hotelpb.GetOwnerIdResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOwnerIdByHotelId indicates an expected call of GetOwnerIdByHotelId.
+func (mr *MockHotelClientMockRecorder) GetOwnerIdByHotelId(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOwnerIdByHotelId", reflect.TypeOf((*MockHotelClient)(nil).GetOwnerIdByHotelId), ctx, req)
+}
+
+// GetPriceQuotes mocks base method.
+func (m *MockHotelClient) GetPriceQuotes(ctx context.Context, req *hotelpb.GetPriceQuotesRequest) (*hotelpb.GetPriceQuotesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceQuotes", ctx, req)
+	ret0, _ := ret[0].(*hotelpb.GetPriceQuotesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceQuotes indicates an expected call of GetPriceQuotes.
+func (mr *MockHotelClientMockRecorder) GetPriceQuotes(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceQuotes", reflect.TypeOf((*MockHotelClient)(nil).GetPriceQuotes), ctx, req)
+}
+
+// GetPromoCode mocks base method.
+func (m *MockHotelClient) GetPromoCode(ctx context.Context, req *hotelpb.GetPromoCodeRequest) (*hotelpb.GetPromoCodeResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPromoCode", ctx, req)
+	ret0, _ := ret[0].(*hotelpb.GetPromoCodeResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPromoCode indicates an expected call of GetPromoCode.
+func (mr *MockHotelClientMockRecorder) GetPromoCode(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPromoCode", reflect.TypeOf((*MockHotelClient)(nil).GetPromoCode), ctx, req)
+}
+
+// GetRoomDetails mocks base method.
+func (m *MockHotelClient) GetRoomDetails(ctx context.Context, req *hotelpb.GetRoomDetailsRequest) (*hotelpb.GetRoomDetailsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomDetails", ctx, req)
+	ret0, _ := ret[0].(*hotelpb.GetRoomDetailsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomDetails indicates an expected call of GetRoomDetails.
+func (mr *MockHotelClientMockRecorder) GetRoomDetails(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomDetails", reflect.TypeOf((*MockHotelClient)(nil).GetRoomDetails), ctx, req)
+}
+
+// GetRoomsByHotelId mocks base method.
+func (m *MockHotelClient) GetRoomsByHotelId(ctx context.Context, req *hotelpb.GetRoomsRequest) (*hotelpb.GetRoomsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomsByHotelId", ctx, req)
+	ret0, _ := ret[0].(*hotelpb.GetRoomsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomsByHotelId indicates an expected call of GetRoomsByHotelId.
+func (mr *MockHotelClientMockRecorder) GetRoomsByHotelId(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomsByHotelId", reflect.TypeOf((*MockHotelClient)(nil).GetRoomsByHotelId), ctx, req)
+}
+
+// SearchHotels mocks base method.
+func (m *MockHotelClient) SearchHotels(ctx context.Context, req *hotelpb.SearchHotelsRequest) (*hotelpb.SearchHotelsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchHotels", ctx, req)
+	ret0, _ := ret[0].(*hotelpb.SearchHotelsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchHotels indicates an expected call of SearchHotels.
+func (mr *MockHotelClientMockRecorder) SearchHotels(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchHotels", reflect.TypeOf((*MockHotelClient)(nil).SearchHotels), ctx, req)
+}
+
+// MockAuthSvcClient is a mock of AuthSvcClient interface.
+type MockAuthSvcClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthSvcClientMockRecorder
+}
+
+// MockAuthSvcClientMockRecorder is the mock recorder for MockAuthSvcClient.
+type MockAuthSvcClientMockRecorder struct {
+	mock *MockAuthSvcClient
+}
+
+// NewMockAuthSvcClient creates a new mock instance.
+func NewMockAuthSvcClient(ctrl *gomock.Controller) *MockAuthSvcClient {
+	mock := &MockAuthSvcClient{ctrl: ctrl}
+	mock.recorder = &MockAuthSvcClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthSvcClient) EXPECT() *MockAuthSvcClientMockRecorder {
+	return m.recorder
+}
+
+// GetHotelierInformation mocks base method.
+func (m *MockAuthSvcClient) GetHotelierInformation(ctx context.Context, request *authpb.GetHotelierRequest) (*authpb.GetHotelierResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHotelierInformation", ctx, request)
+	ret0, _ := ret[0].(*authpb.GetHotelierResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHotelierInformation indicates an expected call of GetHotelierInformation.
+func (mr *MockAuthSvcClientMockRecorder) GetHotelierInformation(ctx, request interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHotelierInformation", reflect.TypeOf((*MockAuthSvcClient)(nil).GetHotelierInformation), ctx, request)
+}
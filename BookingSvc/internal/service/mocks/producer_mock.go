@@ -0,0 +1,63 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: producer.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessageProducer is a mock of MessageProducer interface.
+type MockMessageProducer struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageProducerMockRecorder
+}
+
+// MockMessageProducerMockRecorder is the mock recorder for MockMessageProducer.
+type MockMessageProducerMockRecorder struct {
+	mock *MockMessageProducer
+}
+
+// NewMockMessageProducer creates a new mock instance.
+func NewMockMessageProducer(ctrl *gomock.Controller) *MockMessageProducer {
+	mock := &MockMessageProducer{ctrl: ctrl}
+	mock.recorder = &MockMessageProducerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageProducer) EXPECT() *MockMessageProducerMockRecorder {
+	return m.recorder
+}
+
+// SendHotelierMessage mocks base method.
+func (m *MockMessageProducer) SendHotelierMessage(ctx context.Context, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHotelierMessage", ctx, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHotelierMessage indicates an expected call of SendHotelierMessage.
+func (mr *MockMessageProducerMockRecorder) SendHotelierMessage(ctx, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHotelierMessage", reflect.TypeOf((*MockMessageProducer)(nil).SendHotelierMessage), ctx, value)
+}
+
+// SendUserMessage mocks base method.
+func (m *MockMessageProducer) SendUserMessage(ctx context.Context, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendUserMessage", ctx, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendUserMessage indicates an expected call of SendUserMessage.
+func (mr *MockMessageProducerMockRecorder) SendUserMessage(ctx, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendUserMessage", reflect.TypeOf((*MockMessageProducer)(nil).SendUserMessage), ctx, value)
+}
@@ -0,0 +1,779 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: storage.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	models "github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockStorage is a mock of Storage interface.
+type MockStorage struct {
+	ctrl     *gomock.Controller
+	recorder *MockStorageMockRecorder
+}
+
+// MockStorageMockRecorder is the mock recorder for MockStorage.
+type MockStorageMockRecorder struct {
+	mock *MockStorage
+}
+
+// NewMockStorage creates a new mock instance.
+func NewMockStorage(ctrl *gomock.Controller) *MockStorage {
+	mock := &MockStorage{ctrl: ctrl}
+	mock.recorder = &MockStorageMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStorage) EXPECT() *MockStorageMockRecorder {
+	return m.recorder
+}
+
+// AbandonBooking mocks base method.
+func (m *MockStorage) AbandonBooking(ctx context.Context, change *models.StatusTransition, bookingID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AbandonBooking", ctx, change, bookingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AbandonBooking indicates an expected call of AbandonBooking.
+func (mr *MockStorageMockRecorder) AbandonBooking(ctx, change, bookingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AbandonBooking", reflect.TypeOf((*MockStorage)(nil).AbandonBooking), ctx, change, bookingID)
+}
+
+// ApplyBookingModification mocks base method.
+func (m *MockStorage) ApplyBookingModification(ctx context.Context, modificationID int, booking *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyBookingModification", ctx, modificationID, booking, change, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyBookingModification indicates an expected call of ApplyBookingModification.
+func (mr *MockStorageMockRecorder) ApplyBookingModification(ctx, modificationID, booking, change, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyBookingModification", reflect.TypeOf((*MockStorage)(nil).ApplyBookingModification), ctx, modificationID, booking, change, outbox)
+}
+
+// CancelBooking mocks base method.
+func (m *MockStorage) CancelBooking(ctx context.Context, bookingID int, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelBooking", ctx, bookingID, change, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelBooking indicates an expected call of CancelBooking.
+func (mr *MockStorageMockRecorder) CancelBooking(ctx, bookingID, change, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelBooking", reflect.TypeOf((*MockStorage)(nil).CancelBooking), ctx, bookingID, change, outbox)
+}
+
+// ClaimWaitlistOffer mocks base method.
+func (m *MockStorage) ClaimWaitlistOffer(ctx context.Context, token string, userID int) (*models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimWaitlistOffer", ctx, token, userID)
+	ret0, _ := ret[0].(*models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimWaitlistOffer indicates an expected call of ClaimWaitlistOffer.
+func (mr *MockStorageMockRecorder) ClaimWaitlistOffer(ctx, token, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimWaitlistOffer", reflect.TypeOf((*MockStorage)(nil).ClaimWaitlistOffer), ctx, token, userID)
+}
+
+// CreateBooking mocks base method.
+func (m *MockStorage) CreateBooking(ctx context.Context, booking *models.Booking, promo *models.PromoCode) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBooking", ctx, booking, promo)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBooking indicates an expected call of CreateBooking.
+func (mr *MockStorageMockRecorder) CreateBooking(ctx, booking, promo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBooking", reflect.TypeOf((*MockStorage)(nil).CreateBooking), ctx, booking, promo)
+}
+
+// CreateBookingModification mocks base method.
+func (m *MockStorage) CreateBookingModification(ctx context.Context, modification *models.BookingModification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBookingModification", ctx, modification)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateBookingModification indicates an expected call of CreateBookingModification.
+func (mr *MockStorageMockRecorder) CreateBookingModification(ctx, modification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBookingModification", reflect.TypeOf((*MockStorage)(nil).CreateBookingModification), ctx, modification)
+}
+
+// CreateGroupBooking mocks base method.
+func (m *MockStorage) CreateGroupBooking(ctx context.Context, group *models.GroupBooking) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGroupBooking", ctx, group)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateGroupBooking indicates an expected call of CreateGroupBooking.
+func (mr *MockStorageMockRecorder) CreateGroupBooking(ctx, group interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGroupBooking", reflect.TypeOf((*MockStorage)(nil).CreateGroupBooking), ctx, group)
+}
+
+// CreateICalFeed mocks base method.
+func (m *MockStorage) CreateICalFeed(ctx context.Context, feed *models.ICalFeed, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateICalFeed", ctx, feed, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateICalFeed indicates an expected call of CreateICalFeed.
+func (mr *MockStorageMockRecorder) CreateICalFeed(ctx, feed, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateICalFeed", reflect.TypeOf((*MockStorage)(nil).CreateICalFeed), ctx, feed, userID)
+}
+
+// CreateICalImport mocks base method.
+func (m *MockStorage) CreateICalImport(ctx context.Context, icalImport *models.ICalImport, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateICalImport", ctx, icalImport, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateICalImport indicates an expected call of CreateICalImport.
+func (mr *MockStorageMockRecorder) CreateICalImport(ctx, icalImport, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateICalImport", reflect.TypeOf((*MockStorage)(nil).CreateICalImport), ctx, icalImport, userID)
+}
+
+// CreateRoomBlock mocks base method.
+func (m *MockStorage) CreateRoomBlock(ctx context.Context, block *models.RoomBlock, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRoomBlock", ctx, block, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateRoomBlock indicates an expected call of CreateRoomBlock.
+func (mr *MockStorageMockRecorder) CreateRoomBlock(ctx, block, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRoomBlock", reflect.TypeOf((*MockStorage)(nil).CreateRoomBlock), ctx, block, userID)
+}
+
+// CreateWaitlistEntry mocks base method.
+func (m *MockStorage) CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWaitlistEntry", ctx, entry)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWaitlistEntry indicates an expected call of CreateWaitlistEntry.
+func (mr *MockStorageMockRecorder) CreateWaitlistEntry(ctx, entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWaitlistEntry", reflect.TypeOf((*MockStorage)(nil).CreateWaitlistEntry), ctx, entry)
+}
+
+// DeleteICalFeed mocks base method.
+func (m *MockStorage) DeleteICalFeed(ctx context.Context, token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteICalFeed", ctx, token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteICalFeed indicates an expected call of DeleteICalFeed.
+func (mr *MockStorageMockRecorder) DeleteICalFeed(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteICalFeed", reflect.TypeOf((*MockStorage)(nil).DeleteICalFeed), ctx, token)
+}
+
+// DeleteICalImport mocks base method.
+func (m *MockStorage) DeleteICalImport(ctx context.Context, importID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteICalImport", ctx, importID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteICalImport indicates an expected call of DeleteICalImport.
+func (mr *MockStorageMockRecorder) DeleteICalImport(ctx, importID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteICalImport", reflect.TypeOf((*MockStorage)(nil).DeleteICalImport), ctx, importID)
+}
+
+// DeletePaymentWebhook mocks base method.
+func (m *MockStorage) DeletePaymentWebhook(ctx context.Context, signature string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePaymentWebhook", ctx, signature)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePaymentWebhook indicates an expected call of DeletePaymentWebhook.
+func (mr *MockStorageMockRecorder) DeletePaymentWebhook(ctx, signature interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePaymentWebhook", reflect.TypeOf((*MockStorage)(nil).DeletePaymentWebhook), ctx, signature)
+}
+
+// DeleteRoomBlock mocks base method.
+func (m *MockStorage) DeleteRoomBlock(ctx context.Context, blockID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRoomBlock", ctx, blockID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRoomBlock indicates an expected call of DeleteRoomBlock.
+func (mr *MockStorageMockRecorder) DeleteRoomBlock(ctx, blockID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRoomBlock", reflect.TypeOf((*MockStorage)(nil).DeleteRoomBlock), ctx, blockID)
+}
+
+// ExpireBookingModifications mocks base method.
+func (m *MockStorage) ExpireBookingModifications(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireBookingModifications", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireBookingModifications indicates an expected call of ExpireBookingModifications.
+func (mr *MockStorageMockRecorder) ExpireBookingModifications(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireBookingModifications", reflect.TypeOf((*MockStorage)(nil).ExpireBookingModifications), ctx)
+}
+
+// ExpireBookings mocks base method.
+func (m *MockStorage) ExpireBookings(ctx context.Context, change *models.StatusTransition, toOutbox func(*models.Booking) ([]*models.OutboxMessage, error)) ([]*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireBookings", ctx, change, toOutbox)
+	ret0, _ := ret[0].([]*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireBookings indicates an expected call of ExpireBookings.
+func (mr *MockStorageMockRecorder) ExpireBookings(ctx, change, toOutbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireBookings", reflect.TypeOf((*MockStorage)(nil).ExpireBookings), ctx, change, toOutbox)
+}
+
+// ExpireWaitlistEntries mocks base method.
+func (m *MockStorage) ExpireWaitlistEntries(ctx context.Context) ([]*models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpireWaitlistEntries", ctx)
+	ret0, _ := ret[0].([]*models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpireWaitlistEntries indicates an expected call of ExpireWaitlistEntries.
+func (mr *MockStorageMockRecorder) ExpireWaitlistEntries(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpireWaitlistEntries", reflect.TypeOf((*MockStorage)(nil).ExpireWaitlistEntries), ctx)
+}
+
+// GetActiveBookingsByHotelPeriod mocks base method.
+func (m *MockStorage) GetActiveBookingsByHotelPeriod(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveBookingsByHotelPeriod", ctx, hotelID, startDate, endDate)
+	ret0, _ := ret[0].([]*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveBookingsByHotelPeriod indicates an expected call of GetActiveBookingsByHotelPeriod.
+func (mr *MockStorageMockRecorder) GetActiveBookingsByHotelPeriod(ctx, hotelID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveBookingsByHotelPeriod", reflect.TypeOf((*MockStorage)(nil).GetActiveBookingsByHotelPeriod), ctx, hotelID, startDate, endDate)
+}
+
+// GetBookingByID mocks base method.
+func (m *MockStorage) GetBookingByID(ctx context.Context, bookingID int) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingByID", ctx, bookingID)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingByID indicates an expected call of GetBookingByID.
+func (mr *MockStorageMockRecorder) GetBookingByID(ctx, bookingID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingByID", reflect.TypeOf((*MockStorage)(nil).GetBookingByID), ctx, bookingID)
+}
+
+// GetBookingByIdempotencyKey mocks base method.
+func (m *MockStorage) GetBookingByIdempotencyKey(ctx context.Context, userID int, key string, since time.Time) (*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingByIdempotencyKey", ctx, userID, key, since)
+	ret0, _ := ret[0].(*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingByIdempotencyKey indicates an expected call of GetBookingByIdempotencyKey.
+func (mr *MockStorageMockRecorder) GetBookingByIdempotencyKey(ctx, userID, key, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingByIdempotencyKey", reflect.TypeOf((*MockStorage)(nil).GetBookingByIdempotencyKey), ctx, userID, key, since)
+}
+
+// GetBookingModification mocks base method.
+func (m *MockStorage) GetBookingModification(ctx context.Context, modificationID int) (*models.BookingModification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingModification", ctx, modificationID)
+	ret0, _ := ret[0].(*models.BookingModification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingModification indicates an expected call of GetBookingModification.
+func (mr *MockStorageMockRecorder) GetBookingModification(ctx, modificationID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingModification", reflect.TypeOf((*MockStorage)(nil).GetBookingModification), ctx, modificationID)
+}
+
+// GetBookingsByGroupID mocks base method.
+func (m *MockStorage) GetBookingsByGroupID(ctx context.Context, groupID int) ([]*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByGroupID", ctx, groupID)
+	ret0, _ := ret[0].([]*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByGroupID indicates an expected call of GetBookingsByGroupID.
+func (mr *MockStorageMockRecorder) GetBookingsByGroupID(ctx, groupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByGroupID", reflect.TypeOf((*MockStorage)(nil).GetBookingsByGroupID), ctx, groupID)
+}
+
+// GetBookingsByHotelID mocks base method.
+func (m *MockStorage) GetBookingsByHotelID(ctx context.Context, hotelID int, filter *models.BookingFilter) ([]*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByHotelID", ctx, hotelID, filter)
+	ret0, _ := ret[0].([]*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByHotelID indicates an expected call of GetBookingsByHotelID.
+func (mr *MockStorageMockRecorder) GetBookingsByHotelID(ctx, hotelID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByHotelID", reflect.TypeOf((*MockStorage)(nil).GetBookingsByHotelID), ctx, hotelID, filter)
+}
+
+// GetBookingsByUserID mocks base method.
+func (m *MockStorage) GetBookingsByUserID(ctx context.Context, userID int, filter *models.BookingFilter) ([]*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBookingsByUserID", ctx, userID, filter)
+	ret0, _ := ret[0].([]*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBookingsByUserID indicates an expected call of GetBookingsByUserID.
+func (mr *MockStorageMockRecorder) GetBookingsByUserID(ctx, userID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBookingsByUserID", reflect.TypeOf((*MockStorage)(nil).GetBookingsByUserID), ctx, userID, filter)
+}
+
+// GetICalFeed mocks base method.
+func (m *MockStorage) GetICalFeed(ctx context.Context, token string) (*models.ICalFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetICalFeed", ctx, token)
+	ret0, _ := ret[0].(*models.ICalFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetICalFeed indicates an expected call of GetICalFeed.
+func (mr *MockStorageMockRecorder) GetICalFeed(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetICalFeed", reflect.TypeOf((*MockStorage)(nil).GetICalFeed), ctx, token)
+}
+
+// GetICalImport mocks base method.
+func (m *MockStorage) GetICalImport(ctx context.Context, importID int) (*models.ICalImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetICalImport", ctx, importID)
+	ret0, _ := ret[0].(*models.ICalImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetICalImport indicates an expected call of GetICalImport.
+func (mr *MockStorageMockRecorder) GetICalImport(ctx, importID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetICalImport", reflect.TypeOf((*MockStorage)(nil).GetICalImport), ctx, importID)
+}
+
+// GetICalImports mocks base method.
+func (m *MockStorage) GetICalImports(ctx context.Context, hotelID int) ([]*models.ICalImport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetICalImports", ctx, hotelID)
+	ret0, _ := ret[0].([]*models.ICalImport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetICalImports indicates an expected call of GetICalImports.
+func (mr *MockStorageMockRecorder) GetICalImports(ctx, hotelID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetICalImports", reflect.TypeOf((*MockStorage)(nil).GetICalImports), ctx, hotelID)
+}
+
+// GetPendingOutbox mocks base method.
+func (m *MockStorage) GetPendingOutbox(ctx context.Context, limit int) ([]*models.OutboxMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingOutbox", ctx, limit)
+	ret0, _ := ret[0].([]*models.OutboxMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingOutbox indicates an expected call of GetPendingOutbox.
+func (mr *MockStorageMockRecorder) GetPendingOutbox(ctx, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingOutbox", reflect.TypeOf((*MockStorage)(nil).GetPendingOutbox), ctx, limit)
+}
+
+// GetRoomBlock mocks base method.
+func (m *MockStorage) GetRoomBlock(ctx context.Context, blockID int64) (*models.RoomBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomBlock", ctx, blockID)
+	ret0, _ := ret[0].(*models.RoomBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomBlock indicates an expected call of GetRoomBlock.
+func (mr *MockStorageMockRecorder) GetRoomBlock(ctx, blockID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomBlock", reflect.TypeOf((*MockStorage)(nil).GetRoomBlock), ctx, blockID)
+}
+
+// GetRoomBlocks mocks base method.
+func (m *MockStorage) GetRoomBlocks(ctx context.Context, hotelID, roomID int, from, to *time.Time) ([]*models.RoomBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomBlocks", ctx, hotelID, roomID, from, to)
+	ret0, _ := ret[0].([]*models.RoomBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomBlocks indicates an expected call of GetRoomBlocks.
+func (mr *MockStorageMockRecorder) GetRoomBlocks(ctx, hotelID, roomID, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomBlocks", reflect.TypeOf((*MockStorage)(nil).GetRoomBlocks), ctx, hotelID, roomID, from, to)
+}
+
+// GetRoomBlocksByHotelPeriod mocks base method.
+func (m *MockStorage) GetRoomBlocksByHotelPeriod(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*models.RoomBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRoomBlocksByHotelPeriod", ctx, hotelID, startDate, endDate)
+	ret0, _ := ret[0].([]*models.RoomBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRoomBlocksByHotelPeriod indicates an expected call of GetRoomBlocksByHotelPeriod.
+func (mr *MockStorageMockRecorder) GetRoomBlocksByHotelPeriod(ctx, hotelID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRoomBlocksByHotelPeriod", reflect.TypeOf((*MockStorage)(nil).GetRoomBlocksByHotelPeriod), ctx, hotelID, startDate, endDate)
+}
+
+// GetStalePendingBookings mocks base method.
+func (m *MockStorage) GetStalePendingBookings(ctx context.Context, pendingSince time.Time, limit int) ([]*models.Booking, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStalePendingBookings", ctx, pendingSince, limit)
+	ret0, _ := ret[0].([]*models.Booking)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStalePendingBookings indicates an expected call of GetStalePendingBookings.
+func (mr *MockStorageMockRecorder) GetStalePendingBookings(ctx, pendingSince, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStalePendingBookings", reflect.TypeOf((*MockStorage)(nil).GetStalePendingBookings), ctx, pendingSince, limit)
+}
+
+// GetStatusHistory mocks base method.
+func (m *MockStorage) GetStatusHistory(ctx context.Context, bookingIDs []int) (map[int][]*models.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStatusHistory", ctx, bookingIDs)
+	ret0, _ := ret[0].(map[int][]*models.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStatusHistory indicates an expected call of GetStatusHistory.
+func (mr *MockStorageMockRecorder) GetStatusHistory(ctx, bookingIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatusHistory", reflect.TypeOf((*MockStorage)(nil).GetStatusHistory), ctx, bookingIDs)
+}
+
+// GetUnavailableRoomsByHotelIDs mocks base method.
+func (m *MockStorage) GetUnavailableRoomsByHotelIDs(ctx context.Context, hotelIDs []int, startDate, endDate time.Time) (map[int]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnavailableRoomsByHotelIDs", ctx, hotelIDs, startDate, endDate)
+	ret0, _ := ret[0].(map[int]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnavailableRoomsByHotelIDs indicates an expected call of GetUnavailableRoomsByHotelIDs.
+func (mr *MockStorageMockRecorder) GetUnavailableRoomsByHotelIDs(ctx, hotelIDs, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnavailableRoomsByHotelIDs", reflect.TypeOf((*MockStorage)(nil).GetUnavailableRoomsByHotelIDs), ctx, hotelIDs, startDate, endDate)
+}
+
+// GetUnavailableRoomsByHotelId mocks base method.
+func (m *MockStorage) GetUnavailableRoomsByHotelId(ctx context.Context, HotelID int, startDate, endDate time.Time) (map[int]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUnavailableRoomsByHotelId", ctx, HotelID, startDate, endDate)
+	ret0, _ := ret[0].(map[int]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUnavailableRoomsByHotelId indicates an expected call of GetUnavailableRoomsByHotelId.
+func (mr *MockStorageMockRecorder) GetUnavailableRoomsByHotelId(ctx, HotelID, startDate, endDate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUnavailableRoomsByHotelId", reflect.TypeOf((*MockStorage)(nil).GetUnavailableRoomsByHotelId), ctx, HotelID, startDate, endDate)
+}
+
+// GetWaitlistCandidates mocks base method.
+func (m *MockStorage) GetWaitlistCandidates(ctx context.Context, hotelID, roomTypeID int, startDate, endDate time.Time, limit int) ([]*models.WaitlistEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWaitlistCandidates", ctx, hotelID, roomTypeID, startDate, endDate, limit)
+	ret0, _ := ret[0].([]*models.WaitlistEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWaitlistCandidates indicates an expected call of GetWaitlistCandidates.
+func (mr *MockStorageMockRecorder) GetWaitlistCandidates(ctx, hotelID, roomTypeID, startDate, endDate, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWaitlistCandidates", reflect.TypeOf((*MockStorage)(nil).GetWaitlistCandidates), ctx, hotelID, roomTypeID, startDate, endDate, limit)
+}
+
+// MarkICalImportFailed mocks base method.
+func (m *MockStorage) MarkICalImportFailed(ctx context.Context, importID int, reason string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkICalImportFailed", ctx, importID, reason)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkICalImportFailed indicates an expected call of MarkICalImportFailed.
+func (mr *MockStorageMockRecorder) MarkICalImportFailed(ctx, importID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkICalImportFailed", reflect.TypeOf((*MockStorage)(nil).MarkICalImportFailed), ctx, importID, reason)
+}
+
+// MarkOutboxFailed mocks base method.
+func (m *MockStorage) MarkOutboxFailed(ctx context.Context, messageID int64, reason string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxFailed", ctx, messageID, reason)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkOutboxFailed indicates an expected call of MarkOutboxFailed.
+func (mr *MockStorageMockRecorder) MarkOutboxFailed(ctx, messageID, reason interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxFailed", reflect.TypeOf((*MockStorage)(nil).MarkOutboxFailed), ctx, messageID, reason)
+}
+
+// MarkOutboxSent mocks base method.
+func (m *MockStorage) MarkOutboxSent(ctx context.Context, messageID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkOutboxSent", ctx, messageID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkOutboxSent indicates an expected call of MarkOutboxSent.
+func (mr *MockStorageMockRecorder) MarkOutboxSent(ctx, messageID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkOutboxSent", reflect.TypeOf((*MockStorage)(nil).MarkOutboxSent), ctx, messageID)
+}
+
+// OfferWaitlistEntry mocks base method.
+func (m *MockStorage) OfferWaitlistEntry(ctx context.Context, entryID, roomID int, token string, expiresAt time.Time, outbox []*models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OfferWaitlistEntry", ctx, entryID, roomID, token, expiresAt, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// OfferWaitlistEntry indicates an expected call of OfferWaitlistEntry.
+func (mr *MockStorageMockRecorder) OfferWaitlistEntry(ctx, entryID, roomID, token, expiresAt, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OfferWaitlistEntry", reflect.TypeOf((*MockStorage)(nil).OfferWaitlistEntry), ctx, entryID, roomID, token, expiresAt, outbox)
+}
+
+// ReleaseIdempotencyKeys mocks base method.
+func (m *MockStorage) ReleaseIdempotencyKeys(ctx context.Context, olderThan time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIdempotencyKeys", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleaseIdempotencyKeys indicates an expected call of ReleaseIdempotencyKeys.
+func (mr *MockStorageMockRecorder) ReleaseIdempotencyKeys(ctx, olderThan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIdempotencyKeys", reflect.TypeOf((*MockStorage)(nil).ReleaseIdempotencyKeys), ctx, olderThan)
+}
+
+// ReleasePaymentWebhooks mocks base method.
+func (m *MockStorage) ReleasePaymentWebhooks(ctx context.Context, olderThan time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleasePaymentWebhooks", ctx, olderThan)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReleasePaymentWebhooks indicates an expected call of ReleasePaymentWebhooks.
+func (mr *MockStorageMockRecorder) ReleasePaymentWebhooks(ctx, olderThan interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleasePaymentWebhooks", reflect.TypeOf((*MockStorage)(nil).ReleasePaymentWebhooks), ctx, olderThan)
+}
+
+// ReplaceImportedBlocks mocks base method.
+func (m *MockStorage) ReplaceImportedBlocks(ctx context.Context, importID int, blocks []*models.RoomBlock) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceImportedBlocks", ctx, importID, blocks)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReplaceImportedBlocks indicates an expected call of ReplaceImportedBlocks.
+func (mr *MockStorageMockRecorder) ReplaceImportedBlocks(ctx, importID, blocks interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceImportedBlocks", reflect.TypeOf((*MockStorage)(nil).ReplaceImportedBlocks), ctx, importID, blocks)
+}
+
+// RequeueWaitlistEntry mocks base method.
+func (m *MockStorage) RequeueWaitlistEntry(ctx context.Context, entryID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequeueWaitlistEntry", ctx, entryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RequeueWaitlistEntry indicates an expected call of RequeueWaitlistEntry.
+func (mr *MockStorageMockRecorder) RequeueWaitlistEntry(ctx, entryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequeueWaitlistEntry", reflect.TypeOf((*MockStorage)(nil).RequeueWaitlistEntry), ctx, entryID)
+}
+
+// ResolveBookingModification mocks base method.
+func (m *MockStorage) ResolveBookingModification(ctx context.Context, modificationID int, from, to string, outbox []*models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveBookingModification", ctx, modificationID, from, to, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResolveBookingModification indicates an expected call of ResolveBookingModification.
+func (mr *MockStorageMockRecorder) ResolveBookingModification(ctx, modificationID, from, to, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveBookingModification", reflect.TypeOf((*MockStorage)(nil).ResolveBookingModification), ctx, modificationID, from, to, outbox)
+}
+
+// SavePaymentWebhook mocks base method.
+func (m *MockStorage) SavePaymentWebhook(ctx context.Context, signature string, signedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePaymentWebhook", ctx, signature, signedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePaymentWebhook indicates an expected call of SavePaymentWebhook.
+func (mr *MockStorageMockRecorder) SavePaymentWebhook(ctx, signature, signedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePaymentWebhook", reflect.TypeOf((*MockStorage)(nil).SavePaymentWebhook), ctx, signature, signedAt)
+}
+
+// StreamBookingsByHotelID mocks base method.
+func (m *MockStorage) StreamBookingsByHotelID(ctx context.Context, hotelID int, filter *models.BookingFilter, fn func(*models.Booking) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamBookingsByHotelID", ctx, hotelID, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamBookingsByHotelID indicates an expected call of StreamBookingsByHotelID.
+func (mr *MockStorageMockRecorder) StreamBookingsByHotelID(ctx, hotelID, filter, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamBookingsByHotelID", reflect.TypeOf((*MockStorage)(nil).StreamBookingsByHotelID), ctx, hotelID, filter, fn)
+}
+
+// UpdateBooking mocks base method.
+func (m *MockStorage) UpdateBooking(ctx context.Context, booking *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBooking", ctx, booking, change, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBooking indicates an expected call of UpdateBooking.
+func (mr *MockStorageMockRecorder) UpdateBooking(ctx, booking, change, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBooking", reflect.TypeOf((*MockStorage)(nil).UpdateBooking), ctx, booking, change, outbox)
+}
+
+// UpdateBookingStatus mocks base method.
+func (m *MockStorage) UpdateBookingStatus(ctx context.Context, change *models.StatusTransition, bookingID int, outbox []*models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateBookingStatus", ctx, change, bookingID, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateBookingStatus indicates an expected call of UpdateBookingStatus.
+func (mr *MockStorageMockRecorder) UpdateBookingStatus(ctx, change, bookingID, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBookingStatus", reflect.TypeOf((*MockStorage)(nil).UpdateBookingStatus), ctx, change, bookingID, outbox)
+}
+
+// UpdateGroupStatus mocks base method.
+func (m *MockStorage) UpdateGroupStatus(ctx context.Context, change *models.StatusTransition, groupID int, outbox []*models.OutboxMessage) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGroupStatus", ctx, change, groupID, outbox)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGroupStatus indicates an expected call of UpdateGroupStatus.
+func (mr *MockStorageMockRecorder) UpdateGroupStatus(ctx, change, groupID, outbox interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGroupStatus", reflect.TypeOf((*MockStorage)(nil).UpdateGroupStatus), ctx, change, groupID, outbox)
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"go.uber.org/zap"
+	"strconv"
+	"time"
+)
+
+// requestSurcharge сохраняет изменение, ожидающее доплаты, и запрашивает доплату в PaymentSystem.
+// Если запрос доплаты не ушёл, изменение закрывается, и бронирование можно изменить снова.
+func (b *BookingServiceImpl) requestSurcharge(ctx context.Context, booking *models.Booking, modification *models.BookingModification, cardNumber string, user *models.User) error {
+	err := b.storage.CreateBookingModification(ctx, modification)
+	if err != nil {
+		if errors.Is(err, myerror.ErrInvalidBookingStatus) {
+			b.log.Warn("in service requestSurcharge", zap.Error(err))
+			return err
+		}
+		b.log.Error("in service requestSurcharge", zap.Error(err))
+		return err
+	}
+
+	updated := modification.Apply(booking)
+	startDateStr := updated.StartDate.Format(messageDateLayout)
+	endDateStr := updated.EndDate.Format(messageDateLayout)
+	bookingMessage := updated.ToBookingMessage(models.EventBookingModified, user.Username, user.ChatID, startDateStr, endDateStr)
+	paymentRequest := models.ToSurchargePaymentRequest(bookingMessage, cardNumber, modification.ID, modification.Surcharge)
+	if err = b.paymentSystemClient.CreatePaymentRequest(ctx, paymentRequest); err != nil {
+		b.log.Error("in service requestSurcharge", zap.Error(err))
+		// Если доплата всё же пройдёт, вебхук по закрытому изменению её вернёт
+		resolveErr := b.storage.ResolveBookingModification(ctx, modification.ID, models.ModificationPending, models.ModificationPaymentFailed, nil)
+		if resolveErr != nil {
+			b.log.Error("in service requestSurcharge", zap.Error(resolveErr))
+		}
+		return fmt.Errorf("error in payment request: %w", err)
+	}
+	return nil
+}
+
+// applySurchargeStatus применяет результат доплаты за изменение бронирования.
+// Неуспешная доплата только закрывает изменение: бронирование остаётся подтверждённым на прежних условиях.
+// Успешная переносит новые условия в бронирование, а если изменение уже нельзя применить - возвращается.
+func (b *BookingServiceImpl) applySurchargeStatus(ctx context.Context, paymentStatus string, bookingID, modificationID int) error {
+	status, ok := models.StatusFromPayment(paymentStatus)
+	if !ok {
+		b.log.Warn("error in service applySurchargeStatus", zap.String("payment status", paymentStatus))
+		return fmt.Errorf("error in service applySurchargeStatus: payment status %q: %w", paymentStatus, myerror.ErrInvalidPaymentStatus)
+	}
+	modification, err := b.storage.GetBookingModification(ctx, modificationID)
+	if err == nil && modification.BookingID != bookingID {
+		err = myerror.ErrModificationNotFound
+	}
+	if err != nil {
+		if errors.Is(err, myerror.ErrModificationNotFound) {
+			b.log.Warn("error in service applySurchargeStatus", zap.Error(err))
+			return fmt.Errorf("error in service applySurchargeStatus: %w", myerror.ErrBookingNotFound)
+		}
+		b.log.Error("error in service applySurchargeStatus", zap.Error(err))
+		return fmt.Errorf("error in service applySurchargeStatus: %w", err)
+	}
+	log := b.log.With(zap.Int("booking id", bookingID), zap.Int("modification id", modificationID))
+
+	if status == models.StatusPaymentFailed {
+		err = b.storage.ResolveBookingModification(ctx, modification.ID, models.ModificationPending, models.ModificationPaymentFailed, nil)
+		if err != nil {
+			log.Warn("error in service applySurchargeStatus", zap.Error(err))
+			return fmt.Errorf("error in service applySurchargeStatus: %w", err)
+		}
+		log.Info("booking modification surcharge failed, booking keeps previous terms")
+		return nil
+	}
+
+	if modification.Status == models.ModificationPending && time.Now().Before(modification.HoldExpiresAt) {
+		err = b.applyModification(ctx, modification)
+		if err == nil {
+			log.Info("booking modification applied after surcharge")
+			return nil
+		}
+		// Комнату заняли или бронирование отменили, пока шла доплата
+		if !errors.Is(err, myerror.ErrBookingAlreadyExists) && !errors.Is(err, myerror.ErrInvalidBookingStatus) {
+			log.Error("error in service applySurchargeStatus", zap.Error(err))
+			return fmt.Errorf("error in service applySurchargeStatus: %w", err)
+		}
+		log.Warn("paid booking modification can not be applied, refunding surcharge", zap.Error(err))
+	}
+	if err = b.refundSurcharge(ctx, modification); err != nil {
+		log.Warn("error in service applySurchargeStatus", zap.Error(err))
+		return fmt.Errorf("error in service applySurchargeStatus: %w", err)
+	}
+	log.Info("surcharge for unapplied booking modification refunded", zap.Int("surcharge", modification.Surcharge))
+	return nil
+}
+
+// applyModification переносит новые условия в бронирование и уведомляет гостя и владельца отеля об изменении
+func (b *BookingServiceImpl) applyModification(ctx context.Context, modification *models.BookingModification) error {
+	booking, err := b.storage.GetBookingByID(ctx, modification.BookingID)
+	if err != nil {
+		return err
+	}
+	updated := modification.Apply(booking)
+	startDateStr := updated.StartDate.Format(messageDateLayout)
+	endDateStr := updated.EndDate.Format(messageDateLayout)
+	bookingMessage := updated.ToBookingMessage(models.EventBookingModified, booking.Username, booking.ChatID, startDateStr, endDateStr)
+	outbox, err := bookingOutbox(bookingMessage)
+	if err != nil {
+		return err
+	}
+	change := &models.StatusTransition{From: models.StatusConfirmed, To: models.StatusConfirmed, Actor: models.ActorPaymentSystem}
+	return b.storage.ApplyBookingModification(ctx, modification.ID, updated, change, outbox)
+}
+
+// refundSurcharge закрывает неприменённое изменение и возвращает доплату через outbox.
+// Ключ возврата привязан к изменению, поэтому доплата не вернётся дважды.
+func (b *BookingServiceImpl) refundSurcharge(ctx context.Context, modification *models.BookingModification) error {
+	refundRequest := models.ToRefundRequest(modification.OrderID(), modification.Surcharge)
+	refundRequest.RefundID = "modification-" + strconv.Itoa(modification.ID)
+	refund, err := refundOutbox(refundRequest)
+	if err != nil {
+		return err
+	}
+	return b.storage.ResolveBookingModification(ctx, modification.ID, modification.Status, models.ModificationRefunded,
+		[]*models.OutboxMessage{refund})
+}
@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/service/mocks"
+	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"testing"
+	"time"
+)
+
+type testService struct {
+	service *BookingServiceImpl
+	storage *mocks.MockStorage
+	hotel   *mocks.MockHotelClient
+	payment *mocks.MockPaymentSystemClient
+}
+
+func newTestService(t *testing.T) *testService {
+	ctrl := gomock.NewController(t)
+	ts := &testService{
+		storage: mocks.NewMockStorage(ctrl),
+		hotel:   mocks.NewMockHotelClient(ctrl),
+		payment: mocks.NewMockPaymentSystemClient(ctrl),
+	}
+	ts.service = NewBookingServiceImpl(ts.storage, mocks.NewMockMessageProducer(ctrl), ts.hotel, mocks.NewMockAuthSvcClient(ctrl),
+		ts.payment, nil, 15*time.Minute, time.Hour, time.Hour, "", "secret", time.Minute, "",
+		otel.Tracer("test-tracer"), zap.NewNop())
+	return ts
+}
+
+// confirmedBooking - оплаченное бронирование на три ночи через неделю
+func confirmedBooking() *models.Booking {
+	start := time.Now().AddDate(0, 0, 7).UTC().Truncate(24 * time.Hour).Add(14 * time.Hour)
+	return &models.Booking{
+		ID:            5,
+		UserID:        1,
+		RoomID:        1,
+		HotelID:       7,
+		Status:        models.StatusConfirmed,
+		Amount:        3000,
+		CountOfPeople: 2,
+		StartDate:     start,
+		EndDate:       start.AddDate(0, 0, 3).Add(-2 * time.Hour),
+	}
+}
+
+// expectQuote задаёт комнату и новую стоимость проживания в HotelSvc
+func (ts *testService) expectQuote(roomID, total int) {
+	ts.hotel.EXPECT().GetRoomDetails(gomock.Any(), gomock.Any()).
+		Return(&hotelpb.GetRoomDetailsResponse{Room: &hotelpb.Room{Id: int32(roomID), Number: 101, MaxAdults: 4}}, nil)
+	ts.hotel.EXPECT().GetPriceQuotes(gomock.Any(), gomock.Any()).
+		Return(&hotelpb.GetPriceQuotesResponse{Quotes: []*hotelpb.PriceQuote{{RoomId: int32(roomID), Subtotal: int32(total), Total: int32(total)}}}, nil)
+}
+
+// outboxRefunds - возвраты, сохранённые в outbox
+func outboxRefunds(t *testing.T, outbox []*models.OutboxMessage) []*models.RefundRequest {
+	var refunds []*models.RefundRequest
+	for _, message := range outbox {
+		if message.Recipient != models.RecipientPaymentSystem {
+			continue
+		}
+		var refund models.RefundRequest
+		require.NoError(t, json.Unmarshal(message.Payload, &refund))
+		refunds = append(refunds, &refund)
+	}
+	return refunds
+}
+
+func TestModifyBooking_PriceDecreaseRefundsThroughOutbox(t *testing.T) {
+	ts := newTestService(t)
+	booking := confirmedBooking()
+	ts.storage.EXPECT().GetBookingByID(gomock.Any(), booking.ID).Return(booking, nil)
+	ts.expectQuote(booking.RoomID, 2000)
+
+	// Возврат разницы сохраняется вместе с изменением, напрямую PaymentSystem не вызывается
+	ts.storage.EXPECT().UpdateBooking(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, updated *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+			assert.Equal(t, 2000, updated.Amount)
+			assert.Equal(t, models.StatusConfirmed, change.To)
+			refunds := outboxRefunds(t, outbox)
+			require.Len(t, refunds, 1)
+			assert.Equal(t, "5", refunds[0].OrderID)
+			assert.Equal(t, 1000, refunds[0].Amount)
+			assert.NotEmpty(t, refunds[0].RefundID)
+			return nil
+		})
+
+	endDate := booking.EndDate.AddDate(0, 0, -1)
+	updated, err := ts.service.ModifyBooking(context.Background(), booking.ID, &models.BookingUpdateRequest{EndDate: &endDate},
+		&models.User{UserID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2000, updated.Amount)
+}
+
+func TestModifyBooking_GuestCapacity(t *testing.T) {
+	two, four := 2, 4
+	tests := []struct {
+		name    string
+		request *models.BookingUpdateRequest
+		err     error
+	}{
+		{"children fit", &models.BookingUpdateRequest{Children: &two}, nil},
+		{"adults exceed max adults", &models.BookingUpdateRequest{Adults: &four}, myerror.ErrRoomCapacityExceeded},
+		{"count mismatch", &models.BookingUpdateRequest{CountOfPeople: &two, Children: &two}, myerror.ErrInvalidBookingData},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestService(t)
+			booking := confirmedBooking()
+			ts.storage.EXPECT().GetBookingByID(gomock.Any(), booking.ID).Return(booking, nil)
+			if tt.err != myerror.ErrInvalidBookingData {
+				ts.hotel.EXPECT().GetRoomDetails(gomock.Any(), gomock.Any()).
+					Return(&hotelpb.GetRoomDetailsResponse{Room: &hotelpb.Room{Id: 1, Number: 101, MaxAdults: 2, MaxChildren: 2}}, nil)
+			}
+			if tt.err == nil {
+				ts.hotel.EXPECT().GetPriceQuotes(gomock.Any(), gomock.Any()).
+					Return(&hotelpb.GetPriceQuotesResponse{Quotes: []*hotelpb.PriceQuote{{RoomId: 1, Subtotal: 3000, Total: 3000}}}, nil)
+				ts.storage.EXPECT().UpdateBooking(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, updated *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+						assert.Equal(t, 4, updated.CountOfPeople)
+						assert.Equal(t, 2, updated.Children)
+						return nil
+					})
+			}
+
+			_, err := ts.service.ModifyBooking(context.Background(), booking.ID, tt.request, &models.User{UserID: 1})
+			if tt.err == nil {
+				require.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestPublishOutbox_Refund(t *testing.T) {
+	ts := newTestService(t)
+	refund, err := refundOutbox(models.ToRefundRequest("5", 1000))
+	require.NoError(t, err)
+	refund.ID = 11
+
+	ts.storage.EXPECT().GetPendingOutbox(gomock.Any(), 10).Return([]*models.OutboxMessage{refund}, nil)
+	ts.payment.EXPECT().CreateRefundRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req *models.RefundRequest) error {
+			assert.Equal(t, "5", req.OrderID)
+			assert.Equal(t, 1000, req.Amount)
+			assert.NotEmpty(t, req.RefundID)
+			return nil
+		})
+	ts.storage.EXPECT().MarkOutboxSent(gomock.Any(), int64(11)).Return(nil)
+
+	sent, err := ts.service.PublishOutbox(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+}
+
+func TestModifyBooking_SurchargeKeepsBookingConfirmed(t *testing.T) {
+	ts := newTestService(t)
+	booking := confirmedBooking()
+	ts.storage.EXPECT().GetBookingByID(gomock.Any(), booking.ID).Return(booking, nil)
+	ts.expectQuote(booking.RoomID, 4000)
+
+	// Бронирование не меняется, новые условия ждут доплаты в отдельной записи
+	ts.storage.EXPECT().CreateBookingModification(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, modification *models.BookingModification) error {
+			assert.Equal(t, booking.ID, modification.BookingID)
+			assert.Equal(t, models.ModificationPending, modification.Status)
+			assert.Equal(t, 4000, modification.Amount)
+			assert.Equal(t, 1000, modification.Surcharge)
+			modification.ID = 9
+			return nil
+		})
+	ts.payment.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req *models.PaymentRequest) error {
+			assert.Equal(t, "5", req.OrderID)
+			assert.Equal(t, 1000, req.Amount)
+			assert.Contains(t, req.WebHookURL, "booking_id=5&modification_id=9")
+			return nil
+		})
+
+	endDate := booking.EndDate.AddDate(0, 0, 1)
+	result, err := ts.service.ModifyBooking(context.Background(), booking.ID,
+		&models.BookingUpdateRequest{EndDate: &endDate, CardNumber: "4111"}, &models.User{UserID: 1})
+	require.NoError(t, err)
+	assert.Equal(t, models.StatusConfirmed, result.Status)
+	assert.Equal(t, 3000, result.Amount)
+	require.NotNil(t, result.PendingModification)
+	assert.Equal(t, endDate, result.PendingModification.EndDate)
+}
+
+func TestApplySurchargeStatus(t *testing.T) {
+	pendingModification := func() *models.BookingModification {
+		booking := confirmedBooking()
+		updated := *booking
+		updated.EndDate = booking.EndDate.AddDate(0, 0, 1)
+		updated.Amount = 4000
+		modification := models.NewBookingModification(&updated, 1000, time.Now().Add(time.Minute))
+		modification.ID = 9
+		return modification
+	}
+
+	tests := []struct {
+		name          string
+		paymentStatus string
+		setup         func(ts *testService, modification *models.BookingModification)
+	}{
+		{
+			name:          "paid surcharge applies new terms",
+			paymentStatus: models.PaymentSuccess,
+			setup: func(ts *testService, modification *models.BookingModification) {
+				ts.storage.EXPECT().GetBookingByID(gomock.Any(), 5).Return(confirmedBooking(), nil)
+				ts.storage.EXPECT().ApplyBookingModification(gomock.Any(), 9, gomock.Any(), gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, id int, updated *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+						assert.Equal(t, 4000, updated.Amount)
+						assert.Equal(t, modification.EndDate, updated.EndDate)
+						assert.Equal(t, models.StatusConfirmed, change.From)
+						assert.Equal(t, models.StatusConfirmed, change.To)
+						assert.Len(t, outbox, 2)
+						assert.Empty(t, outboxRefunds(t, outbox))
+						return nil
+					})
+			},
+		},
+		{
+			// Бронирование остаётся подтверждённым на прежних условиях, возвращать нечего
+			name:          "failed surcharge keeps booking without refund",
+			paymentStatus: models.PaymentFailed,
+			setup: func(ts *testService, modification *models.BookingModification) {
+				ts.storage.EXPECT().
+					ResolveBookingModification(gomock.Any(), 9, models.ModificationPending, models.ModificationPaymentFailed, gomock.Nil()).
+					Return(nil)
+			},
+		},
+		{
+			name:          "room taken while paying refunds surcharge only",
+			paymentStatus: models.PaymentSuccess,
+			setup: func(ts *testService, modification *models.BookingModification) {
+				ts.storage.EXPECT().GetBookingByID(gomock.Any(), 5).Return(confirmedBooking(), nil)
+				ts.storage.EXPECT().ApplyBookingModification(gomock.Any(), 9, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(myerror.ErrBookingAlreadyExists)
+				ts.expectSurchargeRefund(t, models.ModificationPending)
+			},
+		},
+		{
+			name:          "surcharge paid after hold refunds surcharge only",
+			paymentStatus: models.PaymentSuccess,
+			setup: func(ts *testService, modification *models.BookingModification) {
+				modification.HoldExpiresAt = time.Now().Add(-time.Minute)
+				ts.expectSurchargeRefund(t, models.ModificationPending)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestService(t)
+			modification := pendingModification()
+			tt.setup(ts, modification)
+			ts.storage.EXPECT().GetBookingModification(gomock.Any(), 9).Return(modification, nil)
+
+			assert.NoError(t, ts.service.applySurchargeStatus(context.Background(), tt.paymentStatus, 5, 9))
+		})
+	}
+}
+
+// expectSurchargeRefund ожидает закрытия изменения с возвратом одной доплаты через outbox
+func (ts *testService) expectSurchargeRefund(t *testing.T, from string) {
+	ts.storage.EXPECT().ResolveBookingModification(gomock.Any(), 9, from, models.ModificationRefunded, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, id int, from, to string, outbox []*models.OutboxMessage) error {
+			refunds := outboxRefunds(t, outbox)
+			require.Len(t, refunds, 1)
+			assert.Equal(t, &models.RefundRequest{OrderID: "5", Amount: 1000, RefundID: "modification-9"}, refunds[0])
+			return nil
+		})
+}
+
+func TestExpireStaleBookings_SurchargeHoldKeepsBookingConfirmed(t *testing.T) {
+	ts := newTestService(t)
+
+	// Просроченное изменение закрывается отдельно, оплаченное бронирование под истечение брони не попадает
+	ts.storage.EXPECT().ExpireBookingModifications(gomock.Any()).Return(1, nil)
+	ts.storage.EXPECT().ExpireBookings(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, change *models.StatusTransition, toOutbox func(*models.Booking) ([]*models.OutboxMessage, error)) ([]*models.Booking, error) {
+			assert.Equal(t, models.StatusPendingPayment, change.From)
+			assert.Equal(t, models.StatusExpired, change.To)
+			return nil, nil
+		})
+
+	expired, err := ts.service.ExpireStaleBookings(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, expired)
+}
+
+func TestApplySurchargeStatus_PaidAfterExpiryRefundsSurcharge(t *testing.T) {
+	ts := newTestService(t)
+	booking := confirmedBooking()
+	updated := *booking
+	updated.Amount = 4000
+	modification := models.NewBookingModification(&updated, 1000, time.Now().Add(-time.Minute))
+	modification.ID = 9
+	modification.Status = models.ModificationExpired
+
+	ts.storage.EXPECT().GetBookingModification(gomock.Any(), 9).Return(modification, nil)
+	ts.expectSurchargeRefund(t, models.ModificationExpired)
+
+	assert.NoError(t, ts.service.applySurchargeStatus(context.Background(), models.PaymentSuccess, 5, 9))
+}
+
+func TestPublishOutbox_DeadLetter(t *testing.T) {
+	ts := newTestService(t)
+	refund, err := refundOutbox(models.ToRefundRequest("5", 1000))
+	require.NoError(t, err)
+	refund.ID = 11
+	refund.Attempts = 49
+
+	// Последняя неудачная попытка откладывает сообщение в dead letter, ошибки у relay нет
+	ts.storage.EXPECT().GetPendingOutbox(gomock.Any(), 10).Return([]*models.OutboxMessage{refund}, nil)
+	ts.payment.EXPECT().CreateRefundRequest(gomock.Any(), gomock.Any()).Return(assert.AnError)
+	ts.storage.EXPECT().MarkOutboxFailed(gomock.Any(), int64(11), gomock.Any()).Return(true, nil)
+
+	sent, err := ts.service.PublishOutbox(context.Background(), 10)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+}
+
+func TestCancelBooking_RefundThroughOutbox(t *testing.T) {
+	ts := newTestService(t)
+	booking := confirmedBooking()
+	ts.storage.EXPECT().GetBookingByID(gomock.Any(), booking.ID).Return(booking, nil)
+
+	// Возврат уходит только вместе с сохранённой отменой, напрямую PaymentSystem не вызывается
+	ts.storage.EXPECT().CancelBooking(gomock.Any(), booking.ID, gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, bookingID int, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+			assert.Equal(t, models.StatusCancelled, change.To)
+			refunds := outboxRefunds(t, outbox)
+			require.Len(t, refunds, 1)
+			assert.Equal(t, &models.RefundRequest{OrderID: "5", Amount: 3000, RefundID: "cancellation-5"}, refunds[0])
+			return myerror.ErrInvalidBookingStatus
+		})
+
+	err := ts.service.CancelBooking(context.Background(), booking.ID, &models.User{UserID: 1})
+	assert.ErrorIs(t, err, myerror.ErrInvalidBookingStatus)
+}
+
+func TestCreateBooking_PaymentRequestFailedReleasesKey(t *testing.T) {
+	ts := newTestService(t)
+	start := time.Now().AddDate(0, 0, 7).UTC().Truncate(24 * time.Hour).Add(14 * time.Hour)
+	request := &models.BookingRequest{RoomID: 1, HotelID: 7, CardNumber: "4111", CountOfPeople: 2,
+		StartDate: start, EndDate: start.AddDate(0, 0, 3), IdempotencyKey: "key-1"}
+
+	ts.storage.EXPECT().GetBookingByIdempotencyKey(gomock.Any(), 1, "key-1", gomock.Any()).
+		Return(nil, myerror.ErrBookingNotFound)
+	ts.hotel.EXPECT().GetRoomDetails(gomock.Any(), gomock.Any()).
+		Return(&hotelpb.GetRoomDetailsResponse{Room: &hotelpb.Room{Id: 1, Number: 101, MaxAdults: 4},
+			RoomType: &hotelpb.RoomType{BasePrice: 1000}}, nil)
+	ts.hotel.EXPECT().GetPriceQuotes(gomock.Any(), gomock.Any()).
+		Return(&hotelpb.GetPriceQuotesResponse{Quotes: []*hotelpb.PriceQuote{{RoomId: 1, Subtotal: 3000, Total: 3000}}}, nil)
+	ts.storage.EXPECT().CreateBooking(gomock.Any(), gomock.Any(), gomock.Nil()).Return(5, nil)
+	ts.payment.EXPECT().CreatePaymentRequest(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+	// Бронирование без платежа снимается сразу, а ключ освобождается для повтора запроса
+	ts.storage.EXPECT().AbandonBooking(gomock.Any(), gomock.Any(), 5).
+		DoAndReturn(func(ctx context.Context, change *models.StatusTransition, bookingID int) error {
+			assert.Equal(t, models.StatusPendingPayment, change.From)
+			assert.Equal(t, models.StatusPaymentFailed, change.To)
+			assert.Equal(t, models.ReasonPaymentNotSent, change.Reason)
+			return nil
+		})
+
+	_, err := ts.service.CreateBooking(context.Background(), request, &models.User{UserID: 1})
+	assert.ErrorIs(t, err, assert.AnError)
+}
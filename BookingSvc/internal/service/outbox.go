@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/AuthSvc/pkj/authpb"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// PublishOutbox отправляет в Kafka накопившиеся события outbox, а возвраты - в PaymentSystem.
+// Доставка at-least-once: сообщение, упавшее после отправки, уйдёт повторно.
+// Сообщение, так и не доставленное за отведённое число попыток, остаётся в dead letter.
+func (b *BookingServiceImpl) PublishOutbox(ctx context.Context, batchSize int) (int, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.PublishOutbox")
+	defer span.End()
+
+	messages, err := b.storage.GetPendingOutbox(ctx, batchSize)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service PublishOutbox", zap.Error(err))
+		return 0, fmt.Errorf("error in service PublishOutbox: %w", err)
+	}
+
+	sent := 0
+	for _, message := range messages {
+		// Одно неотправленное сообщение не должно задерживать остальные
+		if err = b.publishOutboxMessage(ctx, message); err != nil {
+			span.RecordError(err)
+			b.log.Warn("failed to publish outbox message", zap.Int64("message id", message.ID),
+				zap.Int("attempts", message.Attempts+1), zap.Error(err))
+			dead, markErr := b.storage.MarkOutboxFailed(ctx, message.ID, err.Error())
+			if markErr != nil {
+				b.log.Error("error in service PublishOutbox", zap.Error(markErr))
+				return sent, fmt.Errorf("error in service PublishOutbox: %w", markErr)
+			}
+			if dead {
+				b.log.Error("outbox message moved to dead letter", zap.Int64("message id", message.ID),
+					zap.String("recipient", message.Recipient))
+			}
+			continue
+		}
+		if err = b.storage.MarkOutboxSent(ctx, message.ID); err != nil {
+			b.log.Error("error in service PublishOutbox", zap.Error(err))
+			return sent, fmt.Errorf("error in service PublishOutbox: %w", err)
+		}
+		sent++
+	}
+
+	span.SetAttributes(attribute.Int("outbox.sent", sent))
+	return sent, nil
+}
+
+func (b *BookingServiceImpl) publishOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	switch message.Recipient {
+	case models.RecipientUser:
+		if err := b.messageProducer.SendUserMessage(ctx, message.Payload); err != nil {
+			return fmt.Errorf("error SendMessage: %w", err)
+		}
+	case models.RecipientHotelier:
+		var hotelierMessage models.BookingMessage
+		if err := json.Unmarshal(message.Payload, &hotelierMessage); err != nil {
+			return fmt.Errorf("error in Unmarshal KafkaHotelierMessage: %w", err)
+		}
+		chatID, err := b.getHotelierChatID(ctx, hotelierMessage.HotelID)
+		if err != nil {
+			return err
+		}
+		hotelierMessage.ChatID = chatID
+		kafkaHotelierMessage, err := json.Marshal(&hotelierMessage)
+		if err != nil {
+			return fmt.Errorf("error in Marshal KafkaHotelierMessage: %w", err)
+		}
+		if err = b.messageProducer.SendHotelierMessage(ctx, kafkaHotelierMessage); err != nil {
+			return fmt.Errorf("error in SendMessage: %w", err)
+		}
+	case models.RecipientPaymentSystem:
+		var refundRequest models.RefundRequest
+		if err := json.Unmarshal(message.Payload, &refundRequest); err != nil {
+			return fmt.Errorf("error in Unmarshal RefundRequest: %w", err)
+		}
+		if err := b.paymentSystemClient.CreateRefundRequest(ctx, &refundRequest); err != nil {
+			return fmt.Errorf("error in refund request: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown outbox recipient %q", message.Recipient)
+	}
+	return nil
+}
+
+// getHotelierChatID находит чат владельца отеля через HotelSvc и AuthSvc
+func (b *BookingServiceImpl) getHotelierChatID(ctx context.Context, hotelID int) (string, error) {
+	hotelReq := &hotelpb.GetOwnerIdRequest{Id: int32(hotelID)}
+	hotelResponse, err := b.hotelSvcClient.GetOwnerIdByHotelId(ctx, hotelReq)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.NotFound {
+			b.log.Warn("error in service gRPC GetOwnerIdByHotelId:", zap.Error(myerror.ErrHotelNotFound))
+			return "", fmt.Errorf("error in service GetOwnerIdByHotelId: %w", myerror.ErrHotelNotFound)
+		}
+		b.log.Error("error in service gRPC GetOwnerIdByHotelId:", zap.Error(err))
+		return "", fmt.Errorf("error in service GetOwnerIdByHotelId: %w", err)
+	}
+	authReq := &authpb.GetHotelierRequest{OwnerID: hotelResponse.OwnerId}
+
+	authResponse, err := b.authSvcClient.GetHotelierInformation(ctx, authReq)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.NotFound {
+			b.log.Warn("error in service gRPC GetHotelierInformation:", zap.Error(err))
+			return "", fmt.Errorf("error in service GetHotelierInformation: %w", myerror.ErrHotelNotFound)
+		}
+		b.log.Error("error in service gRPC GetHotelierInformation:", zap.Error(err))
+		return "", fmt.Errorf("error in service GetHotelierInformation: %w", err)
+	}
+	return authResponse.ChatID, nil
+}
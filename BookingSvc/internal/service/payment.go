@@ -8,4 +8,6 @@ import (
 //go:generate mockgen -source=payment.go -destination=mocks/payment_mock.go -package=mocks
 type PaymentSystemClient interface {
 	CreatePaymentRequest(ctx context.Context, paymentRequest *models.PaymentRequest) error
+	CreateRefundRequest(ctx context.Context, refundRequest *models.RefundRequest) error
+	GetPaymentStatus(ctx context.Context, orderID string) (*models.PaymentStatus, error)
 }
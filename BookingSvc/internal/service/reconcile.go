@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"time"
+)
+
+// paymentDiscrepancies - бронирования, ожидающие оплату, по которым PaymentSystem уже знает итог платежа.
+// Каждое такое расхождение - потерянный или не обработанный вебхук.
+var paymentDiscrepancies = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "booking_payment_discrepancies_total",
+		Help: "Pending bookings whose payment was already finished in PaymentSystem",
+	},
+	[]string{"payment_status"},
+)
+
+// ReconcilePayments сверяет с PaymentSystem бронирования, ожидающие оплату дольше pendingFor,
+// и применяет итог платежа, если вебхук о нём не дошёл. Возвращает число исправленных заказов.
+func (b *BookingServiceImpl) ReconcilePayments(ctx context.Context, pendingFor time.Duration, limit int) (int, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ReconcilePayments")
+	defer span.End()
+
+	bookings, err := b.storage.GetStalePendingBookings(ctx, time.Now().Add(-pendingFor), limit)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service ReconcilePayments", zap.Error(err))
+		return 0, fmt.Errorf("error in service ReconcilePayments: %w", err)
+	}
+
+	// Бронирования группы оплачены одним заказом, сверяем его один раз
+	checked := make(map[string]struct{}, len(bookings))
+	reconciled := 0
+	for _, booking := range bookings {
+		orderID := booking.OrderID()
+		if _, ok := checked[orderID]; ok {
+			continue
+		}
+		checked[orderID] = struct{}{}
+
+		applied, err := b.reconcilePayment(ctx, booking)
+		if err != nil {
+			// Ошибка по одному заказу не мешает сверке остальных
+			span.RecordError(err)
+			b.log.Error("error in service ReconcilePayments", zap.String("order id", orderID), zap.Error(err))
+			continue
+		}
+		if applied {
+			reconciled++
+		}
+	}
+	span.SetAttributes(attribute.Int("payments.checked", len(checked)), attribute.Int("payments.reconciled", reconciled))
+	return reconciled, nil
+}
+
+// reconcilePayment применяет итог платежа по заказу бронирования, если он уже известен PaymentSystem
+func (b *BookingServiceImpl) reconcilePayment(ctx context.Context, booking *models.Booking) (bool, error) {
+	orderID := booking.OrderID()
+	payment, err := b.paymentSystemClient.GetPaymentStatus(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrPaymentNotFound) {
+			// Запрос на оплату не дошёл до PaymentSystem - бронирование снимет истечение срока ожидания
+			b.log.Warn("payment for pending booking not found", zap.String("order id", orderID))
+			return false, nil
+		}
+		return false, err
+	}
+	if _, ok := models.StatusFromPayment(payment.Status); !ok {
+		// Платёж ещё проводится или уже возвращён - ждём вебхук или истечение срока ожидания
+		return false, nil
+	}
+
+	paymentDiscrepancies.WithLabelValues(payment.Status).Inc()
+	b.log.Warn("payment result missed by booking, reconciling",
+		zap.String("order id", orderID), zap.String("payment status", payment.Status))
+	if err = b.applyPaymentStatus(ctx, payment.Status, booking.ID, booking.GroupID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	hotelpb "github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+)
+
+// GetHotelReport считает владельцу отеля загрузку, ADR, RevPAR и долю отмен за период.
+// Доступный фонд - комнаты отеля из HotelSvc без снятых с продажи ночей, бронирования читаются из хранилища потоком.
+func (b *BookingServiceImpl) GetHotelReport(ctx context.Context, req *models.ReportRequest, userID int) (*models.HotelReport, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.GetHotelReport")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: GetHotelReport"),
+		zap.Int("hotel id", req.HotelID),
+		zap.Int("user id", userID),
+		zap.Strings("group by", req.GroupBy),
+	).Info("Received request to get hotel report")
+
+	if err := req.Validate(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error in service GetHotelReport: %w: %v", myerror.ErrInvalidReportRequest, err)
+	}
+	if err := b.checkHotelOwner(ctx, req.HotelID, userID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error in service GetHotelReport: %w", err)
+	}
+
+	response, err := b.hotelSvcClient.GetRoomsByHotelId(ctx, &hotelpb.GetRoomsRequest{HotelId: int32(req.HotelID)})
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service gRPC GetRoomsByHotelId:", zap.Error(err))
+		return nil, fmt.Errorf("error in gRPC request GetRoomsByHotelID: %w", err)
+	}
+	rooms := make([]*models.ReportRoom, 0, len(response.Rooms))
+	for _, room := range response.Rooms {
+		rooms = append(rooms, &models.ReportRoom{RoomID: int(room.Id), RoomTypeID: int(room.RoomTypeId)})
+	}
+	builder := models.NewReportBuilder(req, rooms)
+
+	blocks, err := b.storage.GetRoomBlocksByHotelPeriod(ctx, req.HotelID, req.From, req.To)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service GetHotelReport:", zap.Error(err))
+		return nil, fmt.Errorf("error in service GetHotelReport: %w", err)
+	}
+	// Занятые на другой площадке ночи остаются в фонде: комната продана, хоть и не через нас
+	for _, block := range blocks {
+		if block.Source == models.BlockSourceMaintenance {
+			builder.RemoveFromInventory(block)
+		}
+	}
+
+	from, to := req.From, req.To
+	filter := &models.BookingFilter{
+		Statuses: append([]string{models.StatusCancelled}, models.ReportSoldStatuses...),
+		From:     &from,
+		To:       &to,
+		Sort:     models.SortStartAsc,
+	}
+	bookings := 0
+	err = b.storage.StreamBookingsByHotelID(ctx, req.HotelID, filter, func(booking *models.Booking) error {
+		bookings++
+		builder.Add(booking)
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service GetHotelReport:", zap.Error(err))
+		return nil, fmt.Errorf("error in service GetHotelReport: %w", err)
+	}
+
+	span.SetAttributes(attribute.Int("report.rooms", len(rooms)), attribute.Int("report.bookings", bookings))
+	span.AddEvent("get hotel report success")
+	return builder.Build(), nil
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	hotelpb "github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Сколько запросов цен в HotelSvc выполняется одновременно при поиске
+const searchQuoteConcurrency = 4
+
+// SearchHotels ищет в отелях города свободные на даты комнаты и возвращает отели с самым дешёвым
+// подходящим по цене типом комнаты, от дешёвых к дорогим
+func (b *BookingServiceImpl) SearchHotels(ctx context.Context, searchRequest *models.HotelSearchRequest) ([]*models.HotelSearchResult, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.SearchHotels")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: SearchHotels"),
+		zap.String("city", searchRequest.City),
+		zap.Int("guests", searchRequest.Guests),
+		zap.Int("children", searchRequest.Children),
+	).Info("Received request to search hotels")
+
+	guests := models.Guests{Adults: searchRequest.Guests - searchRequest.Children, Children: searchRequest.Children}
+	if strings.TrimSpace(searchRequest.City) == "" || guests.Adults <= 0 || guests.Children < 0 ||
+		!searchRequest.EndDate.After(searchRequest.StartDate) || !searchRequest.StartDate.After(time.Now()) ||
+		searchRequest.MinPrice < 0 || searchRequest.MaxPrice < 0 ||
+		(searchRequest.MaxPrice > 0 && searchRequest.MaxPrice < searchRequest.MinPrice) {
+		span.RecordError(myerror.ErrInvalidBookingData)
+		return nil, fmt.Errorf("in service SearchHotels: %w", myerror.ErrInvalidBookingData)
+	}
+
+	response, err := b.hotelSvcClient.SearchHotels(ctx, &hotelpb.SearchHotelsRequest{City: searchRequest.City})
+	if err != nil {
+		span.RecordError(err)
+		if st, ok := status.FromError(err); ok && st.Code() == codes.InvalidArgument {
+			return nil, fmt.Errorf("in service SearchHotels: %s: %w", st.Message(), myerror.ErrInvalidBookingData)
+		}
+		b.log.Error("error in service gRPC SearchHotels:", zap.Error(err))
+		return nil, fmt.Errorf("error in gRPC request SearchHotels: %w", err)
+	}
+	hotelIDs := make([]int, 0, len(response.Hotels))
+	for _, hotel := range response.Hotels {
+		hotelIDs = append(hotelIDs, int(hotel.Id))
+	}
+	if len(hotelIDs) == 0 {
+		return []*models.HotelSearchResult{}, nil
+	}
+
+	unavailable, err := b.storage.GetUnavailableRoomsByHotelIDs(ctx, hotelIDs, searchRequest.StartDate, searchRequest.EndDate)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service SearchHotels:", zap.Error(err))
+		return nil, fmt.Errorf("error in service SearchHotels: %w", err)
+	}
+
+	// Цены считаются по одной свободной комнате каждого типа: тариф задаётся типом комнаты
+	results := make([]*models.HotelSearchResult, len(response.Hotels))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(searchQuoteConcurrency)
+	for i, hotel := range response.Hotels {
+		roomTypes := availableRoomTypes(hotel.Rooms, unavailable, guests)
+		if len(roomTypes) == 0 {
+			continue
+		}
+		group.Go(func() error {
+			cheapest, err := b.cheapestRoomType(groupCtx, int(hotel.Id), roomTypes, searchRequest)
+			if err != nil {
+				return err
+			}
+			if cheapest != nil {
+				results[i] = &models.HotelSearchResult{
+					HotelID:      int(hotel.Id),
+					HotelName:    hotel.Name,
+					City:         hotel.City,
+					Address:      hotel.Address,
+					CheapestRoom: cheapest,
+				}
+			}
+			return nil
+		})
+	}
+	if err = group.Wait(); err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service SearchHotels:", zap.Error(err))
+		return nil, fmt.Errorf("error in service SearchHotels: %w", err)
+	}
+
+	found := make([]*models.HotelSearchResult, 0, len(results))
+	for _, result := range results {
+		if result != nil {
+			found = append(found, result)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].CheapestRoom.Price.Total != found[j].CheapestRoom.Price.Total {
+			return found[i].CheapestRoom.Price.Total < found[j].CheapestRoom.Price.Total
+		}
+		return found[i].HotelID < found[j].HotelID
+	})
+
+	span.SetAttributes(attribute.Int("search.hotels", len(hotelIDs)), attribute.Int("search.found", len(found)))
+	span.AddEvent("search hotels success")
+	return found, nil
+}
+
+// availableRoomTypes группирует свободные и вмещающие гостей комнаты отеля по типу,
+// первая свободная комната типа - для бронирования
+func availableRoomTypes(rooms []*hotelpb.Room, unavailable map[int]struct{}, guests models.Guests) []*models.AvailableRoomType {
+	byType := make(map[int]*models.AvailableRoomType)
+	roomTypes := make([]*models.AvailableRoomType, 0)
+	for _, room := range rooms {
+		if _, busy := unavailable[int(room.Id)]; busy {
+			continue
+		}
+		capacity := roomCapacity(room)
+		if !capacity.Fits(guests) {
+			continue
+		}
+		roomType, ok := byType[int(room.RoomTypeId)]
+		if !ok {
+			roomType = &models.AvailableRoomType{
+				RoomTypeID:   int(room.RoomTypeId),
+				RoomID:       int(room.Id),
+				RoomNumber:   int(room.Number),
+				Description:  room.Description,
+				RoomCapacity: capacity,
+			}
+			byType[roomType.RoomTypeID] = roomType
+			roomTypes = append(roomTypes, roomType)
+		}
+		roomType.AvailableRooms++
+	}
+	return roomTypes
+}
+
+// cheapestRoomType возвращает самый дешёвый тип комнаты в диапазоне цены запроса или nil.
+// Отель, комнаты которого пропали из HotelSvc во время поиска, пропускается.
+func (b *BookingServiceImpl) cheapestRoomType(ctx context.Context, hotelID int, roomTypes []*models.AvailableRoomType, searchRequest *models.HotelSearchRequest) (*models.AvailableRoomType, error) {
+	roomIDs := make([]int32, 0, len(roomTypes))
+	for _, roomType := range roomTypes {
+		roomIDs = append(roomIDs, int32(roomType.RoomID))
+	}
+	quotes, err := b.getPriceQuotes(ctx, hotelID, roomIDs, searchRequest.StartDate, searchRequest.EndDate, searchRequest.Guests)
+	if err != nil {
+		if errors.Is(err, myerror.ErrRoomNotFound) {
+			b.log.Warn("hotel rooms changed during search", zap.Int("hotel id", hotelID))
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var cheapest *models.AvailableRoomType
+	for _, roomType := range roomTypes {
+		roomType.Price = quotes[roomType.RoomID]
+		if roomType.Price == nil || !searchRequest.PriceInRange(roomType.Price.Total) {
+			continue
+		}
+		if cheapest == nil || roomType.Price.Total < cheapest.Price.Total {
+			cheapest = roomType
+		}
+	}
+	return cheapest, nil
+}
@@ -2,10 +2,11 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/Quizert/room-reservation-system/AuthSvc/pkj/authpb"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
 	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
@@ -15,16 +16,30 @@ import (
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
-	"log"
+	"strconv"
 	"time"
 )
 
+// Формат дат в уведомлениях
+const messageDateLayout = "2006-01-02 15:04"
+
+// Максимальное число комнат в групповом бронировании
+const maxGroupRooms = 10
+
 type BookingServiceImpl struct {
 	storage             Storage
 	messageProducer     MessageProducer
 	hotelSvcClient      HotelClient
 	authSvcClient       AuthSvcClient
 	paymentSystemClient PaymentSystemClient
+	icalFeedClient      ICalFeedClient
+	holdTTL             time.Duration // Сколько бронирование ждёт оплату
+	idempotencyTTL      time.Duration // Сколько хранится ключ идемпотентности
+	waitlistOfferTTL    time.Duration // Сколько действует ссылка на комнату из листа ожидания
+	waitlistClaimURL    string        // Начало ссылки, к которому добавляется токен предложения
+	webhookSecret       []byte        // Общий с PaymentSystem секрет подписи вебхуков
+	webhookTolerance    time.Duration // Допустимое расхождение времени подписи вебхука
+	icalFeedURL         string        // Начало ссылки на выгрузку .ics, к которому добавляется токен
 	tracer              trace.Tracer
 	log                 *zap.Logger
 }
@@ -35,6 +50,14 @@ func NewBookingServiceImpl(
 	hotelClient HotelClient,
 	authClient AuthSvcClient,
 	paymentClient PaymentSystemClient,
+	icalClient ICalFeedClient,
+	holdTTL time.Duration,
+	idempotencyTTL time.Duration,
+	waitlistOfferTTL time.Duration,
+	waitlistClaimURL string,
+	webhookSecret string,
+	webhookTolerance time.Duration,
+	icalFeedURL string,
 	tracer trace.Tracer,
 	logger *zap.Logger,
 ) *BookingServiceImpl {
@@ -44,15 +67,24 @@ func NewBookingServiceImpl(
 		hotelSvcClient:      hotelClient,
 		authSvcClient:       authClient,
 		paymentSystemClient: paymentClient,
+		icalFeedClient:      icalClient,
+		holdTTL:             holdTTL,
+		idempotencyTTL:      idempotencyTTL,
+		waitlistOfferTTL:    waitlistOfferTTL,
+		waitlistClaimURL:    waitlistClaimURL,
+		webhookSecret:       []byte(webhookSecret),
+		webhookTolerance:    webhookTolerance,
+		icalFeedURL:         icalFeedURL,
 		log:                 logger,
 		tracer:              tracer,
 	}
 }
 
-func (b *BookingServiceImpl) CreateBooking(ctx context.Context, bookingRequest *models.BookingRequest, user *models.User) error {
+// CreateBooking создаёт бронирование и отправляет запрос на оплату.
+// Повторный запрос с тем же ключом идемпотентности возвращает исходное бронирование без повторной оплаты.
+func (b *BookingServiceImpl) CreateBooking(ctx context.Context, bookingRequest *models.BookingRequest, user *models.User) (*models.Booking, error) {
 	ctx, span := b.tracer.Start(ctx, "BookingService.CreateBooking")
 	defer span.End()
-	bookingRequest.Amount = bookingRequest.CountOfPeople * bookingRequest.RoomBasePrice
 
 	b.log.With(
 		zap.String("Layer", "service: CreateBooking"),
@@ -66,11 +98,78 @@ func (b *BookingServiceImpl) CreateBooking(ctx context.Context, bookingRequest *
 		zap.Int("user id", user.UserID),
 		zap.String("username", user.Username),
 		zap.String("chat id", user.ChatID),
-		zap.Int("amount", bookingRequest.Amount)).Info("Received request to create booking")
+		zap.String("idempotency key", bookingRequest.IdempotencyKey),
+		zap.String("promo code", bookingRequest.PromoCode),
+		zap.Int("client base price", bookingRequest.RoomBasePrice)).Info("Received request to create booking")
+
+	// Состав гостей приводится к одному виду до сверки с повторным запросом
+	if !bookingRequest.NormalizeGuests() {
+		span.RecordError(myerror.ErrInvalidBookingData)
+		return nil, fmt.Errorf("in service Create Booking: %w", myerror.ErrInvalidBookingData)
+	}
+	if bookingRequest.IdempotencyKey != "" {
+		existing, err := b.findIdempotentBooking(ctx, bookingRequest, user)
+		if err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("in service Create Booking: %w", err)
+		}
+		if existing != nil {
+			b.log.Info("in service Create Booking replayed by idempotency key", zap.Int("booking id", existing.ID))
+			span.AddEvent("booking_replayed")
+			return existing, nil
+		}
+	}
+
+	if !bookingRequest.EndDate.After(bookingRequest.StartDate) {
+		span.RecordError(myerror.ErrInvalidBookingData)
+		return nil, fmt.Errorf("in service Create Booking: %w", myerror.ErrInvalidBookingData)
+	}
+	// Стоимость и данные комнаты берутся только из HotelSvc
+	details, err := b.getRoomDetails(ctx, bookingRequest.HotelID, bookingRequest.RoomID)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Warn("in service Create Booking", zap.Error(err))
+		return nil, fmt.Errorf("in service Create Booking: %w", err)
+	}
+	if err = applyRoomDetails(bookingRequest, details); err != nil {
+		span.RecordError(err)
+		b.log.Warn("in service Create Booking", zap.Error(err))
+		return nil, fmt.Errorf("in service Create Booking: %w", err)
+	}
+	price, err := b.getPriceQuote(ctx, bookingRequest.HotelID, bookingRequest.RoomID,
+		bookingRequest.StartDate, bookingRequest.EndDate, bookingRequest.CountOfPeople)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Warn("in service Create Booking", zap.Error(err))
+		return nil, fmt.Errorf("in service Create Booking: %w", err)
+	}
+	var promo *models.PromoCode
+	if bookingRequest.PromoCode != "" {
+		promo, err = b.getPromoCode(ctx, bookingRequest.HotelID, bookingRequest.PromoCode)
+		if err != nil {
+			span.RecordError(err)
+			b.log.Warn("in service Create Booking", zap.Error(err))
+			return nil, fmt.Errorf("in service Create Booking: %w", err)
+		}
+		if !promo.Applicable(time.Now(), bookingRequest.StartDate, bookingRequest.EndDate) {
+			span.RecordError(myerror.ErrPromoCodeNotApplicable)
+			b.log.Warn("in service Create Booking: promo code is not applicable", zap.String("promo code", promo.Code))
+			return nil, fmt.Errorf("in service Create Booking: %w", myerror.ErrPromoCodeNotApplicable)
+		}
+		price.ApplyPromo(promo)
+		span.SetAttributes(attribute.Int("booking.promo_code_id", promo.ID))
+	}
+	bookingRequest.Amount = price.Total
 
-	booking := bookingRequest.ToBookingInfo(user.UserID)
+	booking := bookingRequest.ToBooking(user)
+	booking.Price = price
+	if promo != nil {
+		booking.PromoCodeID = promo.ID
+	}
+	holdExpiresAt := time.Now().Add(b.holdTTL)
+	booking.HoldExpiresAt = &holdExpiresAt
 
-	bookingID, err := b.storage.CreateBooking(ctx, booking)
+	bookingID, err := b.storage.CreateBooking(ctx, booking, promo)
 	span.SetAttributes(
 		attribute.String("booking.booking_id", fmt.Sprintf("%d", bookingID)),
 	)
@@ -78,50 +177,279 @@ func (b *BookingServiceImpl) CreateBooking(ctx context.Context, bookingRequest *
 		span.RecordError(err)
 		if errors.Is(err, myerror.ErrBookingAlreadyExists) {
 			b.log.Warn("in service Create Booking", zap.Error(err))
-			return fmt.Errorf("in service Create Booking: %w", myerror.ErrBookingAlreadyExists)
+			return nil, fmt.Errorf("in service Create Booking: %w", myerror.ErrBookingAlreadyExists)
+		}
+		if errors.Is(err, myerror.ErrPromoCodeExhausted) || errors.Is(err, myerror.ErrPromoCodeNotApplicable) {
+			b.log.Warn("in service Create Booking", zap.Error(err))
+			return nil, fmt.Errorf("in service Create Booking: %w", err)
+		}
+		if errors.Is(err, myerror.ErrIdempotencyKeyExists) {
+			// Параллельный повтор успел создать бронирование первым
+			existing, findErr := b.findIdempotentBooking(ctx, bookingRequest, user)
+			if findErr != nil {
+				return nil, fmt.Errorf("in service Create Booking: %w", findErr)
+			}
+			if existing != nil {
+				span.AddEvent("booking_replayed")
+				return existing, nil
+			}
+			b.log.Warn("in service Create Booking", zap.Error(err))
+			return nil, fmt.Errorf("in service Create Booking: %w", myerror.ErrIdempotencyKeyExists)
 		}
 		b.log.Error("in service Create Booking", zap.Error(err))
-		return fmt.Errorf("in service Create Booking: %w", err)
+		return nil, fmt.Errorf("in service Create Booking: %w", err)
 	}
+	booking.ID = bookingID
 
-	startDateStr := bookingRequest.StartDate.Format("2006-02-01 15")
-	endDateStr := bookingRequest.EndDate.Format("2006-02-01 15")
+	startDateStr := bookingRequest.StartDate.Format(messageDateLayout)
+	endDateStr := bookingRequest.EndDate.Format(messageDateLayout)
 	bookingMessage := bookingRequest.ToBookingMessage(bookingID, user.Username, user.ChatID, startDateStr, endDateStr)
 	paymentRequest := models.ToPaymentRequest(bookingMessage, bookingRequest.CardNumber, bookingRequest.Amount)
 
 	err = b.paymentSystemClient.CreatePaymentRequest(ctx, paymentRequest)
 	if err != nil {
 		span.RecordError(err)
-
-		return fmt.Errorf("error in payment request: %w", err)
+		b.log.Error("in service Create Booking", zap.Error(err))
+		b.abandonBooking(ctx, bookingID)
+		return nil, fmt.Errorf("error in payment request: %w", err)
 	}
 
 	b.log.Info("in service Create Booking end successfully")
 	span.AddEvent("booking_created")
+	return booking, nil
+}
+
+// CreateGroupBooking бронирует несколько комнат отеля на одни даты: все комнаты или ни одной.
+// За группу создаётся один платёж, гость и владелец отеля получают одно уведомление со всеми комнатами.
+func (b *BookingServiceImpl) CreateGroupBooking(ctx context.Context, groupRequest *models.GroupBookingRequest, user *models.User) (*models.GroupBooking, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.CreateGroupBooking")
+	defer span.End()
+
+	b.log.With(
+		zap.String("Layer", "service: CreateGroupBooking"),
+		zap.Int("hotel id", groupRequest.HotelID),
+		zap.Int("rooms", len(groupRequest.Rooms)),
+		zap.Time("start date", groupRequest.StartDate),
+		zap.Time("end date", groupRequest.EndDate),
+		zap.Int("user id", user.UserID),
+	).Info("Received request to create group booking")
+
+	if err := validateGroupRequest(groupRequest); err != nil {
+		span.RecordError(err)
+		b.log.Warn("in service CreateGroupBooking", zap.Error(err))
+		return nil, fmt.Errorf("in service CreateGroupBooking: %w", err)
+	}
+
+	holdExpiresAt := time.Now().Add(b.holdTTL)
+	group := &models.GroupBooking{
+		UserID:        user.UserID,
+		HotelID:       groupRequest.HotelID,
+		StartDate:     groupRequest.StartDate,
+		EndDate:       groupRequest.EndDate,
+		HoldExpiresAt: &holdExpiresAt,
+		Bookings:      make([]*models.Booking, 0, len(groupRequest.Rooms)),
+	}
+	for _, room := range groupRequest.Rooms {
+		details, err := b.getRoomDetails(ctx, groupRequest.HotelID, room.RoomID)
+		if err != nil {
+			span.RecordError(err)
+			b.log.Warn("in service CreateGroupBooking", zap.Int("room id", room.RoomID), zap.Error(err))
+			return nil, fmt.Errorf("in service CreateGroupBooking: room %d: %w", room.RoomID, err)
+		}
+		if err = checkRoomCapacity(details.Room, room.Guests()); err != nil {
+			span.RecordError(err)
+			b.log.Warn("in service CreateGroupBooking", zap.Error(err))
+			return nil, fmt.Errorf("in service CreateGroupBooking: %w", err)
+		}
+		price, err := b.getPriceQuote(ctx, groupRequest.HotelID, room.RoomID, groupRequest.StartDate, groupRequest.EndDate, room.CountOfPeople)
+		if err != nil {
+			span.RecordError(err)
+			b.log.Warn("in service CreateGroupBooking", zap.Int("room id", room.RoomID), zap.Error(err))
+			return nil, fmt.Errorf("in service CreateGroupBooking: room %d: %w", room.RoomID, err)
+		}
+
+		booking := groupRequest.ToBooking(room, user)
+		booking.HotelName = details.HotelName
+		booking.RoomDescription = details.Room.Description
+		booking.RoomNumber = int(details.Room.Number)
+		booking.Amount = price.Total
+		booking.Price = price
+		booking.HoldExpiresAt = &holdExpiresAt
+		group.Amount += booking.Amount
+		group.Bookings = append(group.Bookings, booking)
+	}
+
+	err := b.storage.CreateGroupBooking(ctx, group)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrBookingAlreadyExists) {
+			b.log.Warn("in service CreateGroupBooking", zap.Error(err))
+			return nil, fmt.Errorf("in service CreateGroupBooking: %w", myerror.ErrBookingAlreadyExists)
+		}
+		b.log.Error("in service CreateGroupBooking", zap.Error(err))
+		return nil, fmt.Errorf("in service CreateGroupBooking: %w", err)
+	}
+	span.SetAttributes(attribute.Int("booking.group_id", group.ID))
+
+	startDateStr := groupRequest.StartDate.Format(messageDateLayout)
+	endDateStr := groupRequest.EndDate.Format(messageDateLayout)
+	bookingMessage := group.ToBookingMessage(models.EventBookingCreated, user.Username, user.ChatID, startDateStr, endDateStr)
+	paymentRequest := models.ToGroupPaymentRequest(bookingMessage, groupRequest.CardNumber, group.Amount)
+
+	err = b.paymentSystemClient.CreatePaymentRequest(ctx, paymentRequest)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error in payment request: %w", err)
+	}
+
+	b.log.Info("in service CreateGroupBooking end successfully", zap.Int("group id", group.ID))
+	span.AddEvent("group_booking_created")
+	return group, nil
+}
+
+func validateGroupRequest(groupRequest *models.GroupBookingRequest) error {
+	if len(groupRequest.Rooms) == 0 || len(groupRequest.Rooms) > maxGroupRooms {
+		return fmt.Errorf("group must have from 1 to %d rooms: %w", maxGroupRooms, myerror.ErrInvalidBookingData)
+	}
+	if !groupRequest.EndDate.After(groupRequest.StartDate) {
+		return myerror.ErrInvalidBookingData
+	}
+	rooms := make(map[int]struct{}, len(groupRequest.Rooms))
+	for i := range groupRequest.Rooms {
+		room := &groupRequest.Rooms[i]
+		if !room.NormalizeGuests() {
+			return myerror.ErrInvalidBookingData
+		}
+		if _, ok := rooms[room.RoomID]; ok {
+			return fmt.Errorf("room %d listed twice: %w", room.RoomID, myerror.ErrInvalidBookingData)
+		}
+		rooms[room.RoomID] = struct{}{}
+	}
 	return nil
 }
 
-func (b *BookingServiceImpl) GetBookingsByUserID(ctx context.Context, userID int) ([]*models.BookingInfo, error) {
-	ctx, span := b.tracer.Start(ctx, "BookingService.CreateBooking")
+// updateGroupStatus применяет результат общего платежа ко всем бронированиям группы
+func (b *BookingServiceImpl) updateGroupStatus(ctx context.Context, change *models.StatusTransition, groupID int) error {
+	bookings, err := b.storage.GetBookingsByGroupID(ctx, groupID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrBookingNotFound) {
+			b.log.Warn("error in service updateGroupStatus", zap.Int("group id", groupID), zap.Error(err))
+			return fmt.Errorf("error in service updateGroupStatus: %w", myerror.ErrBookingNotFound)
+		}
+		b.log.Error("error in service updateGroupStatus", zap.Error(err))
+		return fmt.Errorf("error in service updateGroupStatus: %w", err)
+	}
+
+	var outbox []*models.OutboxMessage
+	if change.To == models.StatusConfirmed {
+		group := models.NewGroupBooking(groupID, bookings)
+		startDateStr := group.StartDate.Format(messageDateLayout)
+		endDateStr := group.EndDate.Format(messageDateLayout)
+		bookingMessage := group.ToBookingMessage(models.EventBookingCreated, bookings[0].Username, bookings[0].ChatID, startDateStr, endDateStr)
+		outbox, err = bookingOutbox(bookingMessage)
+		if err != nil {
+			b.log.Error("error in service updateGroupStatus", zap.Error(err))
+			return fmt.Errorf("error in service updateGroupStatus: %w", err)
+		}
+	}
+
+	err = b.storage.UpdateGroupStatus(ctx, change, groupID, outbox)
+	if err != nil {
+		if errors.Is(err, myerror.ErrInvalidBookingStatus) && change.To == models.StatusConfirmed {
+			return b.refundLateGroupPayment(ctx, groupID)
+		}
+		b.log.Error("error in service updateGroupStatus", zap.Error(err))
+		return fmt.Errorf("error in service updateGroupStatus: %w", err)
+	}
+	b.log.Info("in service update group status end successfully",
+		zap.Int("group id", groupID), zap.String("status", change.To))
+	return nil
+}
+
+// refundLateGroupPayment возвращает общий платёж, пришедший после того, как все бронирования группы сняты
+func (b *BookingServiceImpl) refundLateGroupPayment(ctx context.Context, groupID int) error {
+	bookings, err := b.storage.GetBookingsByGroupID(ctx, groupID)
+	if err != nil {
+		b.log.Error("error in service refundLateGroupPayment", zap.Error(err))
+		return fmt.Errorf("error in service refundLateGroupPayment: %w", err)
+	}
+	for _, booking := range bookings {
+		if booking.Status != models.StatusCancelled && booking.Status != models.StatusExpired {
+			return fmt.Errorf("error in service refundLateGroupPayment: %w", myerror.ErrInvalidBookingStatus)
+		}
+	}
+	b.log.Warn("payment received for inactive group booking, refunding", zap.Int("group id", groupID))
+	err = b.paymentSystemClient.CreateRefundRequest(ctx, models.ToRefundRequest(models.GroupOrderID(groupID), 0))
+	if err != nil {
+		b.log.Error("error in service refundLateGroupPayment", zap.Error(err))
+		return fmt.Errorf("error in refund request: %w", err)
+	}
+	return nil
+}
+
+// abandonBooking снимает бронирование, для которого не удалось создать платёж, и освобождает его ключ идемпотентности:
+// иначе повтор запроса вернул бы бронирование, которое никто не оплатит. Если платёж всё же пройдёт,
+// поздняя оплата будет возвращена. При ошибке бронирование снимет истечение срока ожидания.
+func (b *BookingServiceImpl) abandonBooking(ctx context.Context, bookingID int) {
+	change, err := newTransition(models.StatusPendingPayment, models.StatusPaymentFailed, models.ActorSystem, 0, models.ReasonPaymentNotSent)
+	if err == nil {
+		err = b.storage.AbandonBooking(ctx, change, bookingID)
+	}
+	if err != nil {
+		b.log.Error("error in service abandonBooking", zap.Int("booking id", bookingID), zap.Error(err))
+	}
+}
+
+// findIdempotentBooking возвращает бронирование, уже созданное с ключом из запроса, или nil
+func (b *BookingServiceImpl) findIdempotentBooking(ctx context.Context, bookingRequest *models.BookingRequest, user *models.User) (*models.Booking, error) {
+	since := time.Now().Add(-b.idempotencyTTL)
+	booking, err := b.storage.GetBookingByIdempotencyKey(ctx, user.UserID, bookingRequest.IdempotencyKey, since)
+	if err != nil {
+		if errors.Is(err, myerror.ErrBookingNotFound) {
+			return nil, nil
+		}
+		b.log.Error("error in service findIdempotentBooking", zap.Error(err))
+		return nil, err
+	}
+	if !booking.MatchesRequest(bookingRequest) {
+		b.log.Warn("idempotency key reused with different request", zap.Int("booking id", booking.ID))
+		return nil, myerror.ErrIdempotencyKeyReused
+	}
+	return booking, nil
+}
+
+func (b *BookingServiceImpl) GetBookingsByUserID(ctx context.Context, userID int, filter *models.BookingFilter) (*models.BookingPage, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.GetBookingsByUserID")
 	defer span.End()
 	b.log.With(
 		zap.String("Layer", "service: GetBookingsByUserID"),
 		zap.Int("user id", userID),
 	).Info("Received request to get booking by user id")
 
-	bookings, err := b.storage.GetBookingsByUserID(ctx, userID)
+	if err := filter.Normalize(); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error in service GetBookingsByUserID: %w: %v", myerror.ErrInvalidBookingFilter, err)
+	}
+	bookings, err := b.storage.GetBookingsByUserID(ctx, userID, filter)
 	if err != nil {
 		span.RecordError(err)
-		b.log.Error("error in service GetBookingsByHotelID:", zap.Error(err))
-		return nil, fmt.Errorf("error in service GetBookingsByHotelID: %w", err)
+		b.log.Error("error in service GetBookingsByUserID:", zap.Error(err))
+		return nil, fmt.Errorf("error in service GetBookingsByUserID: %w", err)
+	}
+
+	page, err := b.bookingPage(ctx, bookings, filter)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service GetBookingsByUserID:", zap.Error(err))
+		return nil, fmt.Errorf("error in service GetBookingsByUserID: %w", err)
 	}
 
 	b.log.Info("in service get bookings by user id end successfully")
 	span.AddEvent("get booking success")
-	return bookings, nil
+	return page, nil
 }
 
-func (b *BookingServiceImpl) GetBookingsByHotelID(ctx context.Context, hotelID, userID int) ([]*models.BookingInfo, error) {
+func (b *BookingServiceImpl) GetBookingsByHotelID(ctx context.Context, hotelID, userID int, filter *models.BookingFilter) (*models.BookingPage, error) {
 	ctx, span := b.tracer.Start(ctx, "BookingService.GetBookingsByHotelID")
 	defer span.End()
 	b.log.With(
@@ -129,26 +457,16 @@ func (b *BookingServiceImpl) GetBookingsByHotelID(ctx context.Context, hotelID,
 		zap.Int("user id", userID),
 		zap.Int("hotel id", hotelID)).Info("Received request to get bookings by owner")
 
-	//Проверим, что отель действительно принадлежит userID
-	req := &hotelpb.GetOwnerIdRequest{Id: int32(hotelID)}
-	response, err := b.hotelSvcClient.GetOwnerIdByHotelId(ctx, req)
-	if err != nil {
+	if err := filter.Normalize(); err != nil {
 		span.RecordError(err)
-		st, ok := status.FromError(err)
-		if ok && st.Code() == codes.NotFound {
-			b.log.Warn("error in service gRPC GetBookingsByHotelID:", zap.Error(myerror.ErrHotelNotFound))
-			return nil, fmt.Errorf("error in service GetOwnerIdByHotelId: %w", myerror.ErrHotelNotFound)
-		}
-		b.log.Error("error in service gRPC GetBookingsByHotelID:", zap.Error(err))
-		return nil, fmt.Errorf("error in service GetOwnerIdByHotelId: %w", err)
+		return nil, fmt.Errorf("error in service GetBookingsByHotelID: %w: %v", myerror.ErrInvalidBookingFilter, err)
 	}
-	if response.OwnerId != int32(userID) {
-		span.RecordError(myerror.ErrForbiddenAccess)
-		b.log.Warn("hotelier tries to check forbidden bookings from other hotel!")
-		return nil, fmt.Errorf("user not owned by hotel %w", myerror.ErrForbiddenAccess)
+	if err := b.checkHotelOwner(ctx, hotelID, userID); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error in service GetBookingsByHotelID: %w", err)
 	}
 
-	bookings, err := b.storage.GetBookingsByHotelID(ctx, hotelID)
+	bookings, err := b.storage.GetBookingsByHotelID(ctx, hotelID, filter)
 	if err != nil {
 		span.RecordError(err)
 
@@ -156,12 +474,106 @@ func (b *BookingServiceImpl) GetBookingsByHotelID(ctx context.Context, hotelID,
 		return nil, fmt.Errorf("error in service GetBookingsByHotelID: %w", err)
 	}
 
+	page, err := b.bookingPage(ctx, bookings, filter)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service GetBookingsByHotelID:", zap.Error(err))
+		return nil, fmt.Errorf("error in service GetBookingsByHotelID: %w", err)
+	}
+	if page.Blocks, err = b.hotelRoomBlocks(ctx, hotelID, filter); err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service GetBookingsByHotelID:", zap.Error(err))
+		return nil, fmt.Errorf("error in service GetBookingsByHotelID: %w", err)
+	}
+
 	b.log.Info("in service get bookings by hotel id end successfully")
 	span.AddEvent("get booking success")
-	return bookings, nil
+	return page, nil
+}
+
+// bookingPage собирает страницу с историей статусов бронирований:
+// хранилище возвращает на одну запись больше Limit, если есть следующая страница
+func (b *BookingServiceImpl) bookingPage(ctx context.Context, bookings []*models.Booking, filter *models.BookingFilter) (*models.BookingPage, error) {
+	page := &models.BookingPage{}
+	if len(bookings) > filter.Limit {
+		bookings = bookings[:filter.Limit]
+		page.NextCursor = models.NewBookingCursor(filter.Sort, bookings[len(bookings)-1]).Encode()
+	}
+	bookingIDs := make([]int, 0, len(bookings))
+	for _, booking := range bookings {
+		bookingIDs = append(bookingIDs, booking.ID)
+	}
+	history, err := b.storage.GetStatusHistory(ctx, bookingIDs)
+	if err != nil {
+		return nil, err
+	}
+	page.Bookings = models.DetailsList(bookings, history)
+	return page, nil
+}
+
+// GetBookingByID возвращает бронирование с историей статусов гостю, который его создал, или владельцу отеля
+func (b *BookingServiceImpl) GetBookingByID(ctx context.Context, bookingID, userID int) (*models.BookingDetails, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.GetBookingByID")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: GetBookingByID"),
+		zap.Int("booking id", bookingID),
+		zap.Int("user id", userID),
+	).Info("Received request to get booking")
+
+	booking, err := b.storage.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrBookingNotFound) {
+			b.log.Warn("in service GetBookingByID", zap.Error(err))
+			return nil, fmt.Errorf("in service GetBookingByID: %w", err)
+		}
+		b.log.Error("in service GetBookingByID", zap.Error(err))
+		return nil, fmt.Errorf("in service GetBookingByID: %w", err)
+	}
+	if booking.UserID != userID {
+		if err = b.checkHotelOwner(ctx, booking.HotelID, userID); err != nil {
+			span.RecordError(err)
+			if errors.Is(err, myerror.ErrHotelNotFound) {
+				return nil, fmt.Errorf("in service GetBookingByID: %w", myerror.ErrForbiddenAccess)
+			}
+			return nil, fmt.Errorf("in service GetBookingByID: %w", err)
+		}
+	}
+
+	history, err := b.storage.GetStatusHistory(ctx, []int{booking.ID})
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("in service GetBookingByID", zap.Error(err))
+		return nil, fmt.Errorf("in service GetBookingByID: %w", err)
+	}
+
+	span.AddEvent("get booking success")
+	return models.NewBookingDetails(booking, history[booking.ID]), nil
+}
+
+// checkHotelOwner проверяет в HotelSvc, что отель принадлежит userID
+func (b *BookingServiceImpl) checkHotelOwner(ctx context.Context, hotelID, userID int) error {
+	req := &hotelpb.GetOwnerIdRequest{Id: int32(hotelID)}
+	response, err := b.hotelSvcClient.GetOwnerIdByHotelId(ctx, req)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.NotFound {
+			b.log.Warn("error in service gRPC GetOwnerIdByHotelId:", zap.Error(myerror.ErrHotelNotFound))
+			return fmt.Errorf("error in service GetOwnerIdByHotelId: %w", myerror.ErrHotelNotFound)
+		}
+		b.log.Error("error in service gRPC GetOwnerIdByHotelId:", zap.Error(err))
+		return fmt.Errorf("error in service GetOwnerIdByHotelId: %w", err)
+	}
+	if response.OwnerId != int32(userID) {
+		b.log.Warn("hotelier tries to check forbidden bookings from other hotel!")
+		return fmt.Errorf("user not owned by hotel %w", myerror.ErrForbiddenAccess)
+	}
+	return nil
 }
 
-func (b *BookingServiceImpl) GetAvailableRooms(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*hotelpb.Room, error) {
+// GetAvailableRooms возвращает свободные на даты комнаты отеля, вмещающие гостей, со стоимостью проживания
+func (b *BookingServiceImpl) GetAvailableRooms(ctx context.Context, hotelID int, startDate, endDate time.Time, guests models.Guests) ([]*models.AvailableRoom, error) {
 	ctx, span := b.tracer.Start(ctx, "BookingService.GetAvailableRooms")
 	defer span.End()
 
@@ -171,90 +583,628 @@ func (b *BookingServiceImpl) GetAvailableRooms(ctx context.Context, hotelID int,
 		span.RecordError(err)
 		return nil, fmt.Errorf("error in gRPC request GetRoomsByHotelID: %v", err)
 	}
-	log.Println("AFIKJOAFOIJAFKJL:ALK:FJALKFAL:KJFKAJLFJKLAJFKLAFAKLFJKLA:FJKA\n", allRooms)
 	unavailableRoomsID, err := b.storage.GetUnavailableRoomsByHotelId(ctx, hotelID, startDate, endDate)
 	if err != nil {
 		span.RecordError(err)
 		return nil, fmt.Errorf("error in db GetUnavailableRoomsByHotelId: %v", err)
 	}
 
-	availableRooms := make([]*hotelpb.Room, 0)
+	availableRooms := make([]*models.AvailableRoom, 0)
+	roomIDs := make([]int32, 0)
 	for _, room := range allRooms.Rooms {
-		if _, ok := unavailableRoomsID[int(room.Id)]; !ok {
-			availableRooms = append(availableRooms, room)
+		if _, ok := unavailableRoomsID[int(room.Id)]; ok {
+			continue
+		}
+		capacity := roomCapacity(room)
+		if !capacity.Fits(guests) {
+			continue
 		}
+		availableRooms = append(availableRooms, &models.AvailableRoom{
+			ID:           int(room.Id),
+			HotelID:      int(room.HotelId),
+			RoomTypeID:   int(room.RoomTypeId),
+			Number:       int(room.Number),
+			Description:  room.Description,
+			BasePrice:    int(room.BasePrice),
+			RoomCapacity: capacity,
+		})
+		roomIDs = append(roomIDs, room.Id)
+	}
+	if len(availableRooms) == 0 {
+		return availableRooms, nil
+	}
+
+	quotes, err := b.getPriceQuotes(ctx, hotelID, roomIDs, startDate, endDate, guests.Total())
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("error in service GetAvailableRooms: %w", err)
+	}
+	for _, room := range availableRooms {
+		room.Price = quotes[room.ID]
 	}
 
 	span.AddEvent("get available rooms success")
 	return availableRooms, nil
 }
 
-func (b *BookingServiceImpl) UpdateBookingStatus(ctx context.Context, BookingStatus string, bookingMessage *models.BookingMessage) error {
-	ctx, span := b.tracer.Start(ctx, "BookingService.GetAvailableRooms")
+// UpdateBookingStatus применяет результат оплаты из вебхука PaymentSystem.
+// Вебхук принимается только с верной подписью и один раз, заказ берётся из адреса вебхука,
+// а уведомления собираются из сохранённого бронирования.
+func (b *BookingServiceImpl) UpdateBookingStatus(ctx context.Context, webhook *models.PaymentWebhook) error {
+	ctx, span := b.tracer.Start(ctx, "BookingService.UpdateBookingStatus")
 	defer span.End()
+	span.SetAttributes(attribute.Int("booking.booking_id", webhook.BookingID), attribute.Int("booking.group_id", webhook.GroupID))
 	b.log.With(
 		zap.String("Layer", "service: UpdateBookingStatus"),
-		zap.String("status", BookingStatus),
-		zap.Any("message", bookingMessage),
+		zap.String("payment status", webhook.Status),
+		zap.Int("booking id", webhook.BookingID),
+		zap.Int("group id", webhook.GroupID),
 	).Info("Received request to update booking status")
 
-	err := b.storage.UpdateBookingStatus(ctx, BookingStatus, bookingMessage.BookingID)
+	if err := b.acceptPaymentWebhook(ctx, webhook); err != nil {
+		span.RecordError(err)
+		b.log.Warn("error in service UpdateBookingStatus", zap.Error(err))
+		return fmt.Errorf("error in service UpdateBookingStatus: %w", err)
+	}
+	var err error
+	if webhook.ModificationID != 0 {
+		err = b.applySurchargeStatus(ctx, webhook.Status, webhook.BookingID, webhook.ModificationID)
+	} else {
+		err = b.applyPaymentStatus(ctx, webhook.Status, webhook.BookingID, webhook.GroupID)
+	}
 	if err != nil {
-		b.log.Error("error in service UpdateBookingStatus: %w", zap.Error(err))
+		span.RecordError(err)
+		// Статус не сохранён, поэтому повтор вебхука от PaymentSystem должен быть принят
+		b.releasePaymentWebhook(ctx, webhook)
 		return fmt.Errorf("error in service UpdateBookingStatus: %w", err)
 	}
+	return nil
+}
+
+// applyPaymentStatus переводит ожидающее оплаты бронирование или группу по итогу платежа.
+// Итог приходит вебхуком или из сверки с PaymentSystem.
+func (b *BookingServiceImpl) applyPaymentStatus(ctx context.Context, paymentStatus string, bookingID, groupID int) error {
+	change, err := paymentTransition(paymentStatus)
+	if err != nil {
+		b.log.Warn("error in service applyPaymentStatus", zap.Error(err))
+		return fmt.Errorf("error in service applyPaymentStatus: %w", err)
+	}
+	if groupID != 0 {
+		return b.updateGroupStatus(ctx, change, groupID)
+	}
 
-	switch BookingStatus {
-	case "success":
-		kafkaUserMessage, err := json.Marshal(bookingMessage)
+	booking, err := b.storage.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrBookingNotFound) {
+			b.log.Warn("error in service applyPaymentStatus", zap.Error(err))
+			return fmt.Errorf("error in service applyPaymentStatus: %w", myerror.ErrBookingNotFound)
+		}
+		b.log.Error("error in service applyPaymentStatus", zap.Error(err))
+		return fmt.Errorf("error in service applyPaymentStatus: %w", err)
+	}
+	// Уведомления сохраняются вместе со статусом и уходят в Kafka через outbox
+	var outbox []*models.OutboxMessage
+	if change.To == models.StatusConfirmed {
+		startDateStr := booking.StartDate.Format(messageDateLayout)
+		endDateStr := booking.EndDate.Format(messageDateLayout)
+		bookingMessage := booking.ToBookingMessage(models.EventBookingCreated, booking.Username, booking.ChatID, startDateStr, endDateStr)
+		outbox, err = bookingOutbox(bookingMessage)
 		if err != nil {
-			b.log.Error("error in service UpdateBookingStatus", zap.Error(err))
-			return fmt.Errorf("error in Marshal KafkaUserMessage: %w", err)
+			b.log.Error("error in service applyPaymentStatus", zap.Error(err))
+			return fmt.Errorf("error in service applyPaymentStatus: %w", err)
+		}
+	}
+
+	err = b.storage.UpdateBookingStatus(ctx, change, booking.ID, outbox)
+	if err != nil {
+		// Оплата пришла после отмены бронирования - возвращаем всё оплаченное
+		if errors.Is(err, myerror.ErrInvalidBookingStatus) && change.To == models.StatusConfirmed {
+			return b.refundLatePayment(ctx, booking.ID)
 		}
-		err = b.messageProducer.SendUserMessage(ctx, kafkaUserMessage)
+		b.log.Error("error in service applyPaymentStatus: %w", zap.Error(err))
+		return fmt.Errorf("error in service applyPaymentStatus: %w", err)
+	}
+
+	if change.To == models.StatusPaymentFailed {
+		b.log.Warn("The payment is failing")
+	}
+	b.log.Info("in service apply payment status end successfully", zap.String("status", change.To))
+	return nil
+}
+
+func (b *BookingServiceImpl) CancelBooking(ctx context.Context, bookingID int, user *models.User) error {
+	ctx, span := b.tracer.Start(ctx, "BookingService.CancelBooking")
+	defer span.End()
+	span.SetAttributes(attribute.Int("booking.booking_id", bookingID))
+	b.log.With(
+		zap.String("Layer", "service: CancelBooking"),
+		zap.Int("booking id", bookingID),
+		zap.Int("user id", user.UserID),
+	).Info("Received request to cancel booking")
+
+	booking, err := b.storage.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrBookingNotFound) {
+			b.log.Warn("in service CancelBooking", zap.Error(err))
+			return fmt.Errorf("in service CancelBooking: %w", myerror.ErrBookingNotFound)
+		}
+		b.log.Error("in service CancelBooking", zap.Error(err))
+		return fmt.Errorf("in service CancelBooking: %w", err)
+	}
+	if booking.UserID != user.UserID {
+		span.RecordError(myerror.ErrForbiddenAccess)
+		b.log.Warn("user tries to cancel booking of another user")
+		return fmt.Errorf("booking not owned by user %w", myerror.ErrForbiddenAccess)
+	}
+	// Отменить можно только ещё не начавшееся бронирование, из статуса, допускающего отмену
+	change, err := newTransition(booking.Status, models.StatusCancelled, models.ActorGuest, user.UserID, models.ReasonCancelledByGuest)
+	if err != nil || !booking.StartDate.After(time.Now()) {
+		span.RecordError(myerror.ErrInvalidBookingStatus)
+		b.log.Warn("booking can not be cancelled", zap.String("status", booking.Status))
+		return fmt.Errorf("in service CancelBooking: %w", myerror.ErrInvalidBookingStatus)
+	}
+
+	// Общий платёж группы ещё не прошёл, отмена одной комнаты разошлась бы с его суммой
+	if booking.GroupID != 0 && booking.Status == models.StatusPendingPayment {
+		span.RecordError(myerror.ErrInvalidBookingStatus)
+		b.log.Warn("group booking can not be cancelled before payment", zap.Int("group id", booking.GroupID))
+		return fmt.Errorf("in service CancelBooking: group booking awaits payment: %w", myerror.ErrInvalidBookingStatus)
+	}
+
+	startDateStr := booking.StartDate.Format(messageDateLayout)
+	endDateStr := booking.EndDate.Format(messageDateLayout)
+	bookingMessage := booking.ToBookingMessage(models.EventBookingCancelled, user.Username, user.ChatID, startDateStr, endDateStr)
+	outbox, err := bookingOutbox(bookingMessage)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("in service CancelBooking", zap.Error(err))
+		return fmt.Errorf("in service CancelBooking: %w", err)
+	}
+	// Возврат сохраняется в outbox вместе с отменой: если отмену не удалось сохранить, деньги не уходят.
+	// Бронирование отменяется один раз, поэтому ключ возврата выводится из его ID.
+	if booking.Status == models.StatusConfirmed {
+		refundRequest := models.ToRefundRequest(booking.OrderID(), booking.Amount)
+		refundRequest.RefundID = "cancellation-" + strconv.Itoa(booking.ID)
+		refund, err := refundOutbox(refundRequest)
 		if err != nil {
-			b.log.Error("error in service UpdateBookingStatus", zap.Error(err))
-			return fmt.Errorf("error SendMessage: %w", err)
+			span.RecordError(err)
+			b.log.Error("in service CancelBooking", zap.Error(err))
+			return fmt.Errorf("in service CancelBooking: %w", err)
+		}
+		outbox = append(outbox, refund)
+	}
+
+	err = b.storage.CancelBooking(ctx, bookingID, change, outbox)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrInvalidBookingStatus) {
+			b.log.Warn("in service CancelBooking", zap.Error(err))
+			return fmt.Errorf("in service CancelBooking: %w", myerror.ErrInvalidBookingStatus)
 		}
+		b.log.Error("in service CancelBooking", zap.Error(err))
+		return fmt.Errorf("in service CancelBooking: %w", err)
+	}
+
+	b.offerFreedRoom(ctx, booking.HotelID, booking.RoomID, booking.StartDate, booking.EndDate)
+
+	b.log.Info("in service cancel booking end successfully")
+	span.AddEvent("booking_cancelled")
+	return nil
+}
 
-		hotelReq := &hotelpb.GetOwnerIdRequest{Id: int32(bookingMessage.HotelID)}
-		hotelResponse, err := b.hotelSvcClient.GetOwnerIdByHotelId(ctx, hotelReq)
+// ModifyBooking изменяет даты, комнату или число гостей оплаченного бронирования.
+// Переплата возвращается через outbox вместе с сохранением изменения, а изменение с доплатой
+// ждёт её в PendingModification и применяется только после успешной оплаты.
+func (b *BookingServiceImpl) ModifyBooking(ctx context.Context, bookingID int, updateRequest *models.BookingUpdateRequest, user *models.User) (*models.Booking, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ModifyBooking")
+	defer span.End()
+	span.SetAttributes(attribute.Int("booking.booking_id", bookingID))
+	b.log.With(
+		zap.String("Layer", "service: ModifyBooking"),
+		zap.Int("booking id", bookingID),
+		zap.Int("user id", user.UserID),
+	).Info("Received request to modify booking")
+
+	booking, err := b.storage.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrBookingNotFound) {
+			b.log.Warn("in service ModifyBooking", zap.Error(err))
+			return nil, fmt.Errorf("in service ModifyBooking: %w", myerror.ErrBookingNotFound)
+		}
+		b.log.Error("in service ModifyBooking", zap.Error(err))
+		return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+	}
+	if booking.UserID != user.UserID {
+		span.RecordError(myerror.ErrForbiddenAccess)
+		b.log.Warn("user tries to modify booking of another user")
+		return nil, fmt.Errorf("booking not owned by user %w", myerror.ErrForbiddenAccess)
+	}
+	// Пока идёт оплата, бронирование менять нельзя. Комнаты группы оплачены общим платежом и меняются только отменой
+	if booking.Status != models.StatusConfirmed || !booking.StartDate.After(time.Now()) || booking.GroupID != 0 {
+		span.RecordError(myerror.ErrInvalidBookingStatus)
+		b.log.Warn("booking can not be modified", zap.String("status", booking.Status))
+		return nil, fmt.Errorf("in service ModifyBooking: %w", myerror.ErrInvalidBookingStatus)
+	}
+
+	updated := updateRequest.Apply(booking)
+	guests := updated.Guests()
+	// Общее число гостей, переданное вместе со взрослыми и детьми, должно совпадать с их суммой
+	mismatch := updateRequest.CountOfPeople != nil && *updateRequest.CountOfPeople != guests.Total()
+	if !updated.EndDate.After(updated.StartDate) || !updated.StartDate.After(time.Now()) ||
+		guests.Adults <= 0 || guests.Children < 0 || mismatch {
+		span.RecordError(myerror.ErrInvalidBookingData)
+		return nil, fmt.Errorf("in service ModifyBooking: %w", myerror.ErrInvalidBookingData)
+	}
+
+	details, err := b.getRoomDetails(ctx, updated.HotelID, updated.RoomID)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Warn("in service ModifyBooking", zap.Error(err))
+		return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+	}
+	if err = checkRoomCapacity(details.Room, guests); err != nil {
+		span.RecordError(err)
+		b.log.Warn("in service ModifyBooking", zap.Error(err))
+		return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+	}
+	price, err := b.getPriceQuote(ctx, updated.HotelID, updated.RoomID, updated.StartDate, updated.EndDate, updated.CountOfPeople)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Warn("in service ModifyBooking", zap.Error(err))
+		return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+	}
+	// Промокод сохраняется, если по-прежнему действует на новые даты, иначе скидка и использование кода снимаются.
+	// Окно применения кода не проверяется: код уже был применён при создании бронирования.
+	if code := booking.PromoCodeOf(); code != "" {
+		promo, err := b.getPromoCode(ctx, updated.HotelID, code)
+		if err != nil && !errors.Is(err, myerror.ErrPromoCodeNotFound) {
+			span.RecordError(err)
+			b.log.Error("in service ModifyBooking", zap.Error(err))
+			return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+		}
+		if promo != nil && promo.CoversStay(updated.StartDate, updated.EndDate) {
+			price.ApplyPromo(promo)
+		} else {
+			b.log.Info("promo code dropped on modification", zap.String("promo code", code))
+			updated.PromoCodeID = 0
+		}
+	}
+	updated.RoomDescription = details.Room.Description
+	updated.RoomNumber = int(details.Room.Number)
+	updated.Amount = price.Total
+	updated.Price = price
+
+	delta := updated.Amount - booking.Amount
+	if delta > 0 {
+		if updateRequest.CardNumber == "" {
+			span.RecordError(myerror.ErrInvalidBookingData)
+			return nil, fmt.Errorf("card number is required for surcharge: %w", myerror.ErrInvalidBookingData)
+		}
+		// Бронирование остаётся подтверждённым на прежних условиях, пока доплата не пройдёт
+		modification := models.NewBookingModification(updated, delta, time.Now().Add(b.holdTTL))
+		if err = b.requestSurcharge(ctx, booking, modification, updateRequest.CardNumber, user); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+		}
+		booking.PendingModification = modification
+		span.AddEvent("booking_modification_waiting_payment")
+		return booking, nil
+	}
+
+	startDateStr := updated.StartDate.Format(messageDateLayout)
+	endDateStr := updated.EndDate.Format(messageDateLayout)
+	bookingMessage := updated.ToBookingMessage(models.EventBookingModified, user.Username, user.ChatID, startDateStr, endDateStr)
+	outbox, err := bookingOutbox(bookingMessage)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("in service ModifyBooking", zap.Error(err))
+		return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+	}
+	// Разница возвращается через outbox: возврат сохраняется вместе с изменением и повторяется до успеха
+	if delta < 0 {
+		refund, err := refundOutbox(models.ToRefundRequest(updated.OrderID(), -delta))
 		if err != nil {
-			st, ok := status.FromError(err)
-			if ok && st.Code() == codes.NotFound {
-				b.log.Warn("error in service gRPC GetBookingsByHotelID:", zap.Error(myerror.ErrHotelNotFound))
-				return fmt.Errorf("error in service GetOwnerIdByHotelId: %w", myerror.ErrHotelNotFound)
-			}
-			b.log.Error("error in service gRPC GetBookingsByHotelID:", zap.Error(err))
-			return fmt.Errorf("error in service GetOwnerIdByHotelId: %w", err)
+			span.RecordError(err)
+			b.log.Error("in service ModifyBooking", zap.Error(err))
+			return nil, fmt.Errorf("in service ModifyBooking: %w", err)
 		}
-		authReq := &authpb.GetHotelierRequest{OwnerID: hotelResponse.OwnerId}
+		outbox = append(outbox, refund)
+	}
 
-		authResponse, err := b.authSvcClient.GetHotelierInformation(ctx, authReq)
+	change := &models.StatusTransition{From: booking.Status, To: booking.Status, Actor: models.ActorGuest, ActorID: user.UserID}
+	err = b.storage.UpdateBooking(ctx, updated, change, outbox)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrBookingAlreadyExists) || errors.Is(err, myerror.ErrInvalidBookingStatus) {
+			b.log.Warn("in service ModifyBooking", zap.Error(err))
+			return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+		}
+		b.log.Error("in service ModifyBooking", zap.Error(err))
+		return nil, fmt.Errorf("in service ModifyBooking: %w", err)
+	}
+
+	b.log.Info("in service modify booking end successfully", zap.Int("amount delta", delta))
+	span.AddEvent("booking_modified")
+	return updated, nil
+}
+
+// ExpireStaleBookings снимает с брони комнаты, оплата которых не пришла за holdTTL, и уведомляет гостей.
+// Изменения, не доплаченные вовремя, закрываются без возврата: бронирование остаётся на прежних условиях,
+// а доплата, пришедшая позже, возвращается при обработке вебхука.
+func (b *BookingServiceImpl) ExpireStaleBookings(ctx context.Context) (int, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ExpireStaleBookings")
+	defer span.End()
+
+	modifications, err := b.storage.ExpireBookingModifications(ctx)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service ExpireStaleBookings", zap.Error(err))
+		return 0, fmt.Errorf("error in service ExpireStaleBookings: %w", err)
+	}
+	if modifications > 0 {
+		b.log.Info("booking modification holds expired", zap.Int("count", modifications))
+	}
+	span.SetAttributes(attribute.Int("modifications.expired", modifications))
+
+	change, err := newTransition(models.StatusPendingPayment, models.StatusExpired, models.ActorSystem, 0, models.ReasonHoldExpired)
+	if err != nil {
+		span.RecordError(err)
+		return 0, fmt.Errorf("error in service ExpireStaleBookings: %w", err)
+	}
+	// Об истечении брони узнаёт только гость: для владельца отеля бронирования не было
+	expired, err := b.storage.ExpireBookings(ctx, change, func(booking *models.Booking) ([]*models.OutboxMessage, error) {
+		startDateStr := booking.StartDate.Format(messageDateLayout)
+		endDateStr := booking.EndDate.Format(messageDateLayout)
+		bookingMessage := booking.ToBookingMessage(models.EventBookingExpired, booking.Username, booking.ChatID, startDateStr, endDateStr)
+		payload, err := json.Marshal(bookingMessage)
 		if err != nil {
-			st, ok := status.FromError(err)
-			if ok && st.Code() == codes.NotFound {
-				b.log.Warn("error in service gRPC GetBookingsByHotelID:", zap.Error(err))
-				return fmt.Errorf("error in service GetOwnerIdByHotelId: %w", myerror.ErrHotelNotFound)
-			}
-			b.log.Error("error in service gRPC GetBookingsByHotelID:", zap.Error(err))
-			return fmt.Errorf("error in service GetOwnerIdByHotelId: %w", err)
+			return nil, fmt.Errorf("error in Marshal KafkaUserMessage: %w", err)
+		}
+		return []*models.OutboxMessage{{Recipient: models.RecipientUser, Payload: payload}}, nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service ExpireStaleBookings", zap.Error(err))
+		return 0, fmt.Errorf("error in service ExpireStaleBookings: %w", err)
+	}
+
+	for _, booking := range expired {
+		b.log.Info("booking payment hold expired", zap.Int("booking id", booking.ID))
+		b.offerFreedRoom(ctx, booking.HotelID, booking.RoomID, booking.StartDate, booking.EndDate)
+	}
+
+	span.SetAttributes(attribute.Int("bookings.expired", len(expired)))
+	return len(expired), nil
+}
+
+// ReleaseIdempotencyKeys освобождает ключи идемпотентности старше idempotencyTTL
+func (b *BookingServiceImpl) ReleaseIdempotencyKeys(ctx context.Context) (int, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ReleaseIdempotencyKeys")
+	defer span.End()
+
+	released, err := b.storage.ReleaseIdempotencyKeys(ctx, time.Now().Add(-b.idempotencyTTL))
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service ReleaseIdempotencyKeys", zap.Error(err))
+		return 0, fmt.Errorf("error in service ReleaseIdempotencyKeys: %w", err)
+	}
+	span.SetAttributes(attribute.Int("idempotency_keys.released", released))
+	return released, nil
+}
+
+// getRoomDetails запрашивает в HotelSvc комнату отеля с её типом и ценой
+func (b *BookingServiceImpl) getRoomDetails(ctx context.Context, hotelID, roomID int) (*hotelpb.GetRoomDetailsResponse, error) {
+	req := &hotelpb.GetRoomDetailsRequest{HotelId: int32(hotelID), RoomId: int32(roomID)}
+	details, err := b.hotelSvcClient.GetRoomDetails(ctx, req)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.NotFound {
+			return nil, myerror.ErrRoomNotFound
+		}
+		return nil, fmt.Errorf("error in gRPC request GetRoomDetails: %w", err)
+	}
+	return details, nil
+}
+
+// getPriceQuotes запрашивает в HotelSvc стоимость проживания в комнатах, результат по ID комнаты
+func (b *BookingServiceImpl) getPriceQuotes(ctx context.Context, hotelID int, roomIDs []int32, startDate, endDate time.Time, countOfPeople int) (map[int]*models.PriceQuote, error) {
+	req := &hotelpb.GetPriceQuotesRequest{
+		HotelId:   int32(hotelID),
+		RoomIds:   roomIDs,
+		StartDate: startDate.UTC().Format(hotelpb.DateLayout),
+		EndDate:   endDate.UTC().Format(hotelpb.DateLayout),
+		Guests:    int32(countOfPeople),
+	}
+	response, err := b.hotelSvcClient.GetPriceQuotes(ctx, req)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.NotFound {
+			return nil, myerror.ErrRoomNotFound
+		}
+		if ok && st.Code() == codes.InvalidArgument {
+			return nil, fmt.Errorf("%s: %w", st.Message(), myerror.ErrInvalidBookingData)
+		}
+		return nil, fmt.Errorf("error in gRPC request GetPriceQuotes: %w", err)
+	}
+
+	quotes := make(map[int]*models.PriceQuote, len(response.Quotes))
+	for _, quote := range response.Quotes {
+		nights := make([]models.NightPrice, 0, len(quote.Nights))
+		for _, night := range quote.Nights {
+			nights = append(nights, models.NightPrice{
+				Date:            night.Date,
+				Rate:            night.Rate,
+				Price:           int(night.Price),
+				ExtraGuestPrice: int(night.ExtraGuestPrice),
+			})
+		}
+		quotes[int(quote.RoomId)] = &models.PriceQuote{
+			Nights:          nights,
+			Subtotal:        int(quote.Subtotal),
+			DiscountPercent: int(quote.DiscountPercent),
+			Discount:        int(quote.Discount),
+			Total:           int(quote.Total),
+		}
+	}
+	return quotes, nil
+}
+
+// getPriceQuote - стоимость проживания в одной комнате
+func (b *BookingServiceImpl) getPriceQuote(ctx context.Context, hotelID, roomID int, startDate, endDate time.Time, countOfPeople int) (*models.PriceQuote, error) {
+	quotes, err := b.getPriceQuotes(ctx, hotelID, []int32{int32(roomID)}, startDate, endDate, countOfPeople)
+	if err != nil {
+		return nil, err
+	}
+	quote, ok := quotes[roomID]
+	if !ok {
+		return nil, myerror.ErrRoomNotFound
+	}
+	return quote, nil
+}
+
+// getPromoCode запрашивает в HotelSvc действующий промокод отеля
+func (b *BookingServiceImpl) getPromoCode(ctx context.Context, hotelID int, code string) (*models.PromoCode, error) {
+	req := &hotelpb.GetPromoCodeRequest{HotelId: int32(hotelID), Code: code}
+	response, err := b.hotelSvcClient.GetPromoCode(ctx, req)
+	if err != nil {
+		st, ok := status.FromError(err)
+		if ok && st.Code() == codes.NotFound {
+			return nil, myerror.ErrPromoCodeNotFound
 		}
+		return nil, fmt.Errorf("error in gRPC request GetPromoCode: %w", err)
+	}
+	promo, err := promoCodeFromPb(response.PromoCode)
+	if err != nil {
+		return nil, fmt.Errorf("error in gRPC request GetPromoCode: %w", err)
+	}
+	return promo, nil
+}
 
-		hotelierMessage := bookingMessage.ToHotelierMessage(bookingMessage.Username, authResponse.ChatID)
-		kafkaHotelierMessage, err := json.Marshal(hotelierMessage)
+func promoCodeFromPb(pb *hotelpb.PromoCode) (*models.PromoCode, error) {
+	promo := &models.PromoCode{
+		ID:               int(pb.Id),
+		HotelID:          int(pb.HotelId),
+		Code:             pb.Code,
+		DiscountType:     pb.DiscountType,
+		Value:            int(pb.Value),
+		UsageLimit:       int(pb.UsageLimit),
+		PerUserLimit:     int(pb.PerUserLimit),
+		FirstBookingOnly: pb.FirstBookingOnly,
+	}
+	var err error
+	if promo.ValidFrom, err = time.Parse(time.RFC3339, pb.ValidFrom); err != nil {
+		return nil, fmt.Errorf("invalid valid_from: %w", err)
+	}
+	if promo.ValidUntil, err = time.Parse(time.RFC3339, pb.ValidUntil); err != nil {
+		return nil, fmt.Errorf("invalid valid_until: %w", err)
+	}
+	if pb.StayFrom != "" {
+		stayFrom, err := time.Parse(hotelpb.DateLayout, pb.StayFrom)
 		if err != nil {
-			b.log.Error("error in service UpdateBookingStatus", zap.Error(err))
-			return fmt.Errorf("error in Marshal KafkaHotelierMessage: %w", err)
+			return nil, fmt.Errorf("invalid stay_from: %w", err)
 		}
-		err = b.messageProducer.SendHotelierMessage(ctx, kafkaHotelierMessage)
+		promo.StayFrom = &stayFrom
+	}
+	if pb.StayUntil != "" {
+		stayUntil, err := time.Parse(hotelpb.DateLayout, pb.StayUntil)
 		if err != nil {
-			b.log.Error("error in service UpdateBookingStatus", zap.Error(err))
-			return fmt.Errorf("error in SendMessage: %w", err)
+			return nil, fmt.Errorf("invalid stay_until: %w", err)
 		}
-	case "fail":
-		b.log.Warn("The payment is failing")
+		promo.StayUntil = &stayUntil
+	}
+	return promo, nil
+}
+
+// applyRoomDetails подставляет в запрос данные комнаты из HotelSvc.
+// Цена и номер комнаты, присланные клиентом, должны совпадать с актуальными.
+func applyRoomDetails(bookingRequest *models.BookingRequest, details *hotelpb.GetRoomDetailsResponse) error {
+	if err := checkRoomCapacity(details.Room, models.Guests{Adults: bookingRequest.Adults, Children: bookingRequest.Children}); err != nil {
+		return err
+	}
+	basePrice := int(details.RoomType.BasePrice)
+	roomNumber := int(details.Room.Number)
+	if bookingRequest.RoomBasePrice != 0 && bookingRequest.RoomBasePrice != basePrice {
+		return fmt.Errorf("room base price %d, actual %d: %w", bookingRequest.RoomBasePrice, basePrice, myerror.ErrRoomDataMismatch)
+	}
+	if bookingRequest.RoomNumber != 0 && bookingRequest.RoomNumber != roomNumber {
+		return fmt.Errorf("room number %d, actual %d: %w", bookingRequest.RoomNumber, roomNumber, myerror.ErrRoomDataMismatch)
+	}
+	bookingRequest.RoomBasePrice = basePrice
+	bookingRequest.RoomNumber = roomNumber
+	bookingRequest.HotelName = details.HotelName
+	bookingRequest.RoomDescription = details.Room.Description
+	return nil
+}
+
+// roomCapacity возвращает вместимость комнаты из ответа HotelSvc
+func roomCapacity(room *hotelpb.Room) models.RoomCapacity {
+	return models.RoomCapacity{
+		MaxAdults:        int(room.MaxAdults),
+		MaxChildren:      int(room.MaxChildren),
+		BedConfiguration: room.BedConfiguration,
+	}
+}
+
+// checkRoomCapacity не даёт забронировать комнату на больше гостей, чем она вмещает
+func checkRoomCapacity(room *hotelpb.Room, guests models.Guests) error {
+	capacity := roomCapacity(room)
+	if !capacity.Fits(guests) {
+		return fmt.Errorf("room %d fits %d adults and %d children, requested %d adults and %d children: %w",
+			room.Id, capacity.MaxAdults, capacity.MaxChildren, guests.Adults, guests.Children, myerror.ErrRoomCapacityExceeded)
+	}
+	return nil
+}
+
+func (b *BookingServiceImpl) refundLatePayment(ctx context.Context, bookingID int) error {
+	booking, err := b.storage.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		b.log.Error("error in service refundLatePayment", zap.Error(err))
+		return fmt.Errorf("error in service refundLatePayment: %w", err)
+	}
+	if booking.Status != models.StatusCancelled && booking.Status != models.StatusExpired {
+		return fmt.Errorf("error in service refundLatePayment: %w", myerror.ErrInvalidBookingStatus)
+	}
+	b.log.Warn("payment received for inactive booking, refunding", zap.Int("booking id", bookingID), zap.String("status", booking.Status))
+	err = b.paymentSystemClient.CreateRefundRequest(ctx, models.ToRefundRequest(booking.OrderID(), 0))
+	if err != nil {
+		b.log.Error("error in service refundLatePayment", zap.Error(err))
+		return fmt.Errorf("error in refund request: %w", err)
 	}
-	b.log.Info("in service update booking status end successfully", zap.String("status", BookingStatus))
 	return nil
 }
+
+// refundOutbox готовит возврат для отправки через outbox. Ключ возврата задаётся один раз,
+// поэтому повторная отправка того же сообщения не вернёт деньги дважды.
+func refundOutbox(refundRequest *models.RefundRequest) (*models.OutboxMessage, error) {
+	if refundRequest.RefundID == "" {
+		buf := make([]byte, 16)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("error in generating refund id: %w", err)
+		}
+		refundRequest.RefundID = hex.EncodeToString(buf)
+	}
+	payload, err := json.Marshal(refundRequest)
+	if err != nil {
+		return nil, fmt.Errorf("error in Marshal RefundRequest: %w", err)
+	}
+	return &models.OutboxMessage{Recipient: models.RecipientPaymentSystem, Payload: payload}, nil
+}
+
+// bookingOutbox готовит событие бронирования для гостя и владельца отеля.
+// Чат владельца определяется при отправке, поэтому в сообщении для него ChatID пустой.
+func bookingOutbox(bookingMessage *models.BookingMessage) ([]*models.OutboxMessage, error) {
+	userPayload, err := json.Marshal(bookingMessage)
+	if err != nil {
+		return nil, fmt.Errorf("error in Marshal KafkaUserMessage: %w", err)
+	}
+	hotelierPayload, err := json.Marshal(bookingMessage.ToHotelierMessage(bookingMessage.Username, ""))
+	if err != nil {
+		return nil, fmt.Errorf("error in Marshal KafkaHotelierMessage: %w", err)
+	}
+	return []*models.OutboxMessage{
+		{Recipient: models.RecipientUser, Payload: userPayload},
+		{Recipient: models.RecipientHotelier, Payload: hotelierPayload},
+	}, nil
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+)
+
+// newTransition проверяет переход from -> to по машине состояний бронирования и описывает его для истории
+func newTransition(from, to, actor string, actorID int, reason string) (*models.StatusTransition, error) {
+	if !models.CanTransition(from, to) {
+		return nil, fmt.Errorf("booking status %s -> %s: %w", from, to, myerror.ErrInvalidBookingStatus)
+	}
+	return &models.StatusTransition{From: from, To: to, Actor: actor, ActorID: actorID, Reason: reason}, nil
+}
+
+// paymentTransition - переход ожидающего оплаты бронирования по статусу платежа из вебхука PaymentSystem
+func paymentTransition(paymentStatus string) (*models.StatusTransition, error) {
+	status, ok := models.StatusFromPayment(paymentStatus)
+	if !ok {
+		return nil, fmt.Errorf("payment status %q: %w", paymentStatus, myerror.ErrInvalidPaymentStatus)
+	}
+	reason := models.ReasonPaymentSucceeded
+	if status == models.StatusPaymentFailed {
+		reason = models.ReasonPaymentFailed
+	}
+	return newTransition(models.StatusPendingPayment, status, models.ActorPaymentSystem, 0, reason)
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"time"
+)
+
+// CheckInBooking отмечает заезд гостя. Заселить можно оплаченное бронирование в день заезда или позже, до выезда.
+func (b *BookingServiceImpl) CheckInBooking(ctx context.Context, bookingID, userID int) (*models.Booking, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.CheckInBooking")
+	defer span.End()
+	span.SetAttributes(attribute.Int("booking.booking_id", bookingID))
+	b.log.With(
+		zap.String("Layer", "service: CheckInBooking"),
+		zap.Int("booking id", bookingID),
+		zap.Int("user id", userID),
+	).Info("Received request to check in guest")
+
+	booking, err := b.hotelierBooking(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service CheckInBooking: %w", err)
+	}
+
+	now := time.Now()
+	arrivalDay, _ := models.DayBounds(booking.StartDate)
+	change, err := newTransition(booking.Status, models.StatusCheckedIn, models.ActorHotelier, userID, models.ReasonGuestCheckedIn)
+	if err != nil || now.Before(arrivalDay) || !now.Before(booking.EndDate) {
+		span.RecordError(myerror.ErrInvalidBookingStatus)
+		b.log.Warn("guest can not be checked in", zap.String("status", booking.Status))
+		return nil, fmt.Errorf("in service CheckInBooking: %w", myerror.ErrInvalidBookingStatus)
+	}
+	if err = b.changeStayStatus(ctx, booking, change); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service CheckInBooking: %w", err)
+	}
+
+	span.AddEvent("guest checked in")
+	return booking, nil
+}
+
+// CheckOutBooking отмечает выезд гостя. При раннем выезде оставшиеся ночи предлагаются листу ожидания.
+func (b *BookingServiceImpl) CheckOutBooking(ctx context.Context, bookingID, userID int) (*models.Booking, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.CheckOutBooking")
+	defer span.End()
+	span.SetAttributes(attribute.Int("booking.booking_id", bookingID))
+	b.log.With(
+		zap.String("Layer", "service: CheckOutBooking"),
+		zap.Int("booking id", bookingID),
+		zap.Int("user id", userID),
+	).Info("Received request to check out guest")
+
+	booking, err := b.hotelierBooking(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service CheckOutBooking: %w", err)
+	}
+
+	change, err := newTransition(booking.Status, models.StatusCheckedOut, models.ActorHotelier, userID, models.ReasonGuestCheckedOut)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Warn("guest can not be checked out", zap.String("status", booking.Status))
+		return nil, fmt.Errorf("in service CheckOutBooking: %w", err)
+	}
+	if err = b.changeStayStatus(ctx, booking, change); err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service CheckOutBooking: %w", err)
+	}
+
+	// checked_out не занимает комнату, поэтому после раннего выезда она свободна до конца бронирования
+	if now := time.Now(); now.Before(booking.EndDate) {
+		b.offerFreedRoom(ctx, booking.HotelID, booking.RoomID, now, booking.EndDate)
+	}
+
+	span.AddEvent("guest checked out")
+	return booking, nil
+}
+
+// MarkNoShow отмечает, что гость не приехал. Комната остаётся за гостем до конца бронирования,
+// releaseRemainingNights возвращает в продажу ночи после первой, деньги за бронирование не возвращаются.
+func (b *BookingServiceImpl) MarkNoShow(ctx context.Context, bookingID, userID int, releaseRemainingNights bool) (*models.Booking, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.MarkNoShow")
+	defer span.End()
+	span.SetAttributes(attribute.Int("booking.booking_id", bookingID), attribute.Bool("booking.release_nights", releaseRemainingNights))
+	b.log.With(
+		zap.String("Layer", "service: MarkNoShow"),
+		zap.Int("booking id", bookingID),
+		zap.Int("user id", userID),
+		zap.Bool("release remaining nights", releaseRemainingNights),
+	).Info("Received request to mark no-show")
+
+	booking, err := b.hotelierBooking(ctx, bookingID, userID)
+	if err != nil {
+		span.RecordError(err)
+		return nil, fmt.Errorf("in service MarkNoShow: %w", err)
+	}
+
+	// Неявку можно отметить только после времени заезда
+	now := time.Now()
+	change, err := newTransition(booking.Status, models.StatusNoShow, models.ActorHotelier, userID, models.ReasonGuestNoShow)
+	if err != nil || !now.After(booking.StartDate) {
+		span.RecordError(myerror.ErrInvalidBookingStatus)
+		b.log.Warn("booking can not be marked as no-show", zap.String("status", booking.Status))
+		return nil, fmt.Errorf("in service MarkNoShow: %w", myerror.ErrInvalidBookingStatus)
+	}
+
+	// Первая ночь остаётся за гостем, прошедшие ночи освобождать бессмысленно
+	releaseFrom := booking.StartDate.AddDate(0, 0, 1)
+	if now.After(releaseFrom) {
+		releaseFrom = now
+	}
+	if !releaseRemainingNights || !releaseFrom.Before(booking.EndDate) {
+		if err = b.changeStayStatus(ctx, booking, change); err != nil {
+			span.RecordError(err)
+			return nil, fmt.Errorf("in service MarkNoShow: %w", err)
+		}
+		span.AddEvent("booking marked as no-show")
+		return booking, nil
+	}
+
+	freedUntil := booking.EndDate
+	booking.EndDate = releaseFrom
+	if err = b.storage.UpdateBooking(ctx, booking, change, nil); err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrInvalidBookingStatus) {
+			b.log.Warn("in service MarkNoShow", zap.Error(err))
+			return nil, fmt.Errorf("in service MarkNoShow: %w", myerror.ErrInvalidBookingStatus)
+		}
+		b.log.Error("in service MarkNoShow", zap.Error(err))
+		return nil, fmt.Errorf("in service MarkNoShow: %w", err)
+	}
+	booking.Status = change.To
+
+	b.offerFreedRoom(ctx, booking.HotelID, booking.RoomID, releaseFrom, freedUntil)
+
+	span.AddEvent("booking marked as no-show, remaining nights released")
+	return booking, nil
+}
+
+// hotelierBooking загружает бронирование и проверяет, что его отель принадлежит userID
+func (b *BookingServiceImpl) hotelierBooking(ctx context.Context, bookingID, userID int) (*models.Booking, error) {
+	booking, err := b.storage.GetBookingByID(ctx, bookingID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrBookingNotFound) {
+			b.log.Warn("in service hotelierBooking", zap.Error(err))
+			return nil, myerror.ErrBookingNotFound
+		}
+		b.log.Error("in service hotelierBooking", zap.Error(err))
+		return nil, err
+	}
+	if err = b.checkHotelOwner(ctx, booking.HotelID, userID); err != nil {
+		if errors.Is(err, myerror.ErrHotelNotFound) {
+			return nil, myerror.ErrForbiddenAccess
+		}
+		return nil, err
+	}
+	return booking, nil
+}
+
+// changeStayStatus сохраняет переход бронирования, не меняя его даты
+func (b *BookingServiceImpl) changeStayStatus(ctx context.Context, booking *models.Booking, change *models.StatusTransition) error {
+	if err := b.storage.UpdateBookingStatus(ctx, change, booking.ID, nil); err != nil {
+		if errors.Is(err, myerror.ErrInvalidBookingStatus) {
+			b.log.Warn("in service changeStayStatus", zap.Error(err))
+			return myerror.ErrInvalidBookingStatus
+		}
+		b.log.Error("in service changeStayStatus", zap.Error(err))
+		return err
+	}
+	booking.Status = change.To
+	return nil
+}
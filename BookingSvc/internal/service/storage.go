@@ -8,10 +8,59 @@ import (
 
 //go:generate mockgen -source=storage.go -destination=mocks/storage_mock.go -package=mocks
 type Storage interface {
-	CreateBooking(ctx context.Context, booking *models.BookingInfo) (int, error)
-	GetBookingsByUserID(ctx context.Context, userID int) ([]*models.BookingInfo, error)
-	GetBookingsByHotelID(ctx context.Context, hotelID int) ([]*models.BookingInfo, error)
-	UpdateBookingStatus(ctx context.Context, status string, bookingID int) error
+	CreateBooking(ctx context.Context, booking *models.Booking, promo *models.PromoCode) (int, error)
+	CreateGroupBooking(ctx context.Context, group *models.GroupBooking) error
+	UpdateGroupStatus(ctx context.Context, change *models.StatusTransition, groupID int, outbox []*models.OutboxMessage) error
+	GetBookingsByGroupID(ctx context.Context, groupID int) ([]*models.Booking, error)
+	GetBookingByID(ctx context.Context, bookingID int) (*models.Booking, error)
+	GetBookingByIdempotencyKey(ctx context.Context, userID int, key string, since time.Time) (*models.Booking, error)
+	ReleaseIdempotencyKeys(ctx context.Context, olderThan time.Time) (int, error)
+	GetStalePendingBookings(ctx context.Context, pendingSince time.Time, limit int) ([]*models.Booking, error)
+	CancelBooking(ctx context.Context, bookingID int, change *models.StatusTransition, outbox []*models.OutboxMessage) error
+	UpdateBooking(ctx context.Context, booking *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error
+	CreateBookingModification(ctx context.Context, modification *models.BookingModification) error
+	GetBookingModification(ctx context.Context, modificationID int) (*models.BookingModification, error)
+	ApplyBookingModification(ctx context.Context, modificationID int, booking *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error
+	ResolveBookingModification(ctx context.Context, modificationID int, from, to string, outbox []*models.OutboxMessage) error
+	ExpireBookingModifications(ctx context.Context) (int, error)
+	ExpireBookings(ctx context.Context, change *models.StatusTransition, toOutbox func(*models.Booking) ([]*models.OutboxMessage, error)) ([]*models.Booking, error)
+	GetBookingsByUserID(ctx context.Context, userID int, filter *models.BookingFilter) ([]*models.Booking, error)
+	GetBookingsByHotelID(ctx context.Context, hotelID int, filter *models.BookingFilter) ([]*models.Booking, error)
+	StreamBookingsByHotelID(ctx context.Context, hotelID int, filter *models.BookingFilter, fn func(*models.Booking) error) error
+	AbandonBooking(ctx context.Context, change *models.StatusTransition, bookingID int) error
+	UpdateBookingStatus(ctx context.Context, change *models.StatusTransition, bookingID int, outbox []*models.OutboxMessage) error
+	GetStatusHistory(ctx context.Context, bookingIDs []int) (map[int][]*models.StatusChange, error)
+	SavePaymentWebhook(ctx context.Context, signature string, signedAt time.Time) error
+	DeletePaymentWebhook(ctx context.Context, signature string) error
+	ReleasePaymentWebhooks(ctx context.Context, olderThan time.Time) (int, error)
 
+	CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) (int, error)
+	GetWaitlistCandidates(ctx context.Context, hotelID, roomTypeID int, startDate, endDate time.Time, limit int) ([]*models.WaitlistEntry, error)
+	OfferWaitlistEntry(ctx context.Context, entryID, roomID int, token string, expiresAt time.Time, outbox []*models.OutboxMessage) error
+	ClaimWaitlistOffer(ctx context.Context, token string, userID int) (*models.WaitlistEntry, error)
+	RequeueWaitlistEntry(ctx context.Context, entryID int) error
+	ExpireWaitlistEntries(ctx context.Context) ([]*models.WaitlistEntry, error)
+
+	CreateICalFeed(ctx context.Context, feed *models.ICalFeed, userID int) error
+	GetICalFeed(ctx context.Context, token string) (*models.ICalFeed, error)
+	DeleteICalFeed(ctx context.Context, token string) error
+	CreateICalImport(ctx context.Context, icalImport *models.ICalImport, userID int) error
+	GetICalImport(ctx context.Context, importID int) (*models.ICalImport, error)
+	GetICalImports(ctx context.Context, hotelID int) ([]*models.ICalImport, error)
+	DeleteICalImport(ctx context.Context, importID int) error
+	ReplaceImportedBlocks(ctx context.Context, importID int, blocks []*models.RoomBlock) error
+	MarkICalImportFailed(ctx context.Context, importID int, reason string) error
+
+	GetPendingOutbox(ctx context.Context, limit int) ([]*models.OutboxMessage, error)
+	MarkOutboxSent(ctx context.Context, messageID int64) error
+	MarkOutboxFailed(ctx context.Context, messageID int64, reason string) (bool, error)
+
+	GetActiveBookingsByHotelPeriod(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*models.Booking, error)
+	GetRoomBlocksByHotelPeriod(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*models.RoomBlock, error)
+	CreateRoomBlock(ctx context.Context, block *models.RoomBlock, userID int) error
+	GetRoomBlock(ctx context.Context, blockID int64) (*models.RoomBlock, error)
+	GetRoomBlocks(ctx context.Context, hotelID, roomID int, from, to *time.Time) ([]*models.RoomBlock, error)
+	DeleteRoomBlock(ctx context.Context, blockID int64) error
 	GetUnavailableRoomsByHotelId(ctx context.Context, HotelID int, startDate, endDate time.Time) (map[int]struct{}, error)
+	GetUnavailableRoomsByHotelIDs(ctx context.Context, hotelIDs []int, startDate, endDate time.Time) (map[int]struct{}, error)
 }
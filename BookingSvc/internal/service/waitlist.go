@@ -0,0 +1,194 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"net/url"
+	"time"
+)
+
+// Сколько первых гостей очереди проверяется, когда освобождается комната
+const waitlistCandidatesLimit = 20
+
+// JoinWaitlist ставит гостя в лист ожидания отеля на даты, когда подходящих свободных комнат нет
+func (b *BookingServiceImpl) JoinWaitlist(ctx context.Context, waitlistRequest *models.WaitlistRequest, user *models.User) (*models.WaitlistEntry, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.JoinWaitlist")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: JoinWaitlist"),
+		zap.Int("hotel id", waitlistRequest.HotelID),
+		zap.Int("room type id", waitlistRequest.RoomTypeID),
+		zap.Int("user id", user.UserID),
+	).Info("Received request to join waitlist")
+
+	if waitlistRequest.HotelID <= 0 || waitlistRequest.RoomTypeID < 0 || waitlistRequest.CountOfPeople <= 0 ||
+		!waitlistRequest.EndDate.After(waitlistRequest.StartDate) || !waitlistRequest.StartDate.After(time.Now()) {
+		span.RecordError(myerror.ErrInvalidBookingData)
+		return nil, fmt.Errorf("in service JoinWaitlist: %w", myerror.ErrInvalidBookingData)
+	}
+
+	entry := waitlistRequest.ToWaitlistEntry(user)
+	entryID, err := b.storage.CreateWaitlistEntry(ctx, entry)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("in service JoinWaitlist", zap.Error(err))
+		return nil, fmt.Errorf("in service JoinWaitlist: %w", err)
+	}
+	entry.ID = entryID
+	entry.CreatedAt = time.Now()
+
+	span.AddEvent("waitlist_joined")
+	return entry, nil
+}
+
+// ClaimWaitlistOffer бронирует предложенную по ссылке комнату обычным порядком, с оплатой картой гостя.
+// Комната на время предложения не удерживается: если её успели занять, гость возвращается в очередь.
+func (b *BookingServiceImpl) ClaimWaitlistOffer(ctx context.Context, token string, claimRequest *models.WaitlistClaimRequest, user *models.User) (*models.Booking, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ClaimWaitlistOffer")
+	defer span.End()
+	b.log.With(
+		zap.String("Layer", "service: ClaimWaitlistOffer"),
+		zap.Int("user id", user.UserID),
+	).Info("Received request to claim waitlist offer")
+
+	entry, err := b.storage.ClaimWaitlistOffer(ctx, token, user.UserID)
+	if err != nil {
+		span.RecordError(err)
+		if errors.Is(err, myerror.ErrWaitlistOfferUnavailable) {
+			b.log.Warn("in service ClaimWaitlistOffer", zap.Error(err))
+			return nil, fmt.Errorf("in service ClaimWaitlistOffer: %w", myerror.ErrWaitlistOfferUnavailable)
+		}
+		b.log.Error("in service ClaimWaitlistOffer", zap.Error(err))
+		return nil, fmt.Errorf("in service ClaimWaitlistOffer: %w", err)
+	}
+	span.SetAttributes(attribute.Int("waitlist.entry_id", entry.ID))
+
+	booking, err := b.CreateBooking(ctx, entry.ToBookingRequest(claimRequest.CardNumber), user)
+	if err != nil {
+		span.RecordError(err)
+		if requeueErr := b.storage.RequeueWaitlistEntry(ctx, entry.ID); requeueErr != nil {
+			b.log.Error("in service ClaimWaitlistOffer: failed to requeue", zap.Int("entry id", entry.ID), zap.Error(requeueErr))
+		}
+		return nil, fmt.Errorf("in service ClaimWaitlistOffer: %w", err)
+	}
+
+	b.log.Info("waitlist offer claimed", zap.Int("entry id", entry.ID), zap.Int("booking id", booking.ID))
+	span.AddEvent("waitlist_offer_claimed")
+	return booking, nil
+}
+
+// ExpireWaitlistOffers снимает просроченные предложения и предлагает те же комнаты следующим в очереди
+func (b *BookingServiceImpl) ExpireWaitlistOffers(ctx context.Context) (int, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ExpireWaitlistOffers")
+	defer span.End()
+
+	expired, err := b.storage.ExpireWaitlistEntries(ctx)
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service ExpireWaitlistOffers", zap.Error(err))
+		return 0, fmt.Errorf("error in service ExpireWaitlistOffers: %w", err)
+	}
+	for _, entry := range expired {
+		if entry.OfferedRoomID == 0 {
+			continue
+		}
+		b.offerFreedRoom(ctx, entry.HotelID, entry.OfferedRoomID, entry.StartDate, entry.EndDate)
+	}
+	span.SetAttributes(attribute.Int("waitlist.expired", len(expired)))
+	return len(expired), nil
+}
+
+// offerFreedRoom предлагает освободившуюся на период комнату первому гостю из очереди, для которого она свободна.
+// Ошибки только логируются: освобождение комнаты уже произошло и не должно от них откатываться.
+func (b *BookingServiceImpl) offerFreedRoom(ctx context.Context, hotelID, roomID int, startDate, endDate time.Time) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.offerFreedRoom")
+	defer span.End()
+	log := b.log.With(zap.String("Layer", "service: offerFreedRoom"), zap.Int("hotel id", hotelID), zap.Int("room id", roomID))
+
+	details, err := b.getRoomDetails(ctx, hotelID, roomID)
+	if err != nil {
+		span.RecordError(err)
+		log.Error("failed to get room details", zap.Error(err))
+		return
+	}
+	candidates, err := b.storage.GetWaitlistCandidates(ctx, hotelID, int(details.RoomType.Id), startDate, endDate, waitlistCandidatesLimit)
+	if err != nil {
+		span.RecordError(err)
+		log.Error("failed to get waitlist candidates", zap.Error(err))
+		return
+	}
+
+	for _, candidate := range candidates {
+		// Состав гостей в листе ожидания не хранится, поэтому все гости проверяются как взрослые
+		if !roomCapacity(details.Room).Fits(models.Guests{Adults: candidate.CountOfPeople}) {
+			continue
+		}
+		unavailable, err := b.storage.GetUnavailableRoomsByHotelId(ctx, hotelID, candidate.StartDate, candidate.EndDate)
+		if err != nil {
+			span.RecordError(err)
+			log.Error("failed to check room availability", zap.Error(err))
+			return
+		}
+		if _, busy := unavailable[roomID]; busy {
+			continue
+		}
+
+		token, err := newClaimToken()
+		if err != nil {
+			span.RecordError(err)
+			log.Error("failed to generate claim token", zap.Error(err))
+			return
+		}
+		expiresAt := time.Now().Add(b.waitlistOfferTTL)
+		message := &models.BookingMessage{
+			Event:           models.EventWaitlistOffer,
+			HotelID:         hotelID,
+			HotelName:       details.HotelName,
+			RoomDescription: details.Room.Description,
+			RoomNumber:      int(details.Room.Number),
+			Username:        candidate.Username,
+			ChatID:          candidate.ChatID,
+			StartDate:       candidate.StartDate.Format(messageDateLayout),
+			EndDate:         candidate.EndDate.Format(messageDateLayout),
+			ClaimURL:        b.waitlistClaimURL + url.QueryEscape(token),
+			ClaimExpiresAt:  expiresAt.Format(messageDateLayout),
+		}
+		payload, err := json.Marshal(message)
+		if err != nil {
+			span.RecordError(err)
+			log.Error("error in Marshal KafkaUserMessage", zap.Error(err))
+			return
+		}
+		outbox := []*models.OutboxMessage{{Recipient: models.RecipientUser, Payload: payload}}
+
+		err = b.storage.OfferWaitlistEntry(ctx, candidate.ID, roomID, token, expiresAt, outbox)
+		if errors.Is(err, myerror.ErrWaitlistOfferUnavailable) {
+			// Запись успели изменить параллельно - пробуем следующего гостя
+			continue
+		}
+		if err != nil {
+			span.RecordError(err)
+			log.Error("failed to offer waitlist entry", zap.Error(err))
+			return
+		}
+		log.Info("freed room offered to waitlisted guest", zap.Int("entry id", candidate.ID))
+		span.AddEvent("waitlist_offer_sent")
+		return
+	}
+}
+
+func newClaimToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"strconv"
+	"time"
+)
+
+// acceptPaymentWebhook проверяет подпись и время вебхука и запоминает подпись, чтобы не принять его повторно.
+// Если вебхук затем не удалось обработать, подпись удаляется в releasePaymentWebhook.
+func (b *BookingServiceImpl) acceptPaymentWebhook(ctx context.Context, webhook *models.PaymentWebhook) error {
+	if (webhook.BookingID == 0) == (webhook.GroupID == 0) {
+		return fmt.Errorf("webhook must name exactly one order: %w", myerror.ErrInvalidWebhook)
+	}
+	if webhook.ModificationID != 0 && webhook.BookingID == 0 {
+		return fmt.Errorf("booking modification webhook without booking: %w", myerror.ErrInvalidWebhook)
+	}
+	unix, err := strconv.ParseInt(webhook.Timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("webhook timestamp %q: %w", webhook.Timestamp, myerror.ErrInvalidWebhook)
+	}
+	signature, err := hex.DecodeString(webhook.Signature)
+	if err != nil || !hmac.Equal(signature, b.webhookSignature(webhook)) {
+		return myerror.ErrInvalidWebhook
+	}
+	signedAt := time.Unix(unix, 0)
+	if skew := time.Since(signedAt); skew > b.webhookTolerance || skew < -b.webhookTolerance {
+		return fmt.Errorf("webhook signed at %s: %w", signedAt.UTC().Format(time.RFC3339), myerror.ErrInvalidWebhook)
+	}
+	return b.storage.SavePaymentWebhook(ctx, webhook.Signature, signedAt)
+}
+
+// releasePaymentWebhook забывает подпись вебхука, обработка которого не удалась.
+// Ошибка удаления только логируется: вызывающему важнее исходная ошибка обработки.
+func (b *BookingServiceImpl) releasePaymentWebhook(ctx context.Context, webhook *models.PaymentWebhook) {
+	if err := b.storage.DeletePaymentWebhook(ctx, webhook.Signature); err != nil {
+		b.log.Error("error in service releasePaymentWebhook", zap.Error(err))
+	}
+}
+
+// webhookSignature - HMAC-SHA256 от "время.путь?запрос.тело", так же подписывает PaymentSystem
+func (b *BookingServiceImpl) webhookSignature(webhook *models.PaymentWebhook) []byte {
+	mac := hmac.New(sha256.New, b.webhookSecret)
+	mac.Write([]byte(webhook.Timestamp + "." + webhook.RequestURI + "."))
+	mac.Write(webhook.Body)
+	return mac.Sum(nil)
+}
+
+// ReleasePaymentWebhooks удаляет подписи вебхуков, которые уже не пройдут проверку времени
+func (b *BookingServiceImpl) ReleasePaymentWebhooks(ctx context.Context) (int, error) {
+	ctx, span := b.tracer.Start(ctx, "BookingService.ReleasePaymentWebhooks")
+	defer span.End()
+
+	released, err := b.storage.ReleasePaymentWebhooks(ctx, time.Now().Add(-b.webhookTolerance))
+	if err != nil {
+		span.RecordError(err)
+		b.log.Error("error in service ReleasePaymentWebhooks", zap.Error(err))
+		return 0, fmt.Errorf("error in service ReleasePaymentWebhooks: %w", err)
+	}
+	span.SetAttributes(attribute.Int("payment_webhooks.released", released))
+	return released, nil
+}
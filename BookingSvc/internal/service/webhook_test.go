@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// signedWebhook - вебхук об успешной оплате бронирования 5 с верной подписью
+func (ts *testService) signedWebhook() *models.PaymentWebhook {
+	webhook := &models.PaymentWebhook{
+		Status:     models.PaymentSuccess,
+		BookingID:  5,
+		Timestamp:  strconv.FormatInt(time.Now().Unix(), 10),
+		RequestURI: "/bookings/payment/response?booking_id=5",
+		Body:       []byte(`{"status":"success"}`),
+	}
+	webhook.Signature = hex.EncodeToString(ts.service.webhookSignature(webhook))
+	return webhook
+}
+
+func TestUpdateBookingStatus_FailedApplyReleasesSignature(t *testing.T) {
+	ts := newTestService(t)
+	webhook := ts.signedWebhook()
+	dbErr := errors.New("connection reset")
+
+	// Статус не сохранился, поэтому подпись забывается и повтор вебхука не считается replay
+	ts.storage.EXPECT().SavePaymentWebhook(gomock.Any(), webhook.Signature, gomock.Any()).Return(nil)
+	ts.storage.EXPECT().GetBookingByID(gomock.Any(), 5).Return(nil, dbErr)
+	ts.storage.EXPECT().DeletePaymentWebhook(gomock.Any(), webhook.Signature).Return(nil)
+
+	err := ts.service.UpdateBookingStatus(context.Background(), webhook)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, dbErr)
+}
+
+func TestUpdateBookingStatus_AppliedKeepsSignature(t *testing.T) {
+	ts := newTestService(t)
+	webhook := ts.signedWebhook()
+	booking := confirmedBooking()
+	booking.Status = models.StatusPendingPayment
+
+	ts.storage.EXPECT().SavePaymentWebhook(gomock.Any(), webhook.Signature, gomock.Any()).Return(nil)
+	ts.storage.EXPECT().GetBookingByID(gomock.Any(), 5).Return(booking, nil)
+	ts.storage.EXPECT().UpdateBookingStatus(gomock.Any(), gomock.Any(), 5, gomock.Any()).Return(nil)
+
+	require.NoError(t, ts.service.UpdateBookingStatus(context.Background(), webhook))
+}
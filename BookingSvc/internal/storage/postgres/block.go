@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// CreateRoomBlock снимает комнату с продажи. Комнату, занятую активным бронированием, заблокировать нельзя:
+// myerror.ErrRoomBlockConflict. Транзакция сериализуемая, как и создание бронирования, которое проверяет блокировки.
+func (r *Repository) CreateRoomBlock(ctx context.Context, block *models.RoomBlock, userID int) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.CreateRoomBlock")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Create room block", status, duration)
+	}()
+	bookedQuery := `
+		SELECT EXISTS (
+			SELECT 1 FROM bookings
+			WHERE RoomID = $1 AND Status = ANY($4) AND Period && tstzrange($2, $3, '[)')
+		)
+	`
+	insertQuery := `
+		INSERT INTO room_blocks (HotelID, RoomID, StartDate, EndDate, Source, Reason, CreatedBy)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING ID, CreatedAt
+	`
+	err := r.inTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+		var booked bool
+		err := tx.QueryRow(ctx, bookedQuery, block.RoomID, block.StartDate, block.EndDate, models.ActiveStatuses).Scan(&booked)
+		if err != nil {
+			return fmt.Errorf("failed to check room bookings: %w", err)
+		}
+		if booked {
+			return myerror.ErrRoomBlockConflict
+		}
+		return tx.QueryRow(ctx, insertQuery, block.HotelID, block.RoomID, block.StartDate, block.EndDate, block.Source,
+			block.Reason, userID).Scan(&block.ID, &block.CreatedAt)
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage CreateRoomBlock: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetRoomBlock(ctx context.Context, blockID int64) (*models.RoomBlock, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetRoomBlock")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get room block", status, duration)
+	}()
+	blocks, err := queryRoomBlocks(ctx, r.db, `SELECT `+roomBlockColumns+` FROM room_blocks WHERE ID = $1`, blockID)
+	if err == nil && len(blocks) == 0 {
+		err = myerror.ErrRoomBlockNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage GetRoomBlock: %w", err)
+	}
+	return blocks[0], nil
+}
+
+// GetRoomBlocks возвращает блокировки комнат отеля, пересекающиеся с периодом [from, to).
+// nil в границе - неограниченный период, нулевой roomID - все комнаты.
+func (r *Repository) GetRoomBlocks(ctx context.Context, hotelID, roomID int, from, to *time.Time) ([]*models.RoomBlock, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetRoomBlocks")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get room blocks", status, duration)
+	}()
+	query := `
+		SELECT ` + roomBlockColumns + `
+		FROM room_blocks
+		WHERE HotelID = $1
+		AND ($2 = 0 OR RoomID = $2)
+		AND Period && tstzrange($3::timestamptz, $4::timestamptz, '[)')
+		ORDER BY StartDate, ID
+	`
+	blocks, err := queryRoomBlocks(ctx, r.db, query, hotelID, roomID, from, to)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage GetRoomBlocks: %w", err)
+	}
+	return blocks, nil
+}
+
+// DeleteRoomBlock возвращает комнату в продажу. Блокировки импорта iCal удаляются только вместе с подпиской.
+func (r *Repository) DeleteRoomBlock(ctx context.Context, blockID int64) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.DeleteRoomBlock")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Delete room block", status, duration)
+	}()
+	tag, err := r.db.Exec(ctx, `DELETE FROM room_blocks WHERE ID = $1 AND ImportID IS NULL`, blockID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = myerror.ErrRoomBlockNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage DeleteRoomBlock: %w", err)
+	}
+	return nil
+}
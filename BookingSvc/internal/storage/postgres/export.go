@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// Сколько бронирований выгрузки читается из курсора за раз
+const exportFetchSize = 500
+
+// StreamBookingsByHotelID передаёт в fn по одному все бронирования отеля по фильтру.
+// Бронирования читаются серверным курсором порциями по exportFetchSize, поэтому в памяти
+// не бывает больше одной порции. Транзакция только для чтения видит один снимок на всю выгрузку.
+// Ошибка fn прерывает чтение и возвращается как есть.
+func (r *Repository) StreamBookingsByHotelID(ctx context.Context, hotelID int, filter *models.BookingFilter, fn func(*models.Booking) error) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.StreamBookingsByHotelID")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Stream bookings by hotel", status, duration)
+	}()
+
+	query, args := bookingListQuery("HotelID", hotelID, filter)
+	// Без повторов inTx: часть бронирований уже могла уйти в fn
+	txOptions := pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}
+	err := r.runTx(ctx, txOptions, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DECLARE booking_export NO SCROLL CURSOR FOR `+query, args...); err != nil {
+			return fmt.Errorf("failed to declare cursor: %w", err)
+		}
+		fetch := fmt.Sprintf("FETCH %d FROM booking_export", exportFetchSize)
+		for {
+			bookings, err := queryBookings(ctx, tx, fetch)
+			if err != nil {
+				return fmt.Errorf("failed to fetch bookings: %w", err)
+			}
+			for _, booking := range bookings {
+				if err = fn(booking); err != nil {
+					return err
+				}
+			}
+			if len(bookings) < exportFetchSize {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage StreamBookingsByHotelID: %w", err)
+	}
+	return nil
+}
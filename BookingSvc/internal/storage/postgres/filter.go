@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"strings"
+)
+
+// bookingListQuery строит запрос страницы бронирований, где ownerColumn = ownerID (UserID или HotelID).
+// Запрашивается на одну запись больше Limit, чтобы понять, есть ли следующая страница.
+// С нулевым Limit запрос возвращает все подходящие бронирования.
+func bookingListQuery(ownerColumn string, ownerID int, filter *models.BookingFilter) (string, []interface{}) {
+	args := []interface{}{ownerID}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	conditions := []string{ownerColumn + " = $1"}
+
+	if len(filter.Statuses) > 0 {
+		conditions = append(conditions, "Status = ANY("+arg(filter.Statuses)+")")
+	}
+	if filter.RoomID > 0 {
+		conditions = append(conditions, "RoomID = "+arg(filter.RoomID))
+	}
+	switch filter.DateFilter {
+	case models.DateFilterArrivals, models.DateFilterDepartures:
+		column := "StartDate"
+		if filter.DateFilter == models.DateFilterDepartures {
+			column = "EndDate"
+		}
+		if filter.From != nil {
+			conditions = append(conditions, column+" >= "+arg(*filter.From))
+		}
+		if filter.To != nil {
+			conditions = append(conditions, column+" < "+arg(*filter.To))
+		}
+	case models.DateFilterInHouse:
+		conditions = append(conditions, "StartDate <= "+arg(*filter.From))
+		if filter.To != nil {
+			conditions = append(conditions, "EndDate >= "+arg(*filter.To))
+		} else {
+			conditions = append(conditions, "EndDate > "+arg(*filter.From))
+		}
+	default:
+		if filter.From != nil || filter.To != nil {
+			// NULL в границе tstzrange - неограниченный период
+			conditions = append(conditions,
+				"Period && tstzrange("+arg(filter.From)+"::timestamptz, "+arg(filter.To)+"::timestamptz, '[)')")
+		}
+	}
+
+	column, direction, compare := "CreatedAt", "DESC", "<"
+	switch filter.Sort {
+	case models.SortCreatedAsc:
+		direction, compare = "ASC", ">"
+	case models.SortStartAsc:
+		column, direction, compare = "StartDate", "ASC", ">"
+	case models.SortStartDesc:
+		column = "StartDate"
+	}
+	if filter.Cursor != nil {
+		conditions = append(conditions,
+			fmt.Sprintf("(%s, ID) %s (%s, %s)", column, compare, arg(filter.Cursor.At), arg(filter.Cursor.ID)))
+	}
+
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY ` + column + ` ` + direction + `, ID ` + direction
+	if filter.Limit > 0 {
+		query += `
+		LIMIT ` + arg(filter.Limit+1)
+	}
+	return query, args
+}
@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// GetStatusHistory возвращает историю статусов бронирований bookingIDs в порядке переходов
+func (r *Repository) GetStatusHistory(ctx context.Context, bookingIDs []int) (map[int][]*models.StatusChange, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetStatusHistory")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get booking status history", status, duration)
+	}()
+
+	query := `
+		SELECT BookingID, COALESCE(FromStatus, ''), ToStatus, Actor, COALESCE(ActorID, 0), Reason, CreatedAt
+		FROM booking_status_history
+		WHERE BookingID = ANY($1)
+		ORDER BY BookingID, CreatedAt, ID
+	`
+	rows, err := r.db.Query(ctx, query, bookingIDs)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("failed to query status history: %w", err)
+	}
+	defer rows.Close()
+
+	history := make(map[int][]*models.StatusChange, len(bookingIDs))
+	for rows.Next() {
+		var bookingID int
+		change := &models.StatusChange{}
+		if err = rows.Scan(&bookingID, &change.From, &change.Status, &change.Actor, &change.ActorID, &change.Reason, &change.At); err != nil {
+			span.RecordError(err)
+			status = "failed"
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		history[bookingID] = append(history[bookingID], change)
+	}
+	if err = rows.Err(); err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("rows iteration myerror: %w", err)
+	}
+	return history, nil
+}
+
+// insertStatusHistory записывает переход change бронирований bookingIDs в транзакции tx
+func insertStatusHistory(ctx context.Context, tx pgx.Tx, change *models.StatusTransition, bookingIDs ...int) error {
+	if len(bookingIDs) == 0 {
+		return nil
+	}
+	query := `
+		INSERT INTO booking_status_history (BookingID, FromStatus, ToStatus, Actor, ActorID, Reason)
+		SELECT ID, NULLIF($2, ''), $3, $4, NULLIF($5::int, 0), $6
+		FROM unnest($1::int[]) AS ID
+	`
+	_, err := tx.Exec(ctx, query, bookingIDs, change.From, change.To, change.Actor, change.ActorID, change.Reason)
+	if err != nil {
+		return fmt.Errorf("failed to insert status history: %w", err)
+	}
+	return nil
+}
+
+// queryIDs выполняет запрос, возвращающий ID изменённых записей
+func queryIDs(ctx context.Context, db querier, query string, args ...interface{}) ([]int, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,362 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// icalImportColumns - колонки для scanICalImport
+const icalImportColumns = `ID, HotelID, RoomID, URL, CreatedAt, LastSyncedAt, LastError`
+
+// roomBlockColumns - колонки для queryRoomBlocks
+const roomBlockColumns = `ID, HotelID, RoomID, StartDate, EndDate, Source, COALESCE(ImportID, 0), ExternalUID, Summary,
+		Reason, CreatedAt`
+
+func (r *Repository) CreateICalFeed(ctx context.Context, feed *models.ICalFeed, userID int) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.CreateICalFeed")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Create ical feed", status, duration)
+	}()
+	query := `
+		INSERT INTO ical_feeds (Token, HotelID, RoomID, CreatedBy)
+		VALUES ($1, $2, NULLIF($3, 0), $4)
+		RETURNING CreatedAt
+	`
+	err := r.db.QueryRow(ctx, query, feed.Token, feed.HotelID, feed.RoomID, userID).Scan(&feed.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage CreateICalFeed: %w", err)
+	}
+	return nil
+}
+
+// GetICalFeed возвращает выгрузку по токену ссылки, неизвестный токен - ErrICalFeedNotFound
+func (r *Repository) GetICalFeed(ctx context.Context, token string) (*models.ICalFeed, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetICalFeed")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get ical feed", status, duration)
+	}()
+	query := `
+		SELECT Token, HotelID, COALESCE(RoomID, 0), CreatedAt
+		FROM ical_feeds
+		WHERE Token = $1
+	`
+	var feed models.ICalFeed
+	err := r.db.QueryRow(ctx, query, token).Scan(&feed.Token, &feed.HotelID, &feed.RoomID, &feed.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("in storage GetICalFeed: %w", myerror.ErrICalFeedNotFound)
+		}
+		return nil, fmt.Errorf("in storage GetICalFeed: %w", err)
+	}
+	return &feed, nil
+}
+
+func (r *Repository) DeleteICalFeed(ctx context.Context, token string) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.DeleteICalFeed")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Delete ical feed", status, duration)
+	}()
+	tag, err := r.db.Exec(ctx, `DELETE FROM ical_feeds WHERE Token = $1`, token)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = myerror.ErrICalFeedNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage DeleteICalFeed: %w", err)
+	}
+	return nil
+}
+
+// CreateICalImport подписывает комнату на календарь. Повторная подписка на тот же URL - ErrICalImportExists.
+func (r *Repository) CreateICalImport(ctx context.Context, icalImport *models.ICalImport, userID int) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.CreateICalImport")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Create ical import", status, duration)
+	}()
+	query := `
+		INSERT INTO ical_imports (HotelID, RoomID, URL, CreatedBy)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ID, CreatedAt
+	`
+	err := r.db.QueryRow(ctx, query, icalImport.HotelID, icalImport.RoomID, icalImport.URL, userID).
+		Scan(&icalImport.ID, &icalImport.CreatedAt)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return fmt.Errorf("in storage CreateICalImport: %w", myerror.ErrICalImportExists)
+		}
+		return fmt.Errorf("in storage CreateICalImport: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetICalImport(ctx context.Context, importID int) (*models.ICalImport, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetICalImport")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get ical import", status, duration)
+	}()
+	query := `
+		SELECT ` + icalImportColumns + `
+		FROM ical_imports
+		WHERE ID = $1
+	`
+	icalImport, err := scanICalImport(r.db.QueryRow(ctx, query, importID))
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("in storage GetICalImport: %w", myerror.ErrICalImportNotFound)
+		}
+		return nil, fmt.Errorf("in storage GetICalImport: %w", err)
+	}
+	return icalImport, nil
+}
+
+// GetICalImports возвращает подписки отеля, а при hotelID 0 - все подписки, давно не синхронизированные первыми
+func (r *Repository) GetICalImports(ctx context.Context, hotelID int) ([]*models.ICalImport, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetICalImports")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get ical imports", status, duration)
+	}()
+	query := `
+		SELECT ` + icalImportColumns + `
+		FROM ical_imports
+		WHERE $1 = 0 OR HotelID = $1
+		ORDER BY LastSyncedAt NULLS FIRST, ID
+	`
+	rows, err := r.db.Query(ctx, query, hotelID)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage GetICalImports: %w", err)
+	}
+	defer rows.Close()
+
+	imports := make([]*models.ICalImport, 0)
+	for rows.Next() {
+		icalImport, err := scanICalImport(rows)
+		if err != nil {
+			span.RecordError(err)
+			status = "failed"
+			return nil, fmt.Errorf("failed to scan ical import: %w", err)
+		}
+		imports = append(imports, icalImport)
+	}
+	if err = rows.Err(); err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("rows iteration myerror: %w", err)
+	}
+	return imports, nil
+}
+
+// DeleteICalImport удаляет подписку вместе с её блокировками
+func (r *Repository) DeleteICalImport(ctx context.Context, importID int) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.DeleteICalImport")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Delete ical import", status, duration)
+	}()
+	tag, err := r.db.Exec(ctx, `DELETE FROM ical_imports WHERE ID = $1`, importID)
+	if err == nil && tag.RowsAffected() == 0 {
+		err = myerror.ErrICalImportNotFound
+	}
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage DeleteICalImport: %w", err)
+	}
+	return nil
+}
+
+// ReplaceImportedBlocks заменяет блокировки подписки importID новыми и отмечает успешную синхронизацию.
+// Транзакция сериализуемая, чтобы не разойтись с параллельным бронированием той же комнаты.
+func (r *Repository) ReplaceImportedBlocks(ctx context.Context, importID int, blocks []*models.RoomBlock) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.ReplaceImportedBlocks")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Replace imported blocks", status, duration)
+	}()
+	insertQuery := `
+		INSERT INTO room_blocks (HotelID, RoomID, StartDate, EndDate, Source, ImportID, ExternalUID, Summary)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`
+	err := r.inTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `UPDATE ical_imports SET LastSyncedAt = NOW(), LastError = '' WHERE ID = $1`, importID)
+		if err != nil {
+			return fmt.Errorf("failed to update ical import: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return myerror.ErrICalImportNotFound
+		}
+		if _, err = tx.Exec(ctx, `DELETE FROM room_blocks WHERE ImportID = $1`, importID); err != nil {
+			return fmt.Errorf("failed to delete imported blocks: %w", err)
+		}
+		for _, block := range blocks {
+			_, err = tx.Exec(ctx, insertQuery, block.HotelID, block.RoomID, block.StartDate, block.EndDate, block.Source,
+				importID, block.ExternalUID, block.Summary)
+			if err != nil {
+				return fmt.Errorf("failed to insert room block: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage ReplaceImportedBlocks: %w", err)
+	}
+	return nil
+}
+
+// MarkICalImportFailed сохраняет причину неудачной синхронизации, прежние блокировки остаются
+func (r *Repository) MarkICalImportFailed(ctx context.Context, importID int, reason string) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.MarkICalImportFailed")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Mark ical import failed", status, duration)
+	}()
+	_, err := r.db.Exec(ctx, `UPDATE ical_imports SET LastSyncedAt = NOW(), LastError = $2 WHERE ID = $1`, importID, reason)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage MarkICalImportFailed: %w", err)
+	}
+	return nil
+}
+
+// GetRoomBlocksByHotelPeriod возвращает блокировки комнат отеля, пересекающиеся с периодом [startDate, endDate)
+func (r *Repository) GetRoomBlocksByHotelPeriod(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*models.RoomBlock, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetRoomBlocksByHotelPeriod")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get hotel room blocks by period", status, duration)
+	}()
+	query := `
+		SELECT ` + roomBlockColumns + `
+		FROM room_blocks
+		WHERE HotelID = $1
+		AND Period && tstzrange($2, $3, '[)')
+		ORDER BY RoomID, StartDate
+	`
+	blocks, err := queryRoomBlocks(ctx, r.db, query, hotelID, startDate, endDate)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("failed to query room blocks: %w", err)
+	}
+	return blocks, nil
+}
+
+// checkRoomBlocks не даёт занять комнату на период, пересекающийся с её блокировкой.
+// Блокировка отвечает той же ошибкой, что и занятая бронированием комната.
+func checkRoomBlocks(ctx context.Context, tx pgx.Tx, roomID int, startDate, endDate time.Time) error {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM room_blocks
+			WHERE RoomID = $1 AND Period && tstzrange($2, $3, '[)')
+		)
+	`
+	var blocked bool
+	if err := tx.QueryRow(ctx, query, roomID, startDate, endDate).Scan(&blocked); err != nil {
+		return fmt.Errorf("failed to check room blocks: %w", err)
+	}
+	if blocked {
+		return myerror.ErrBookingAlreadyExists
+	}
+	return nil
+}
+
+// queryRoomBlocks выполняет запрос, возвращающий roomBlockColumns
+func queryRoomBlocks(ctx context.Context, db querier, query string, args ...interface{}) ([]*models.RoomBlock, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocks := make([]*models.RoomBlock, 0)
+	for rows.Next() {
+		var block models.RoomBlock
+		err = rows.Scan(&block.ID, &block.HotelID, &block.RoomID, &block.StartDate, &block.EndDate, &block.Source,
+			&block.ImportID, &block.ExternalUID, &block.Summary, &block.Reason, &block.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan room block: %w", err)
+		}
+		blocks = append(blocks, &block)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration myerror: %w", err)
+	}
+	return blocks, nil
+}
+
+func scanICalImport(row pgx.Row) (*models.ICalImport, error) {
+	var icalImport models.ICalImport
+	err := row.Scan(&icalImport.ID, &icalImport.HotelID, &icalImport.RoomID, &icalImport.URL, &icalImport.CreatedAt,
+		&icalImport.LastSyncedAt, &icalImport.LastError)
+	if err != nil {
+		return nil, err
+	}
+	return &icalImport, nil
+}
@@ -0,0 +1,223 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+const modificationColumns = `ID, BookingID, Status, RoomID, RoomDescription, RoomNumber, CountOfPeople, Children, StartDate, EndDate,
+		Amount, Surcharge, PriceBreakdown, COALESCE(PromoCodeID, 0), HoldExpiresAt, CreatedAt`
+
+// CreateBookingModification сохраняет изменение, ожидающее доплаты. Бронирование должно быть подтверждено
+// и не ждать другой доплаты, иначе myerror.ErrInvalidBookingStatus. Ожидание с истёкшим сроком закрывается.
+func (r *Repository) CreateBookingModification(ctx context.Context, modification *models.BookingModification) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.CreateBookingModification")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Create booking modification", status, duration)
+	}()
+	expireQuery := `
+		UPDATE booking_modifications
+		SET Status = $2, ResolvedAt = NOW()
+		WHERE BookingID = $1 AND Status = $3 AND HoldExpiresAt < NOW()
+	`
+	insertQuery := `
+		INSERT INTO booking_modifications (BookingID, Status, RoomID, RoomDescription, RoomNumber, CountOfPeople,
+			Children, StartDate, EndDate, Amount, Surcharge, PriceBreakdown, PromoCodeID, HoldExpiresAt)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, 0), $14)
+		RETURNING ID, CreatedAt
+	`
+	priceBreakdown, err := marshalPrice(modification.Price)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage CreateBookingModification: %w", err)
+	}
+	err = r.inTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		// Блокировка строки бронирования упорядочивает изменение с отменой и другими изменениями
+		var bookingStatus string
+		err := tx.QueryRow(ctx, `SELECT Status FROM bookings WHERE ID = $1 FOR UPDATE`, modification.BookingID).Scan(&bookingStatus)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return myerror.ErrBookingNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to lock booking: %w", err)
+		}
+		if bookingStatus != models.StatusConfirmed {
+			return myerror.ErrInvalidBookingStatus
+		}
+		if _, err = tx.Exec(ctx, expireQuery, modification.BookingID, models.ModificationExpired, models.ModificationPending); err != nil {
+			return fmt.Errorf("failed to expire booking modifications: %w", err)
+		}
+		err = tx.QueryRow(ctx, insertQuery, modification.BookingID, modification.Status, modification.RoomID,
+			modification.RoomDescription, modification.RoomNumber, modification.CountOfPeople, modification.Children,
+			modification.StartDate, modification.EndDate, modification.Amount, modification.Surcharge, priceBreakdown,
+			modification.PromoCodeID, modification.HoldExpiresAt).Scan(&modification.ID, &modification.CreatedAt)
+		if err != nil {
+			return constraintError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage CreateBookingModification: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetBookingModification(ctx context.Context, modificationID int) (*models.BookingModification, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetBookingModification")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get booking modification", status, duration)
+	}()
+	query := `
+		SELECT ` + modificationColumns + `
+		FROM booking_modifications
+		WHERE ID = $1
+	`
+	modification, err := scanModification(r.db.QueryRow(ctx, query, modificationID))
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("in storage GetBookingModification: %w", myerror.ErrModificationNotFound)
+		}
+		return nil, fmt.Errorf("failed to get booking modification: %w", err)
+	}
+	return modification, nil
+}
+
+// ApplyBookingModification переносит новые условия в бронирование и закрывает ожидание доплаты.
+// booking - бронирование с уже применёнными условиями изменения. Если изменение уже закрыто,
+// бронирование не в статусе change.From или комната занята, ничего не сохраняется.
+func (r *Repository) ApplyBookingModification(ctx context.Context, modificationID int, booking *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.ApplyBookingModification")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Apply booking modification", status, duration)
+	}()
+	err := r.inTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+		if err := resolveModification(ctx, tx, modificationID, models.ModificationPending, models.ModificationApplied); err != nil {
+			return err
+		}
+		if err := updateBooking(ctx, tx, booking, change); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, outbox)
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage ApplyBookingModification: %w", err)
+	}
+	return nil
+}
+
+// ResolveBookingModification закрывает изменение в статусе from без изменения бронирования.
+// События outbox, например возврат доплаты, сохраняются в той же транзакции.
+func (r *Repository) ResolveBookingModification(ctx context.Context, modificationID int, from, to string, outbox []*models.OutboxMessage) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.ResolveBookingModification")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Resolve booking modification", status, duration)
+	}()
+	err := r.inTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		if err := resolveModification(ctx, tx, modificationID, from, to); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, outbox)
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage ResolveBookingModification: %w", err)
+	}
+	return nil
+}
+
+// ExpireBookingModifications закрывает изменения, доплата за которые не пришла до конца срока ожидания.
+// Бронирования остаются подтверждёнными на прежних условиях, поэтому возвращать нечего.
+func (r *Repository) ExpireBookingModifications(ctx context.Context) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.ExpireBookingModifications")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Expire booking modifications", status, duration)
+	}()
+	query := `
+		UPDATE booking_modifications
+		SET Status = $1, ResolvedAt = NOW()
+		WHERE Status = $2 AND HoldExpiresAt < NOW()
+	`
+	tag, err := r.db.Exec(ctx, query, models.ModificationExpired, models.ModificationPending)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return 0, fmt.Errorf("in storage ExpireBookingModifications: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// resolveModification переводит изменение из from в to. Изменение уже в другом статусе - myerror.ErrInvalidBookingStatus,
+// поэтому повторный вебхук не применит изменение и не вернёт доплату второй раз.
+func resolveModification(ctx context.Context, tx pgx.Tx, modificationID int, from, to string) error {
+	query := `
+		UPDATE booking_modifications
+		SET Status = $1, ResolvedAt = NOW()
+		WHERE ID = $2 AND Status = $3
+	`
+	tag, err := tx.Exec(ctx, query, to, modificationID, from)
+	if err != nil {
+		return fmt.Errorf("failed to resolve booking modification: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return myerror.ErrInvalidBookingStatus
+	}
+	return nil
+}
+
+func scanModification(row pgx.Row) (*models.BookingModification, error) {
+	var modification models.BookingModification
+	var priceBreakdown []byte
+	err := row.Scan(&modification.ID, &modification.BookingID, &modification.Status, &modification.RoomID,
+		&modification.RoomDescription, &modification.RoomNumber, &modification.CountOfPeople, &modification.Children,
+		&modification.StartDate, &modification.EndDate, &modification.Amount, &modification.Surcharge, &priceBreakdown,
+		&modification.PromoCodeID, &modification.HoldExpiresAt, &modification.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if len(priceBreakdown) > 0 {
+		if err = json.Unmarshal(priceBreakdown, &modification.Price); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal price breakdown: %w", err)
+		}
+	}
+	return &modification, nil
+}
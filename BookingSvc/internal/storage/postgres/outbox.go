@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"github.com/jackc/pgx/v4"
+	"sort"
+	"time"
+)
+
+const (
+	// Максимальная пауза между попытками отправки - 12 * 10 секунд
+	outboxMaxBackoffSteps = 12
+	// После стольких неудачных попыток сообщение откладывается в dead letter и больше не отправляется
+	outboxMaxAttempts = 50
+	// На это время выбранные сообщения скрыты от других экземпляров сервиса
+	outboxClaimTimeout = time.Minute
+)
+
+// GetPendingOutbox выбирает сообщения, готовые к отправке, и откладывает их следующую попытку на outboxClaimTimeout.
+// Строки, уже захваченные другим экземпляром сервиса, пропускаются, поэтому одно сообщение не отправляется параллельно.
+func (r *Repository) GetPendingOutbox(ctx context.Context, limit int) ([]*models.OutboxMessage, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetPendingOutbox")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get pending outbox", status, duration)
+	}()
+	query := `
+		UPDATE booking_outbox
+		SET NextAttemptAt = NOW() + $2 * INTERVAL '1 second'
+		WHERE ID IN (
+			SELECT ID FROM booking_outbox
+			WHERE SentAt IS NULL AND DeadAt IS NULL AND NextAttemptAt <= NOW()
+			ORDER BY ID
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ID, Recipient, Payload, Attempts
+	`
+	rows, err := r.db.Query(ctx, query, limit, outboxClaimTimeout.Seconds())
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("failed to query outbox: %w", err)
+	}
+	defer rows.Close()
+
+	messages := make([]*models.OutboxMessage, 0)
+	for rows.Next() {
+		var message models.OutboxMessage
+		if err = rows.Scan(&message.ID, &message.Recipient, &message.Payload, &message.Attempts); err != nil {
+			span.RecordError(err)
+			status = "failed"
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		messages = append(messages, &message)
+	}
+	if err = rows.Err(); err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("rows iteration myerror: %w", err)
+	}
+	// RETURNING не сохраняет порядок подзапроса
+	sort.Slice(messages, func(i, j int) bool { return messages[i].ID < messages[j].ID })
+	return messages, nil
+}
+
+func (r *Repository) MarkOutboxSent(ctx context.Context, messageID int64) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.MarkOutboxSent")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Mark outbox sent", status, duration)
+	}()
+	query := `
+		UPDATE booking_outbox
+		SET SentAt = NOW(), Attempts = Attempts + 1, LastError = NULL
+		WHERE ID = $1
+	`
+	if _, err := r.db.Exec(ctx, query, messageID); err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("failed to mark outbox message sent: %w", err)
+	}
+	return nil
+}
+
+// MarkOutboxFailed откладывает следующую попытку тем дольше, чем больше было неудачных.
+// После outboxMaxAttempts попыток сообщение больше не отправляется, тогда dead - true.
+func (r *Repository) MarkOutboxFailed(ctx context.Context, messageID int64, reason string) (bool, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.MarkOutboxFailed")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Mark outbox failed", status, duration)
+	}()
+	query := `
+		UPDATE booking_outbox
+		SET Attempts = Attempts + 1,
+			LastError = $2,
+			NextAttemptAt = NOW() + LEAST(Attempts + 1, $3) * INTERVAL '10 seconds',
+			DeadAt = CASE WHEN Attempts + 1 >= $4 THEN NOW() END
+		WHERE ID = $1
+		RETURNING DeadAt IS NOT NULL
+	`
+	var dead bool
+	err := r.db.QueryRow(ctx, query, messageID, reason, outboxMaxBackoffSteps, outboxMaxAttempts).Scan(&dead)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return false, fmt.Errorf("failed to mark outbox message failed: %w", err)
+	}
+	return dead, nil
+}
+
+func insertOutbox(ctx context.Context, tx pgx.Tx, messages []*models.OutboxMessage) error {
+	query := `
+		INSERT INTO booking_outbox (Recipient, Payload)
+		VALUES ($1, $2)
+	`
+	for _, message := range messages {
+		if _, err := tx.Exec(ctx, query, message.Recipient, message.Payload); err != nil {
+			return fmt.Errorf("failed to insert outbox message: %w", err)
+		}
+	}
+	return nil
+}
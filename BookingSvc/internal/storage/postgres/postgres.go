@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
 	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
@@ -12,6 +14,11 @@ import (
 	"time"
 )
 
+// bookingColumns - колонки для scanBooking
+const bookingColumns = `ID, UserID, RoomID, HotelID, Status, Amount, CountOfPeople, Children, HotelName, RoomDescription, RoomNumber,
+		Username, ChatID, StartDate, EndDate, CreatedAt, HoldExpiresAt, PriceBreakdown, COALESCE(PromoCodeID, 0), COALESCE(GroupID, 0),
+		UpdatedAt, CancelledAt, StatusChangedAt`
+
 type Repository struct {
 	db     *pgxpool.Pool
 	tracer trace.Tracer
@@ -24,7 +31,10 @@ func NewPostgresRepository(db *pgxpool.Pool, tracer trace.Tracer) *Repository {
 	}
 }
 
-func (r *Repository) CreateBooking(ctx context.Context, booking *models.BookingInfo) (int, error) {
+// CreateBooking сохраняет бронирование. Пересечение с активными бронированиями комнаты
+// отсекает ограничение bookings_room_period_excl. Если применён промокод promo, его лимиты проверяются
+// в той же сериализуемой транзакции, поэтому параллельные бронирования не превысят лимит.
+func (r *Repository) CreateBooking(ctx context.Context, booking *models.Booking, promo *models.PromoCode) (int, error) {
 	ctx, span := r.tracer.Start(ctx, "Repository.CreateBooking")
 	defer span.End()
 
@@ -35,73 +45,224 @@ func (r *Repository) CreateBooking(ctx context.Context, booking *models.BookingI
 		metrics.RecordDataBaseMetrics("Create booking", status, duration)
 	}()
 
-	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.Serializable, // Уровень изоляции SERIALIZABLE
+	var bookingID int
+	err := r.inTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+		if promo != nil {
+			if err := checkPromoLimits(ctx, tx, promo, booking.UserID); err != nil {
+				return err
+			}
+		}
+		var err error
+		bookingID, err = insertBooking(ctx, tx, booking)
+		return err
 	})
 	if err != nil {
-		span.RecordError(err)
 		status = "failed"
-		return -1, fmt.Errorf("failed to start transaction: %w", err)
+		span.RecordError(err)
+		if mapped := constraintError(err); mapped != err {
+			return -1, fmt.Errorf("in storage CreateBooking: %w", mapped)
+		}
+		if errors.Is(err, myerror.ErrPromoCodeExhausted) || errors.Is(err, myerror.ErrPromoCodeNotApplicable) {
+			return -1, fmt.Errorf("in storage CreateBooking: %w", err)
+		}
+		return -1, fmt.Errorf("failed to create booking: %w", err)
 	}
-	defer tx.Rollback(ctx)
+	return bookingID, nil
+}
+
+// CreateGroupBooking сохраняет группу и все её бронирования в одной транзакции:
+// если хотя бы одна комната занята, не создаётся ни одно бронирование.
+// ID группы и бронирований проставляются в group.
+func (r *Repository) CreateGroupBooking(ctx context.Context, group *models.GroupBooking) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.CreateGroupBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Create group booking", status, duration)
+	}()
 
 	query := `
-		SELECT RoomID
-		FROM bookings
-		where RoomID = $1
-		and ($2 >= StartDate and $2 < EndDate) or
-			($2 <= StartDate and $3 > StartDate);
+		INSERT INTO booking_groups (UserID, HotelID, Amount)
+		VALUES ($1, $2, $3)
+		RETURNING ID
 	`
-	rows, err := tx.Query(ctx, query, booking.RoomID, booking.StartDate, booking.EndDate)
+	var groupID int
+	bookingIDs := make([]int, len(group.Bookings))
+	err := r.inTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, query, group.UserID, group.HotelID, group.Amount).Scan(&groupID); err != nil {
+			return fmt.Errorf("failed to create booking group: %w", err)
+		}
+		for i, booking := range group.Bookings {
+			grouped := *booking
+			grouped.GroupID = groupID
+			bookingID, err := insertBooking(ctx, tx, &grouped)
+			if err != nil {
+				return err
+			}
+			bookingIDs[i] = bookingID
+		}
+		return nil
+	})
 	if err != nil {
+		status = "failed"
 		span.RecordError(err)
+		if mapped := constraintError(err); mapped != err {
+			return fmt.Errorf("in storage CreateGroupBooking: %w", mapped)
+		}
+		return fmt.Errorf("failed to create group booking: %w", err)
+	}
 
-		status = "failed"
-		return -1, fmt.Errorf("failed to query bookings: %w", err)
+	group.ID = groupID
+	for i, booking := range group.Bookings {
+		booking.ID = bookingIDs[i]
+		booking.GroupID = groupID
 	}
-	defer rows.Close()
+	return nil
+}
 
-	for rows.Next() {
-		var roomID int
-		if err := rows.Scan(&roomID); err != nil {
-			span.RecordError(err)
+// UpdateGroupStatus переводит бронирования группы в статусе change.From в change.To после общего платежа
+func (r *Repository) UpdateGroupStatus(ctx context.Context, change *models.StatusTransition, groupID int, outbox []*models.OutboxMessage) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.UpdateGroupStatus")
+	defer span.End()
 
-			return -1, fmt.Errorf("failed to scan room ID: %w", err)
-		}
-		if roomID == booking.RoomID {
-			span.RecordError(err)
+	start := time.Now()
+	statusMetrics := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Update group status", statusMetrics, duration)
+	}()
 
-			status = "failed"
-			return -1, fmt.Errorf("in storage CreateBooking: %w", myerror.ErrBookingAlreadyExists)
+	query := `
+		UPDATE bookings
+		SET Status = $1, UpdatedAt = NOW(), StatusChangedAt = NOW()
+		WHERE GroupID = $2 AND Status = $3
+		RETURNING ID
+	`
+	err := r.inTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		bookingIDs, err := queryIDs(ctx, tx, query, change.To, groupID, change.From)
+		if err != nil {
+			return fmt.Errorf("failed to update group status: %w", err)
+		}
+		if len(bookingIDs) == 0 {
+			return myerror.ErrInvalidBookingStatus
+		}
+		if err = insertStatusHistory(ctx, tx, change, bookingIDs...); err != nil {
+			return err
 		}
+		return insertOutbox(ctx, tx, outbox)
+	})
+	if err != nil {
+		span.RecordError(err)
+		statusMetrics = "failed"
+		return fmt.Errorf("in storage UpdateGroupStatus: %w", err)
 	}
+	return nil
+}
 
-	query = `
-        INSERT INTO bookings (UserID, RoomID, HotelID, StartDate, EndDate, CreatedAt)
-        VALUES ($1, $2, $3, $4, $5, NOW())
-		RETURNING id
+// GetBookingsByGroupID возвращает все бронирования группы
+func (r *Repository) GetBookingsByGroupID(ctx context.Context, groupID int) ([]*models.Booking, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetBookingsByGroupID")
+	defer span.End()
 
-    `
-	var bookingID int
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get bookings by group id", status, duration)
+	}()
 
-	err = tx.QueryRow(ctx, query, booking.UserID, booking.RoomID, booking.HotelID, booking.StartDate, booking.EndDate).Scan(&bookingID)
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE GroupID = $1
+		ORDER BY ID
+	`
+	bookings, err := queryBookings(ctx, r.db, query, groupID)
 	if err != nil {
-		status = "failed"
 		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage GetBookingsByGroupID: %w", err)
+	}
+	if len(bookings) == 0 {
+		return nil, fmt.Errorf("in storage GetBookingsByGroupID: %w", myerror.ErrBookingNotFound)
+	}
+	return bookings, nil
+}
 
-		return -1, fmt.Errorf("failed to create booking: %w", err)
+// insertBooking добавляет бронирование в транзакции tx вместе с первой записью истории статусов и возвращает его ID
+func insertBooking(ctx context.Context, tx pgx.Tx, booking *models.Booking) (int, error) {
+	query := `
+        INSERT INTO bookings (UserID, RoomID, HotelID, StartDate, EndDate, Amount, CountOfPeople, Children, HotelName, RoomDescription,
+                              RoomNumber, Username, ChatID, HoldExpiresAt, IdempotencyKey, PriceBreakdown, PromoCodeID, GroupID, CreatedAt)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, ''), $16, NULLIF($17, 0), NULLIF($18, 0), NOW())
+		RETURNING id
+    `
+	if err := checkRoomBlocks(ctx, tx, booking.RoomID, booking.StartDate, booking.EndDate); err != nil {
+		return 0, err
 	}
-	err = tx.Commit(ctx)
+	priceBreakdown, err := marshalPrice(booking.Price)
 	if err != nil {
-		status = "failed"
-		span.RecordError(err)
+		return 0, err
+	}
+	var bookingID int
+	err = tx.QueryRow(ctx, query, booking.UserID, booking.RoomID, booking.HotelID, booking.StartDate, booking.EndDate,
+		booking.Amount, booking.CountOfPeople, booking.Children, booking.HotelName, booking.RoomDescription, booking.RoomNumber,
+		booking.Username, booking.ChatID, booking.HoldExpiresAt, booking.IdempotencyKey, priceBreakdown,
+		booking.PromoCodeID, booking.GroupID).Scan(&bookingID)
+	if err != nil {
+		return 0, err
+	}
+	created := &models.StatusTransition{
+		To:      booking.Status,
+		Actor:   models.ActorGuest,
+		ActorID: booking.UserID,
+		Reason:  models.ReasonBookingCreated,
+	}
+	return bookingID, insertStatusHistory(ctx, tx, created, bookingID)
+}
 
-		return -1, fmt.Errorf("failed to commit transaction: %w", err)
+// checkPromoLimits проверяет лимиты промокода для нового бронирования пользователя userID.
+// Учитываются бронирования в models.PromoUsageStatuses: отменённые, просроченные и неоплаченные использования возвращаются.
+func checkPromoLimits(ctx context.Context, tx pgx.Tx, promo *models.PromoCode, userID int) error {
+	if promo.FirstBookingOnly {
+		var hasBookings bool
+		err := tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM bookings WHERE UserID = $1 AND Status = ANY($2))
+		`, userID, models.PromoUsageStatuses).Scan(&hasBookings)
+		if err != nil {
+			return fmt.Errorf("failed to check user bookings: %w", err)
+		}
+		if hasBookings {
+			return fmt.Errorf("first booking only: %w", myerror.ErrPromoCodeNotApplicable)
+		}
 	}
-	return bookingID, nil
+	if promo.UsageLimit == 0 && promo.PerUserLimit == 0 {
+		return nil
+	}
+
+	var used, usedByUser int
+	err := tx.QueryRow(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE UserID = $2)
+		FROM bookings
+		WHERE PromoCodeID = $1 AND Status = ANY($3)
+	`, promo.ID, userID, models.PromoUsageStatuses).Scan(&used, &usedByUser)
+	if err != nil {
+		return fmt.Errorf("failed to count promo code usage: %w", err)
+	}
+	if promo.UsageLimit > 0 && used >= promo.UsageLimit {
+		return myerror.ErrPromoCodeExhausted
+	}
+	if promo.PerUserLimit > 0 && usedByUser >= promo.PerUserLimit {
+		return fmt.Errorf("per user limit: %w", myerror.ErrPromoCodeExhausted)
+	}
+	return nil
 }
 
-func (r *Repository) GetBookingsByUserID(ctx context.Context, userID int) ([]*models.BookingInfo, error) {
+// GetBookingsByUserID возвращает страницу бронирований гостя по фильтру
+func (r *Repository) GetBookingsByUserID(ctx context.Context, userID int, filter *models.BookingFilter) ([]*models.Booking, error) {
 	ctx, span := r.tracer.Start(ctx, "Repository.GetBookingsByUserID")
 	defer span.End()
 
@@ -109,40 +270,19 @@ func (r *Repository) GetBookingsByUserID(ctx context.Context, userID int) ([]*mo
 	status := "ok"
 	defer func() {
 		duration := time.Since(start).Seconds()
-		metrics.RecordDataBaseMetrics("Create booking", status, duration)
+		metrics.RecordDataBaseMetrics("Get bookings by user", status, duration)
 	}()
-	bookings := make([]*models.BookingInfo, 0)
-	query := `
-        SELECT UserID, RoomID, HotelID, StartDate, EndDate
-        FROM bookings
-        WHERE UserID = $1
-    `
-	rows, err := r.db.Query(ctx, query, userID)
+	query, args := bookingListQuery("UserID", userID, filter)
+	bookings, err := queryBookings(ctx, r.db, query, args...)
 	if err != nil {
 		span.RecordError(err)
-
 		status = "failed"
 		return nil, fmt.Errorf("failed to query bookings: %w", err)
 	}
-	defer rows.Close()
-
-	var booking models.BookingInfo
-	for rows.Next() {
-		if err := rows.Scan(&booking.UserID, &booking.RoomID, &booking.HotelID, &booking.StartDate, &booking.EndDate); err != nil {
-			span.RecordError(err)
-
-			status = "failed"
-			return nil, fmt.Errorf("failed to scan booking: %w", err)
-		}
-		bookings = append(bookings, &booking)
-	}
-	if err := rows.Err(); err != nil {
-		status = "failed"
-		return nil, fmt.Errorf("rows iteration myerror: %w", err)
-	}
 	return bookings, nil
 }
 
+// GetUnavailableRoomsByHotelId возвращает комнаты отеля, занятые на период бронированиями или блокировками
 func (r *Repository) GetUnavailableRoomsByHotelId(ctx context.Context, hotelID int, startDate, endDate time.Time) (map[int]struct{}, error) {
 	ctx, span := r.tracer.Start(ctx, "Repository.GetUnavailableRoomsByHotelId")
 	defer span.End()
@@ -157,12 +297,17 @@ func (r *Repository) GetUnavailableRoomsByHotelId(ctx context.Context, hotelID i
 	unavailableRoomsID := make(map[int]struct{})
 	query := `
 		SELECT RoomID
-		from bookings
-		where HotelID = $1
-		and ($2 >= StartDate and $2 < EndDate) or
-			($2 <= StartDate and $3 > StartDate);
+		FROM bookings
+		WHERE HotelID = $1
+		AND Status = ANY($4)
+		AND Period && tstzrange($2, $3, '[)')
+		UNION
+		SELECT RoomID
+		FROM room_blocks
+		WHERE HotelID = $1
+		AND Period && tstzrange($2, $3, '[)')
     `
-	rows, err := r.db.Query(ctx, query, hotelID, startDate, endDate)
+	rows, err := r.db.Query(ctx, query, hotelID, startDate, endDate, models.ActiveStatuses)
 	if err != nil {
 		span.RecordError(err)
 
@@ -191,7 +336,8 @@ func (r *Repository) GetUnavailableRoomsByHotelId(ctx context.Context, hotelID i
 	return unavailableRoomsID, nil
 }
 
-func (r *Repository) GetBookingsByHotelID(ctx context.Context, hotelID int) ([]*models.BookingInfo, error) {
+// GetBookingsByHotelID возвращает страницу бронирований отеля по фильтру
+func (r *Repository) GetBookingsByHotelID(ctx context.Context, hotelID int, filter *models.BookingFilter) ([]*models.Booking, error) {
 	ctx, span := r.tracer.Start(ctx, "Repository.GetBookingsByHotelID")
 	defer span.End()
 
@@ -199,62 +345,506 @@ func (r *Repository) GetBookingsByHotelID(ctx context.Context, hotelID int) ([]*
 	status := "ok"
 	defer func() {
 		duration := time.Since(start).Seconds()
-		metrics.RecordDataBaseMetrics("Create booking", status, duration)
+		metrics.RecordDataBaseMetrics("Get bookings by hotel", status, duration)
+	}()
+	query, args := bookingListQuery("HotelID", hotelID, filter)
+	bookings, err := queryBookings(ctx, r.db, query, args...)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("failed to query bookings: %w", err)
+	}
+	return bookings, nil
+}
+
+// UpdateBookingStatus переводит бронирование из статуса change.From в change.To
+// и сохраняет запись истории и события outbox в той же транзакции
+func (r *Repository) UpdateBookingStatus(ctx context.Context, change *models.StatusTransition, bookingID int, outbox []*models.OutboxMessage) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.UpdateBookingStatus")
+	defer span.End()
+
+	start := time.Now()
+	statusMetrics := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Create booking", statusMetrics, duration)
 	}()
-	bookings := make([]*models.BookingInfo, 0)
+
+	// Отменённое бронирование не должно воскреснуть от запоздавшего вебхука
 	query := `
-        SELECT UserID, RoomID, HotelID, StartDate, EndDate
-        FROM bookings
-        WHERE hotelID = $1
-    `
-	rows, err := r.db.Query(ctx, query, hotelID)
+		UPDATE bookings
+		SET Status = $1, UpdatedAt = NOW(), StatusChangedAt = NOW()
+		WHERE id = $2 AND status = $3
+	`
+	err := r.inTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, change.To, bookingID, change.From)
+		if err != nil {
+			return fmt.Errorf("failed to update booking status: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return myerror.ErrInvalidBookingStatus
+		}
+		if err = insertStatusHistory(ctx, tx, change, bookingID); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, outbox)
+	})
 	if err != nil {
 		span.RecordError(err)
+		statusMetrics = "failed"
+		return fmt.Errorf("in storage UpdateBookingStatus: %w", err)
+	}
+	return nil
+}
+
+// AbandonBooking переводит бронирование из change.From в change.To и освобождает его ключ идемпотентности,
+// чтобы повтор запроса с тем же ключом создал новое бронирование
+func (r *Repository) AbandonBooking(ctx context.Context, change *models.StatusTransition, bookingID int) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.AbandonBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Abandon booking", status, duration)
+	}()
 
+	query := `
+		UPDATE bookings
+		SET Status = $1, IdempotencyKey = NULL, HoldExpiresAt = NULL, UpdatedAt = NOW(), StatusChangedAt = NOW()
+		WHERE ID = $2 AND Status = $3
+	`
+	err := r.inTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, change.To, bookingID, change.From)
+		if err != nil {
+			return fmt.Errorf("failed to abandon booking: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return myerror.ErrInvalidBookingStatus
+		}
+		return insertStatusHistory(ctx, tx, change, bookingID)
+	})
+	if err != nil {
+		span.RecordError(err)
 		status = "failed"
-		return nil, fmt.Errorf("failed to query bookings: %w", err)
+		return fmt.Errorf("in storage AbandonBooking: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetBookingByID(ctx context.Context, bookingID int) (*models.Booking, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetBookingByID")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get booking", status, duration)
+	}()
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE ID = $1
+	`
+	booking, err := scanBooking(r.db.QueryRow(ctx, query, bookingID))
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("in storage GetBookingByID: %w", myerror.ErrBookingNotFound)
+		}
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	return booking, nil
+}
+
+// CancelBooking отменяет бронирование, если оно всё ещё в статусе change.From
+func (r *Repository) CancelBooking(ctx context.Context, bookingID int, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.CancelBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Cancel booking", status, duration)
+	}()
+
+	query := `
+		UPDATE bookings
+		SET Status = $1, CancelledAt = NOW(), StatusChangedAt = NOW()
+		WHERE ID = $2 AND Status = $3
+	`
+	err := r.inTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, change.To, bookingID, change.From)
+		if err != nil {
+			return fmt.Errorf("failed to cancel booking: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return myerror.ErrInvalidBookingStatus
+		}
+		if err = insertStatusHistory(ctx, tx, change, bookingID); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, outbox)
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage CancelBooking: %w", err)
+	}
+	return nil
+}
+
+// UpdateBooking сохраняет изменённое бронирование, если новая комната свободна на новые даты.
+// Изменяется только бронирование в статусе change.From, оно переходит в change.To.
+// Запись истории добавляется, только если статус меняется, события outbox сохраняются в той же транзакции.
+func (r *Repository) UpdateBooking(ctx context.Context, booking *models.Booking, change *models.StatusTransition, outbox []*models.OutboxMessage) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.UpdateBooking")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Update booking", status, duration)
+	}()
+
+	err := r.inTx(ctx, pgx.TxOptions{IsoLevel: pgx.Serializable}, func(tx pgx.Tx) error {
+		if err := updateBooking(ctx, tx, booking, change); err != nil {
+			return err
+		}
+		return insertOutbox(ctx, tx, outbox)
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage UpdateBooking: %w", err)
+	}
+	return nil
+}
+
+// updateBooking сохраняет даты, комнату и стоимость бронирования в статусе change.From и переводит его в change.To.
+// Транзакция должна быть serializable: проверка блокировок комнаты и запись не должны разойтись.
+func updateBooking(ctx context.Context, tx pgx.Tx, booking *models.Booking, change *models.StatusTransition) error {
+	query := `
+		UPDATE bookings
+		SET RoomID = $1, RoomDescription = $2, RoomNumber = $3, CountOfPeople = $4, Children = $14,
+			StartDate = $5, EndDate = $6, Amount = $7, Status = $8, HoldExpiresAt = $9, PriceBreakdown = $10,
+			PromoCodeID = NULLIF($11, 0), UpdatedAt = NOW(),
+			StatusChangedAt = CASE WHEN Status <> $8 THEN NOW() ELSE StatusChangedAt END
+		WHERE ID = $12 AND Status = $13
+	`
+	priceBreakdown, err := marshalPrice(booking.Price)
+	if err != nil {
+		return err
+	}
+	if err = checkMovedBooking(ctx, tx, booking); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, query, booking.RoomID, booking.RoomDescription, booking.RoomNumber, booking.CountOfPeople,
+		booking.StartDate, booking.EndDate, booking.Amount, change.To, booking.HoldExpiresAt, priceBreakdown,
+		booking.PromoCodeID, booking.ID, change.From, booking.Children)
+	if err != nil {
+		return constraintError(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return myerror.ErrInvalidBookingStatus
+	}
+	if change.From != change.To {
+		return insertStatusHistory(ctx, tx, change, booking.ID)
+	}
+	return nil
+}
+
+// checkMovedBooking проверяет блокировки комнаты, если бронирование переезжает в другую комнату
+// или выходит за прежний период. Сокращение периода новых ночей не занимает.
+func checkMovedBooking(ctx context.Context, tx pgx.Tx, booking *models.Booking) error {
+	var (
+		roomID             int
+		startDate, endDate time.Time
+	)
+	err := tx.QueryRow(ctx, `SELECT RoomID, StartDate, EndDate FROM bookings WHERE ID = $1`, booking.ID).
+		Scan(&roomID, &startDate, &endDate)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil // Отсутствие бронирования обнаружит UPDATE
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get booking period: %w", err)
+	}
+	if roomID == booking.RoomID && !booking.StartDate.Before(startDate) && !booking.EndDate.After(endDate) {
+		return nil
+	}
+	return checkRoomBlocks(ctx, tx, booking.RoomID, booking.StartDate, booking.EndDate)
+}
+
+// ExpireBookings переводит из change.From в change.To бронирования, не оплаченные до конца срока ожидания.
+// События для каждого бронирования строит toOutbox, они и записи истории сохраняются в той же транзакции.
+func (r *Repository) ExpireBookings(ctx context.Context, change *models.StatusTransition, toOutbox func(*models.Booking) ([]*models.OutboxMessage, error)) ([]*models.Booking, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.ExpireBookings")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Expire bookings", status, duration)
+	}()
+
+	query := `
+		UPDATE bookings
+		SET Status = $1, UpdatedAt = NOW(), StatusChangedAt = NOW()
+		WHERE Status = $2 AND HoldExpiresAt < NOW()
+		RETURNING ` + bookingColumns
+	var bookings []*models.Booking
+	err := r.inTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		var err error
+		bookings, err = queryBookings(ctx, tx, query, change.To, change.From)
+		if err != nil {
+			return fmt.Errorf("failed to expire bookings: %w", err)
+		}
+		bookingIDs := make([]int, 0, len(bookings))
+		for _, booking := range bookings {
+			bookingIDs = append(bookingIDs, booking.ID)
+		}
+		if err = insertStatusHistory(ctx, tx, change, bookingIDs...); err != nil {
+			return err
+		}
+		for _, booking := range bookings {
+			outbox, err := toOutbox(booking)
+			if err != nil {
+				return err
+			}
+			if err = insertOutbox(ctx, tx, outbox); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage ExpireBookings: %w", err)
+	}
+	return bookings, nil
+}
+
+// GetBookingByIdempotencyKey ищет бронирование пользователя, созданное с ключом key не раньше since
+func (r *Repository) GetBookingByIdempotencyKey(ctx context.Context, userID int, key string, since time.Time) (*models.Booking, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetBookingByIdempotencyKey")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get booking by idempotency key", status, duration)
+	}()
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE UserID = $1 AND IdempotencyKey = $2 AND CreatedAt >= $3
+	`
+	booking, err := scanBooking(r.db.QueryRow(ctx, query, userID, key, since))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("in storage GetBookingByIdempotencyKey: %w", myerror.ErrBookingNotFound)
+		}
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("failed to get booking: %w", err)
+	}
+	return booking, nil
+}
+
+// GetStalePendingBookings возвращает бронирования, ожидающие оплату с момента раньше pendingSince,
+// начиная с самых давних
+func (r *Repository) GetStalePendingBookings(ctx context.Context, pendingSince time.Time, limit int) ([]*models.Booking, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetStalePendingBookings")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get stale pending bookings", status, duration)
+	}()
+
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE Status = $1 AND COALESCE(StatusChangedAt, CreatedAt) < $2
+		ORDER BY COALESCE(StatusChangedAt, CreatedAt), ID
+		LIMIT $3
+	`
+	bookings, err := queryBookings(ctx, r.db, query, models.StatusPendingPayment, pendingSince, limit)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage GetStalePendingBookings: %w", err)
+	}
+	return bookings, nil
+}
+
+// ReleaseIdempotencyKeys освобождает ключи идемпотентности бронирований, созданных раньше olderThan
+func (r *Repository) ReleaseIdempotencyKeys(ctx context.Context, olderThan time.Time) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.ReleaseIdempotencyKeys")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Release idempotency keys", status, duration)
+	}()
+	query := `
+		UPDATE bookings
+		SET IdempotencyKey = NULL
+		WHERE IdempotencyKey IS NOT NULL AND CreatedAt < $1
+	`
+	tag, err := r.db.Exec(ctx, query, olderThan)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return 0, fmt.Errorf("failed to release idempotency keys: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func scanBooking(row pgx.Row) (*models.Booking, error) {
+	var booking models.Booking
+	var priceBreakdown []byte
+	err := row.Scan(&booking.ID, &booking.UserID, &booking.RoomID, &booking.HotelID, &booking.Status, &booking.Amount,
+		&booking.CountOfPeople, &booking.Children, &booking.HotelName, &booking.RoomDescription, &booking.RoomNumber,
+		&booking.Username, &booking.ChatID, &booking.StartDate, &booking.EndDate, &booking.CreatedAt, &booking.HoldExpiresAt,
+		&priceBreakdown, &booking.PromoCodeID, &booking.GroupID,
+		&booking.UpdatedAt, &booking.CancelledAt, &booking.StatusChangedAt)
+	if err != nil {
+		return nil, err
+	}
+	// У бронирований, созданных до расчёта по ночам, PriceBreakdown пустой
+	if len(priceBreakdown) > 0 {
+		if err = json.Unmarshal(priceBreakdown, &booking.Price); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal price breakdown: %w", err)
+		}
+	}
+	return &booking, nil
+}
+
+// marshalPrice готовит расчёт стоимости для колонки PriceBreakdown, nil сохраняется как NULL
+func marshalPrice(price *models.PriceQuote) ([]byte, error) {
+	if price == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(price)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal price breakdown: %w", err)
+	}
+	return data, nil
+}
+
+// querier - пул соединений или транзакция
+type querier interface {
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+}
+
+// queryBookings выполняет запрос, возвращающий bookingColumns
+func queryBookings(ctx context.Context, db querier, query string, args ...interface{}) ([]*models.Booking, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
 
+	bookings := make([]*models.Booking, 0)
 	for rows.Next() {
-		var booking models.BookingInfo
-		if err = rows.Scan(&booking.UserID, &booking.RoomID, &booking.HotelID, &booking.StartDate, &booking.EndDate); err != nil {
-			span.RecordError(err)
-
-			status = "failed"
+		booking, err := scanBooking(rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan booking: %w", err)
 		}
-		bookings = append(bookings, &booking)
+		bookings = append(bookings, booking)
 	}
 	if err = rows.Err(); err != nil {
-		span.RecordError(err)
+		return nil, fmt.Errorf("rows iteration myerror: %w", err)
+	}
+	return bookings, nil
+}
 
+// GetActiveBookingsByHotelPeriod возвращает активные бронирования отеля, пересекающиеся с периодом [startDate, endDate)
+func (r *Repository) GetActiveBookingsByHotelPeriod(ctx context.Context, hotelID int, startDate, endDate time.Time) ([]*models.Booking, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetActiveBookingsByHotelPeriod")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get hotel bookings by period", status, duration)
+	}()
+	query := `
+		SELECT ` + bookingColumns + `
+		FROM bookings
+		WHERE HotelID = $1
+		AND Status = ANY($4)
+		AND Period && tstzrange($2, $3, '[)')
+		ORDER BY RoomID, StartDate
+	`
+	bookings, err := queryBookings(ctx, r.db, query, hotelID, startDate, endDate, models.ActiveStatuses)
+	if err != nil {
+		span.RecordError(err)
 		status = "failed"
-		return nil, fmt.Errorf("rows iteration myerror: %w", err)
+		return nil, fmt.Errorf("failed to query bookings: %w", err)
 	}
 	return bookings, nil
 }
 
-func (r *Repository) UpdateBookingStatus(ctx context.Context, status string, bookingID int) error {
-	ctx, span := r.tracer.Start(ctx, "Repository.UpdateBookingStatus")
+// GetUnavailableRoomsByHotelIDs возвращает занятые на период бронированиями или блокировками комнаты нескольких отелей одним запросом
+func (r *Repository) GetUnavailableRoomsByHotelIDs(ctx context.Context, hotelIDs []int, startDate, endDate time.Time) (map[int]struct{}, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetUnavailableRoomsByHotelIDs")
 	defer span.End()
 
 	start := time.Now()
-	statusMetrics := "ok"
+	status := "ok"
 	defer func() {
 		duration := time.Since(start).Seconds()
-		metrics.RecordDataBaseMetrics("Create booking", statusMetrics, duration)
+		metrics.RecordDataBaseMetrics("Get unavailable rooms by hotels", status, duration)
 	}()
 	query := `
-		UPDATE bookings
-		SET status = $1
-		WHERE id = $2
+		SELECT RoomID
+		FROM bookings
+		WHERE HotelID = ANY($1)
+		AND Status = ANY($4)
+		AND Period && tstzrange($2, $3, '[)')
+		UNION
+		SELECT RoomID
+		FROM room_blocks
+		WHERE HotelID = ANY($1)
+		AND Period && tstzrange($2, $3, '[)')
 	`
-	_, err := r.db.Exec(ctx, query, status, bookingID)
+	rows, err := r.db.Query(ctx, query, hotelIDs, startDate, endDate, models.ActiveStatuses)
 	if err != nil {
 		span.RecordError(err)
-		statusMetrics = "failed"
-		return fmt.Errorf("failed to update booking status: %w", err)
+		status = "failed"
+		return nil, fmt.Errorf("failed to query bookings: %w", err)
 	}
-	return nil
+	defer rows.Close()
+
+	unavailableRoomsID := make(map[int]struct{})
+	for rows.Next() {
+		var roomID int
+		if err := rows.Scan(&roomID); err != nil {
+			span.RecordError(err)
+			status = "failed"
+			return nil, fmt.Errorf("failed to scan room ID: %w", err)
+		}
+		unavailableRoomsID[roomID] = struct{}{}
+	}
+	if err := rows.Err(); err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("rows iteration myerror: %w", err)
+	}
+	return unavailableRoomsID, nil
 }
@@ -0,0 +1,96 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"math/rand"
+	"time"
+)
+
+// Коды ошибок Postgres
+const (
+	uniqueViolationCode      = "23505"
+	exclusionViolationCode   = "23P01"
+	serializationFailureCode = "40001"
+	deadlockDetectedCode     = "40P01"
+)
+
+// Ограничения схемы, ошибки которых переводятся в ошибки myerror
+const (
+	// Пересечение активных бронирований одной комнаты, см. миграции 7_booking_period и 14_booking_status_history
+	bookingPeriodConstraint = "bookings_room_period_excl"
+	// Уникальный индекс ключей идемпотентности, см. миграцию 6_booking_idempotency
+	idempotencyKeyIndex = "bookings_user_idempotency_key_idx"
+	// Не больше одного изменения бронирования, ожидающего доплаты, см. миграцию 19_booking_modifications
+	pendingModificationIndex = "booking_modifications_pending_idx"
+)
+
+const (
+	maxTxAttempts = 5
+	txRetryDelay  = 20 * time.Millisecond
+)
+
+// inTx выполняет fn в транзакции и повторяет её целиком при ошибке сериализации или дедлоке.
+// fn может вызываться несколько раз, поэтому не должна накапливать состояние между вызовами.
+func (r *Repository) inTx(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		err = r.runTx(ctx, opts, fn)
+		if !isRetryable(err) {
+			return err
+		}
+		// Случайная задержка растёт с каждой попыткой, чтобы конкурирующие транзакции разошлись
+		delay := time.Duration(attempt)*txRetryDelay + time.Duration(rand.Int63n(int64(txRetryDelay)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+	return fmt.Errorf("transaction failed after %d attempts: %w", maxTxAttempts, err)
+}
+
+func (r *Repository) runTx(ctx context.Context, opts pgx.TxOptions, fn func(tx pgx.Tx) error) error {
+	tx, err := r.db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func isRetryable(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == serializationFailureCode || pgErr.Code == deadlockDetectedCode
+}
+
+// constraintError переводит нарушение ограничения схемы в ошибку myerror, остальные ошибки возвращает как есть
+func constraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+	switch {
+	case pgErr.Code == exclusionViolationCode && pgErr.ConstraintName == bookingPeriodConstraint:
+		return myerror.ErrBookingAlreadyExists
+	case pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == idempotencyKeyIndex:
+		return myerror.ErrIdempotencyKeyExists
+	case pgErr.Code == uniqueViolationCode && pgErr.ConstraintName == pendingModificationIndex:
+		return myerror.ErrInvalidBookingStatus
+	}
+	return err
+}
@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"github.com/jackc/pgx/v4"
+	"time"
+)
+
+// waitlistColumns - колонки для scanWaitlistEntry
+const waitlistColumns = `ID, UserID, Username, ChatID, HotelID, COALESCE(RoomTypeID, 0), StartDate, EndDate, CountOfPeople,
+		Status, COALESCE(OfferedRoomID, 0), COALESCE(ClaimToken, ''), OfferExpiresAt, CreatedAt`
+
+func (r *Repository) CreateWaitlistEntry(ctx context.Context, entry *models.WaitlistEntry) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.CreateWaitlistEntry")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Create waitlist entry", status, duration)
+	}()
+	query := `
+		INSERT INTO waitlist_entries (UserID, Username, ChatID, HotelID, RoomTypeID, StartDate, EndDate, CountOfPeople)
+		VALUES ($1, $2, $3, $4, NULLIF($5, 0), $6, $7, $8)
+		RETURNING ID
+	`
+	var entryID int
+	err := r.db.QueryRow(ctx, query, entry.UserID, entry.Username, entry.ChatID, entry.HotelID, entry.RoomTypeID,
+		entry.StartDate, entry.EndDate, entry.CountOfPeople).Scan(&entryID)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return 0, fmt.Errorf("failed to create waitlist entry: %w", err)
+	}
+	return entryID, nil
+}
+
+// GetWaitlistCandidates возвращает первых в очереди гостей, ждущих комнату типа roomTypeID
+// в отеле на даты, пересекающиеся с освободившимся периодом
+func (r *Repository) GetWaitlistCandidates(ctx context.Context, hotelID, roomTypeID int, startDate, endDate time.Time, limit int) ([]*models.WaitlistEntry, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.GetWaitlistCandidates")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Get waitlist candidates", status, duration)
+	}()
+	query := `
+		SELECT ` + waitlistColumns + `
+		FROM waitlist_entries
+		WHERE HotelID = $1 AND Status = $2 AND (RoomTypeID IS NULL OR RoomTypeID = $3)
+			AND StartDate < $5 AND EndDate > $4 AND StartDate > NOW()
+		ORDER BY CreatedAt, ID
+		LIMIT $6
+	`
+	entries, err := queryWaitlistEntries(ctx, r.db, query, hotelID, models.WaitlistWaiting, roomTypeID, startDate, endDate, limit)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage GetWaitlistCandidates: %w", err)
+	}
+	return entries, nil
+}
+
+// OfferWaitlistEntry предлагает гостю из очереди комнату roomID, уведомление сохраняется в той же транзакции.
+// Если запись уже не в очереди, возвращается ErrWaitlistOfferUnavailable.
+func (r *Repository) OfferWaitlistEntry(ctx context.Context, entryID, roomID int, token string, expiresAt time.Time, outbox []*models.OutboxMessage) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.OfferWaitlistEntry")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Offer waitlist entry", status, duration)
+	}()
+	query := `
+		UPDATE waitlist_entries
+		SET Status = $1, OfferedRoomID = $2, ClaimToken = $3, OfferExpiresAt = $4
+		WHERE ID = $5 AND Status = $6
+	`
+	err := r.inTx(ctx, pgx.TxOptions{}, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, query, models.WaitlistOffered, roomID, token, expiresAt, entryID, models.WaitlistWaiting)
+		if err != nil {
+			return fmt.Errorf("failed to offer waitlist entry: %w", err)
+		}
+		if tag.RowsAffected() == 0 {
+			return myerror.ErrWaitlistOfferUnavailable
+		}
+		return insertOutbox(ctx, tx, outbox)
+	})
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage OfferWaitlistEntry: %w", err)
+	}
+	return nil
+}
+
+// ClaimWaitlistOffer отмечает предложение использованным, если ссылка принадлежит пользователю и ещё действует
+func (r *Repository) ClaimWaitlistOffer(ctx context.Context, token string, userID int) (*models.WaitlistEntry, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.ClaimWaitlistOffer")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Claim waitlist offer", status, duration)
+	}()
+	query := `
+		UPDATE waitlist_entries
+		SET Status = $1
+		WHERE ClaimToken = $2 AND UserID = $3 AND Status = $4 AND OfferExpiresAt > NOW()
+		RETURNING ` + waitlistColumns
+	entry, err := scanWaitlistEntry(r.db.QueryRow(ctx, query, models.WaitlistClaimed, token, userID, models.WaitlistOffered))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("in storage ClaimWaitlistOffer: %w", myerror.ErrWaitlistOfferUnavailable)
+		}
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage ClaimWaitlistOffer: %w", err)
+	}
+	return entry, nil
+}
+
+// RequeueWaitlistEntry возвращает гостя на прежнее место в очереди, если забронировать предложенную комнату не удалось
+func (r *Repository) RequeueWaitlistEntry(ctx context.Context, entryID int) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.RequeueWaitlistEntry")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Requeue waitlist entry", status, duration)
+	}()
+	query := `
+		UPDATE waitlist_entries
+		SET Status = $1, OfferedRoomID = NULL, ClaimToken = NULL, OfferExpiresAt = NULL
+		WHERE ID = $2 AND Status = $3
+	`
+	if _, err := r.db.Exec(ctx, query, models.WaitlistWaiting, entryID, models.WaitlistClaimed); err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("in storage RequeueWaitlistEntry: %w", err)
+	}
+	return nil
+}
+
+// ExpireWaitlistEntries снимает неиспользованные вовремя предложения и записи, даты которых уже начались
+func (r *Repository) ExpireWaitlistEntries(ctx context.Context) ([]*models.WaitlistEntry, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.ExpireWaitlistEntries")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Expire waitlist entries", status, duration)
+	}()
+	query := `
+		UPDATE waitlist_entries
+		SET Status = $1
+		WHERE (Status = $2 AND OfferExpiresAt <= NOW()) OR (Status = $3 AND StartDate <= NOW())
+		RETURNING ` + waitlistColumns
+	entries, err := queryWaitlistEntries(ctx, r.db, query, models.WaitlistExpired, models.WaitlistOffered, models.WaitlistWaiting)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return nil, fmt.Errorf("in storage ExpireWaitlistEntries: %w", err)
+	}
+	return entries, nil
+}
+
+func scanWaitlistEntry(row pgx.Row) (*models.WaitlistEntry, error) {
+	var entry models.WaitlistEntry
+	err := row.Scan(&entry.ID, &entry.UserID, &entry.Username, &entry.ChatID, &entry.HotelID, &entry.RoomTypeID,
+		&entry.StartDate, &entry.EndDate, &entry.CountOfPeople, &entry.Status, &entry.OfferedRoomID, &entry.ClaimToken,
+		&entry.OfferExpiresAt, &entry.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &entry, nil
+}
+
+// queryWaitlistEntries выполняет запрос, возвращающий waitlistColumns
+func queryWaitlistEntries(ctx context.Context, db querier, query string, args ...interface{}) ([]*models.WaitlistEntry, error) {
+	rows, err := db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]*models.WaitlistEntry, 0)
+	for rows.Next() {
+		entry, err := scanWaitlistEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan waitlist entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration myerror: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/BookingSvc/internal/myerror"
+	"github.com/Quizert/room-reservation-system/Libs/metrics"
+	"time"
+)
+
+// SavePaymentWebhook запоминает подпись принятого вебхука, повтор подписи - myerror.ErrWebhookReplayed
+func (r *Repository) SavePaymentWebhook(ctx context.Context, signature string, signedAt time.Time) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.SavePaymentWebhook")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Save payment webhook", status, duration)
+	}()
+
+	query := `
+		INSERT INTO payment_webhooks (Signature, SignedAt)
+		VALUES ($1, $2)
+		ON CONFLICT (Signature) DO NOTHING
+	`
+	tag, err := r.db.Exec(ctx, query, signature, signedAt)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("failed to save payment webhook: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		status = "replayed"
+		return fmt.Errorf("in storage SavePaymentWebhook: %w", myerror.ErrWebhookReplayed)
+	}
+	return nil
+}
+
+// DeletePaymentWebhook забывает подпись вебхука, который не удалось обработать, чтобы его повтор был принят
+func (r *Repository) DeletePaymentWebhook(ctx context.Context, signature string) error {
+	ctx, span := r.tracer.Start(ctx, "Repository.DeletePaymentWebhook")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Delete payment webhook", status, duration)
+	}()
+
+	if _, err := r.db.Exec(ctx, `DELETE FROM payment_webhooks WHERE Signature = $1`, signature); err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return fmt.Errorf("failed to delete payment webhook: %w", err)
+	}
+	return nil
+}
+
+// ReleasePaymentWebhooks удаляет подписи вебхуков, подписанных раньше olderThan
+func (r *Repository) ReleasePaymentWebhooks(ctx context.Context, olderThan time.Time) (int, error) {
+	ctx, span := r.tracer.Start(ctx, "Repository.ReleasePaymentWebhooks")
+	defer span.End()
+
+	start := time.Now()
+	status := "ok"
+	defer func() {
+		duration := time.Since(start).Seconds()
+		metrics.RecordDataBaseMetrics("Release payment webhooks", status, duration)
+	}()
+
+	tag, err := r.db.Exec(ctx, `DELETE FROM payment_webhooks WHERE SignedAt < $1`, olderThan)
+	if err != nil {
+		span.RecordError(err)
+		status = "failed"
+		return 0, fmt.Errorf("failed to release payment webhooks: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
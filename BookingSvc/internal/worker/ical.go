@@ -0,0 +1,48 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+type ICalImporter interface {
+	SyncICalImports(ctx context.Context) (int, error)
+}
+
+// ICalSyncer периодически загружает календари других площадок и обновляет блокировки комнат
+type ICalSyncer struct {
+	importer ICalImporter
+	interval time.Duration
+	log      *zap.Logger
+}
+
+func NewICalSyncer(importer ICalImporter, interval time.Duration, logger *zap.Logger) *ICalSyncer {
+	return &ICalSyncer{
+		importer: importer,
+		interval: interval,
+		log:      logger,
+	}
+}
+
+// Run работает до отмены контекста
+func (s *ICalSyncer) Run(ctx context.Context) error {
+	s.log.Info("Starting ical syncer", zap.Duration("interval", s.interval))
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.log.Info("ICal syncer stopped")
+			return nil
+		case <-ticker.C:
+			synced, err := s.importer.SyncICalImports(ctx)
+			if err != nil {
+				s.log.Error("Error in ical syncer", zap.Error(err))
+			} else if synced > 0 {
+				s.log.Info("Synced ical imports", zap.Int("count", synced))
+			}
+		}
+	}
+}
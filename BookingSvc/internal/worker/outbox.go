@@ -0,0 +1,55 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+type OutboxPublisher interface {
+	PublishOutbox(ctx context.Context, batchSize int) (int, error)
+}
+
+// OutboxRelay периодически переносит события из таблицы outbox в Kafka
+type OutboxRelay struct {
+	publisher OutboxPublisher
+	interval  time.Duration
+	batchSize int
+	log       *zap.Logger
+}
+
+func NewOutboxRelay(publisher OutboxPublisher, interval time.Duration, batchSize int, logger *zap.Logger) *OutboxRelay {
+	return &OutboxRelay{
+		publisher: publisher,
+		interval:  interval,
+		batchSize: batchSize,
+		log:       logger,
+	}
+}
+
+// Run работает до отмены контекста
+func (r *OutboxRelay) Run(ctx context.Context) error {
+	r.log.Info("Starting outbox relay", zap.Duration("interval", r.interval))
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("Outbox relay stopped")
+			return nil
+		case <-ticker.C:
+			// Полная пачка - вероятно, есть ещё сообщения, забираем их не дожидаясь тика
+			for {
+				sent, err := r.publisher.PublishOutbox(ctx, r.batchSize)
+				if err != nil {
+					r.log.Error("Error in outbox relay", zap.Error(err))
+					break
+				}
+				if sent < r.batchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
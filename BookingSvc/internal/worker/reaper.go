@@ -0,0 +1,71 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+type BookingExpirer interface {
+	ExpireStaleBookings(ctx context.Context) (int, error)
+	ReleaseIdempotencyKeys(ctx context.Context) (int, error)
+	ExpireWaitlistOffers(ctx context.Context) (int, error)
+	ReleasePaymentWebhooks(ctx context.Context) (int, error)
+}
+
+// Reaper периодически снимает неоплаченные бронирования с истёкшим сроком ожидания
+// и освобождает устаревшие ключи идемпотентности, просроченные предложения листа ожидания
+// и подписи вебхуков оплаты, которые уже не пройдут проверку времени
+type Reaper struct {
+	expirer  BookingExpirer
+	interval time.Duration
+	log      *zap.Logger
+}
+
+func NewReaper(expirer BookingExpirer, interval time.Duration, logger *zap.Logger) *Reaper {
+	return &Reaper{
+		expirer:  expirer,
+		interval: interval,
+		log:      logger,
+	}
+}
+
+// Run работает до отмены контекста
+func (r *Reaper) Run(ctx context.Context) error {
+	r.log.Info("Starting booking reaper", zap.Duration("interval", r.interval))
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("Booking reaper stopped")
+			return nil
+		case <-ticker.C:
+			expired, err := r.expirer.ExpireStaleBookings(ctx)
+			if err != nil {
+				r.log.Error("Error in booking reaper", zap.Error(err))
+			} else if expired > 0 {
+				r.log.Info("Expired unpaid bookings", zap.Int("count", expired))
+			}
+			released, err := r.expirer.ReleaseIdempotencyKeys(ctx)
+			if err != nil {
+				r.log.Error("Error in booking reaper", zap.Error(err))
+			} else if released > 0 {
+				r.log.Info("Released idempotency keys", zap.Int("count", released))
+			}
+			offers, err := r.expirer.ExpireWaitlistOffers(ctx)
+			if err != nil {
+				r.log.Error("Error in booking reaper", zap.Error(err))
+			} else if offers > 0 {
+				r.log.Info("Expired waitlist entries", zap.Int("count", offers))
+			}
+			webhooks, err := r.expirer.ReleasePaymentWebhooks(ctx)
+			if err != nil {
+				r.log.Error("Error in booking reaper", zap.Error(err))
+			} else if webhooks > 0 {
+				r.log.Info("Released payment webhook signatures", zap.Int("count", webhooks))
+			}
+		}
+	}
+}
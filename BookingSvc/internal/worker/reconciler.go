@@ -0,0 +1,53 @@
+package worker
+
+import (
+	"context"
+	"go.uber.org/zap"
+	"time"
+)
+
+type PaymentReconciler interface {
+	ReconcilePayments(ctx context.Context, pendingFor time.Duration, limit int) (int, error)
+}
+
+// Reconciler периодически сверяет с PaymentSystem давно ожидающие оплату бронирования:
+// если вебхук потерялся, итог платежа берётся из PaymentSystem
+type Reconciler struct {
+	reconciler PaymentReconciler
+	interval   time.Duration
+	pendingFor time.Duration
+	batchSize  int
+	log        *zap.Logger
+}
+
+func NewReconciler(reconciler PaymentReconciler, interval, pendingFor time.Duration, batchSize int, logger *zap.Logger) *Reconciler {
+	return &Reconciler{
+		reconciler: reconciler,
+		interval:   interval,
+		pendingFor: pendingFor,
+		batchSize:  batchSize,
+		log:        logger,
+	}
+}
+
+// Run работает до отмены контекста
+func (r *Reconciler) Run(ctx context.Context) error {
+	r.log.Info("Starting payment reconciler", zap.Duration("interval", r.interval), zap.Duration("pending for", r.pendingFor))
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("Payment reconciler stopped")
+			return nil
+		case <-ticker.C:
+			reconciled, err := r.reconciler.ReconcilePayments(ctx, r.pendingFor, r.batchSize)
+			if err != nil {
+				r.log.Error("Error in payment reconciler", zap.Error(err))
+			} else if reconciled > 0 {
+				r.log.Info("Reconciled missed payments", zap.Int("count", reconciled))
+			}
+		}
+	}
+}
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS bookings_group_idx;
+
+ALTER TABLE Bookings
+    DROP COLUMN IF EXISTS GroupID;
+
+DROP TABLE IF EXISTS booking_groups;
@@ -0,0 +1,13 @@
+-- Групповое бронирование: несколько комнат одного отеля на одни даты с общей оплатой
+CREATE TABLE IF NOT EXISTS booking_groups (
+    ID SERIAL PRIMARY KEY,
+    UserID INT NOT NULL,
+    HotelID INT NOT NULL,
+    Amount INT NOT NULL,
+    CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE Bookings
+    ADD COLUMN GroupID INT REFERENCES booking_groups(ID);
+
+CREATE INDEX IF NOT EXISTS bookings_group_idx ON Bookings (GroupID) WHERE GroupID IS NOT NULL;
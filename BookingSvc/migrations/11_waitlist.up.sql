@@ -0,0 +1,25 @@
+-- Лист ожидания на занятые даты. RoomTypeID NULL - подходит любая комната отеля.
+-- Освободившаяся комната предлагается первому подходящему гостю по ссылке с ClaimToken до OfferExpiresAt.
+CREATE TABLE IF NOT EXISTS waitlist_entries (
+    ID SERIAL PRIMARY KEY,
+    UserID INT NOT NULL,
+    Username TEXT NOT NULL,
+    ChatID TEXT NOT NULL,
+    HotelID INT NOT NULL,
+    RoomTypeID INT,
+    StartDate TIMESTAMP WITH TIME ZONE NOT NULL,
+    EndDate TIMESTAMP WITH TIME ZONE NOT NULL,
+    CountOfPeople INT NOT NULL,
+    Status TEXT NOT NULL DEFAULT 'waiting',
+    OfferedRoomID INT,
+    ClaimToken TEXT,
+    OfferExpiresAt TIMESTAMP WITH TIME ZONE,
+    CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (StartDate < EndDate)
+);
+
+CREATE INDEX IF NOT EXISTS waitlist_hotel_queue_idx ON waitlist_entries (HotelID, CreatedAt) WHERE Status = 'waiting';
+
+CREATE INDEX IF NOT EXISTS waitlist_offer_expiry_idx ON waitlist_entries (OfferExpiresAt) WHERE Status = 'offered';
+
+CREATE UNIQUE INDEX IF NOT EXISTS waitlist_claim_token_idx ON waitlist_entries (ClaimToken);
@@ -0,0 +1,7 @@
+-- Время последней смены статуса для истории бронирования.
+-- Для уже завершённых бронирований берётся время отмены или последнего изменения.
+ALTER TABLE Bookings
+    ADD COLUMN StatusChangedAt TIMESTAMP WITH TIME ZONE;
+UPDATE Bookings
+SET StatusChangedAt = COALESCE(CancelledAt, UpdatedAt)
+WHERE Status <> 'waiting';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS bookings_hotel_end_idx;
+DROP INDEX IF EXISTS bookings_hotel_start_idx;
+DROP INDEX IF EXISTS bookings_hotel_created_idx;
+DROP INDEX IF EXISTS bookings_user_start_idx;
+DROP INDEX IF EXISTS bookings_user_created_idx;
@@ -0,0 +1,6 @@
+-- Индексы для постраничных списков бронирований гостя и отеля (курсор по ключу сортировки и ID)
+CREATE INDEX IF NOT EXISTS bookings_user_created_idx ON Bookings (UserID, CreatedAt DESC, ID DESC);
+CREATE INDEX IF NOT EXISTS bookings_user_start_idx ON Bookings (UserID, StartDate, ID);
+CREATE INDEX IF NOT EXISTS bookings_hotel_created_idx ON Bookings (HotelID, CreatedAt DESC, ID DESC);
+CREATE INDEX IF NOT EXISTS bookings_hotel_start_idx ON Bookings (HotelID, StartDate, ID);
+CREATE INDEX IF NOT EXISTS bookings_hotel_end_idx ON Bookings (HotelID, EndDate);
@@ -0,0 +1,25 @@
+DROP TABLE IF EXISTS booking_status_history;
+
+ALTER TABLE Bookings
+    DROP CONSTRAINT IF EXISTS bookings_room_period_excl,
+    DROP CONSTRAINT IF EXISTS bookings_status_check;
+DROP INDEX IF EXISTS bookings_pending_hold_idx;
+
+-- Заезд и выезд в старой схеме не различались, а незаезд не должен снова занять комнату
+UPDATE Bookings
+SET Status = CASE Status
+    WHEN 'pending_payment' THEN 'waiting'
+    WHEN 'confirmed' THEN 'success'
+    WHEN 'checked_in' THEN 'success'
+    WHEN 'checked_out' THEN 'success'
+    WHEN 'no_show' THEN 'cancelled'
+    WHEN 'payment_failed' THEN 'fail'
+    ELSE Status
+END;
+
+ALTER TABLE Bookings
+    ALTER COLUMN Status SET DEFAULT 'waiting',
+    ADD CONSTRAINT bookings_room_period_excl EXCLUDE USING gist (RoomID WITH =, Period WITH &&)
+        WHERE (Status IN ('waiting', 'success'));
+
+CREATE INDEX IF NOT EXISTS bookings_waiting_hold_idx ON Bookings (HoldExpiresAt) WHERE Status = 'waiting';
@@ -0,0 +1,50 @@
+-- Явные статусы бронирования вместо статусов платежа
+ALTER TABLE Bookings DROP CONSTRAINT IF EXISTS bookings_room_period_excl;
+DROP INDEX IF EXISTS bookings_waiting_hold_idx;
+
+UPDATE Bookings
+SET Status = CASE Status
+    WHEN 'waiting' THEN 'pending_payment'
+    WHEN 'success' THEN 'confirmed'
+    WHEN 'fail' THEN 'payment_failed'
+    WHEN 'failed' THEN 'payment_failed'
+    ELSE Status
+END;
+
+ALTER TABLE Bookings
+    ALTER COLUMN Status SET DEFAULT 'pending_payment',
+    ADD CONSTRAINT bookings_status_check CHECK (Status IN ('pending_payment', 'confirmed', 'payment_failed', 'cancelled',
+                                                          'expired', 'checked_in', 'checked_out', 'no_show')),
+    ADD CONSTRAINT bookings_room_period_excl EXCLUDE USING gist (RoomID WITH =, Period WITH &&)
+        WHERE (Status IN ('pending_payment', 'confirmed', 'checked_in'));
+
+CREATE INDEX IF NOT EXISTS bookings_pending_hold_idx ON Bookings (HoldExpiresAt) WHERE Status = 'pending_payment';
+
+-- История переходов между статусами. FromStatus пустой у записи о создании бронирования,
+-- ActorID - ID гостя или владельца отеля, NULL для платёжной системы и самого сервиса
+CREATE TABLE IF NOT EXISTS booking_status_history (
+    ID BIGSERIAL PRIMARY KEY,
+    BookingID INT NOT NULL REFERENCES Bookings(ID) ON DELETE CASCADE,
+    FromStatus TEXT,
+    ToStatus TEXT NOT NULL,
+    Actor TEXT NOT NULL,
+    ActorID INT,
+    Reason TEXT NOT NULL DEFAULT '',
+    CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS booking_status_history_booking_idx ON booking_status_history (BookingID, CreatedAt, ID);
+
+-- История существующих бронирований восстанавливается по их отметкам времени
+INSERT INTO booking_status_history (BookingID, FromStatus, ToStatus, Actor, ActorID, Reason, CreatedAt)
+SELECT ID, NULL, 'pending_payment', 'guest', UserID, 'booking created', CreatedAt
+FROM Bookings;
+
+INSERT INTO booking_status_history (BookingID, FromStatus, ToStatus, Actor, ActorID, Reason, CreatedAt)
+SELECT ID, 'pending_payment', Status,
+       CASE Status WHEN 'cancelled' THEN 'guest' WHEN 'expired' THEN 'system' ELSE 'payment_system' END,
+       CASE Status WHEN 'cancelled' THEN UserID END,
+       'restored on migration',
+       COALESCE(StatusChangedAt, CancelledAt, UpdatedAt, CreatedAt)
+FROM Bookings
+WHERE Status <> 'pending_payment';
@@ -0,0 +1,9 @@
+-- Подписи принятых вебхуков PaymentSystem: повтор той же подписи отклоняется.
+-- Подписи старше допустимого расхождения времени удаляются, такие вебхуки и так не проходят проверку.
+CREATE TABLE IF NOT EXISTS payment_webhooks (
+    Signature  TEXT PRIMARY KEY,
+    SignedAt   TIMESTAMPTZ NOT NULL,
+    ReceivedAt TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS payment_webhooks_signed_idx ON payment_webhooks (SignedAt);
@@ -0,0 +1,6 @@
+-- Неявившийся гость занимает комнату до конца бронирования, пока владелец не освободит оставшиеся ночи
+ALTER TABLE Bookings DROP CONSTRAINT IF EXISTS bookings_room_period_excl;
+
+ALTER TABLE Bookings
+    ADD CONSTRAINT bookings_room_period_excl EXCLUDE USING gist (RoomID WITH =, Period WITH &&)
+        WHERE (Status IN ('pending_payment', 'confirmed', 'checked_in', 'no_show'));
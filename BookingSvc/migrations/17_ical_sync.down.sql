@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS room_blocks;
+DROP TABLE IF EXISTS ical_imports;
+DROP TABLE IF EXISTS ical_feeds;
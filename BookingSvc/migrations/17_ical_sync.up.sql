@@ -0,0 +1,47 @@
+-- Секретные ссылки на выгрузку занятости в формате iCalendar. RoomID NULL - весь отель.
+CREATE TABLE IF NOT EXISTS ical_feeds (
+    Token TEXT PRIMARY KEY,
+    HotelID INT NOT NULL,
+    RoomID INT,
+    CreatedBy INT NOT NULL,
+    CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS ical_feeds_hotel_idx ON ical_feeds (HotelID);
+
+-- Календари других площадок, события которых блокируют комнату
+CREATE TABLE IF NOT EXISTS ical_imports (
+    ID SERIAL PRIMARY KEY,
+    HotelID INT NOT NULL,
+    RoomID INT NOT NULL,
+    URL TEXT NOT NULL,
+    CreatedBy INT NOT NULL,
+    CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    LastSyncedAt TIMESTAMP WITH TIME ZONE,
+    LastError TEXT NOT NULL DEFAULT '',
+    UNIQUE (RoomID, URL)
+);
+
+CREATE INDEX IF NOT EXISTS ical_imports_hotel_idx ON ical_imports (HotelID);
+
+-- Периоды, когда комната недоступна без бронирования. Блокировки импорта заменяются целиком при каждой синхронизации.
+CREATE TABLE IF NOT EXISTS room_blocks (
+    ID BIGSERIAL PRIMARY KEY,
+    HotelID INT NOT NULL,
+    RoomID INT NOT NULL,
+    StartDate TIMESTAMP WITH TIME ZONE NOT NULL,
+    EndDate TIMESTAMP WITH TIME ZONE NOT NULL,
+    Period TSTZRANGE GENERATED ALWAYS AS (tstzrange(StartDate, EndDate, '[)')) STORED,
+    Source TEXT NOT NULL,
+    ImportID INT REFERENCES ical_imports(ID) ON DELETE CASCADE,
+    ExternalUID TEXT NOT NULL DEFAULT '',
+    Summary TEXT NOT NULL DEFAULT '',
+    CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT room_blocks_period_check CHECK (StartDate < EndDate)
+);
+
+CREATE INDEX IF NOT EXISTS room_blocks_room_period_idx ON room_blocks USING gist (RoomID, Period);
+
+CREATE INDEX IF NOT EXISTS room_blocks_hotel_period_idx ON room_blocks USING gist (HotelID, Period);
+
+CREATE INDEX IF NOT EXISTS room_blocks_import_idx ON room_blocks (ImportID);
@@ -0,0 +1,5 @@
+DELETE FROM room_blocks WHERE Source = 'maintenance';
+
+ALTER TABLE room_blocks
+    DROP COLUMN IF EXISTS CreatedBy,
+    DROP COLUMN IF EXISTS Reason;
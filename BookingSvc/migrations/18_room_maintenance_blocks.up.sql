@@ -0,0 +1,4 @@
+-- Блокировки, которые владелец отеля создаёт вручную: ремонт или личное использование комнаты
+ALTER TABLE room_blocks
+    ADD COLUMN IF NOT EXISTS Reason TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS CreatedBy INT;
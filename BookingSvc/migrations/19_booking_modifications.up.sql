@@ -0,0 +1,28 @@
+-- Изменения оплаченных бронирований, которые ждут доплаты. Бронирование остаётся подтверждённым
+-- на прежних условиях, новые условия переносятся в него только после успешной доплаты.
+CREATE TABLE IF NOT EXISTS booking_modifications (
+    ID SERIAL PRIMARY KEY,
+    BookingID INT NOT NULL REFERENCES Bookings(ID) ON DELETE CASCADE,
+    Status TEXT NOT NULL DEFAULT 'pending',
+    RoomID INT NOT NULL,
+    RoomDescription TEXT NOT NULL DEFAULT '',
+    RoomNumber INT NOT NULL DEFAULT 0,
+    CountOfPeople INT NOT NULL,
+    Children INT NOT NULL DEFAULT 0,
+    StartDate TIMESTAMP WITH TIME ZONE NOT NULL,
+    EndDate TIMESTAMP WITH TIME ZONE NOT NULL,
+    Amount INT NOT NULL,
+    Surcharge INT NOT NULL,
+    PriceBreakdown JSONB,
+    PromoCodeID INT,
+    HoldExpiresAt TIMESTAMP WITH TIME ZONE NOT NULL,
+    CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    ResolvedAt TIMESTAMP WITH TIME ZONE,
+    CONSTRAINT booking_modifications_status_check CHECK (Status IN ('pending', 'applied', 'payment_failed', 'expired', 'refunded')),
+    CONSTRAINT booking_modifications_surcharge_check CHECK (Surcharge > 0)
+);
+
+-- У бронирования одновременно ожидается не больше одной доплаты
+CREATE UNIQUE INDEX IF NOT EXISTS booking_modifications_pending_idx ON booking_modifications (BookingID) WHERE Status = 'pending';
+
+CREATE INDEX IF NOT EXISTS booking_modifications_hold_idx ON booking_modifications (HoldExpiresAt) WHERE Status = 'pending';
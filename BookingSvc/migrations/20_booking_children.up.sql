@@ -0,0 +1,3 @@
+-- Сколько из CountOfPeople гостей - дети. Прежние бронирования не различали детей, их гости считаются взрослыми.
+ALTER TABLE Bookings
+    ADD COLUMN Children INT NOT NULL DEFAULT 0;
@@ -0,0 +1,6 @@
+ALTER TABLE Bookings
+    DROP COLUMN IF EXISTS Amount,
+    DROP COLUMN IF EXISTS HotelName,
+    DROP COLUMN IF EXISTS RoomDescription,
+    DROP COLUMN IF EXISTS RoomNumber,
+    DROP COLUMN IF EXISTS CancelledAt;
@@ -0,0 +1,6 @@
+ALTER TABLE Bookings
+    ADD COLUMN Amount INT NOT NULL DEFAULT 0,
+    ADD COLUMN HotelName TEXT NOT NULL DEFAULT '',
+    ADD COLUMN RoomDescription TEXT NOT NULL DEFAULT '',
+    ADD COLUMN RoomNumber INT NOT NULL DEFAULT 0,
+    ADD COLUMN CancelledAt TIMESTAMP WITH TIME ZONE;
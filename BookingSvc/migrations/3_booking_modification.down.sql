@@ -0,0 +1,3 @@
+ALTER TABLE Bookings
+    DROP COLUMN IF EXISTS CountOfPeople,
+    DROP COLUMN IF EXISTS UpdatedAt;
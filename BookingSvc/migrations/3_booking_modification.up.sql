@@ -0,0 +1,3 @@
+ALTER TABLE Bookings
+    ADD COLUMN CountOfPeople INT NOT NULL DEFAULT 1,
+    ADD COLUMN UpdatedAt TIMESTAMP WITH TIME ZONE;
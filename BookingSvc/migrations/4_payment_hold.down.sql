@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS bookings_waiting_hold_idx;
+
+ALTER TABLE Bookings
+    DROP COLUMN IF EXISTS HoldExpiresAt,
+    DROP COLUMN IF EXISTS Username,
+    DROP COLUMN IF EXISTS ChatID;
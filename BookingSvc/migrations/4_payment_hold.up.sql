@@ -0,0 +1,6 @@
+ALTER TABLE Bookings
+    ADD COLUMN HoldExpiresAt TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN Username TEXT NOT NULL DEFAULT '',
+    ADD COLUMN ChatID TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS bookings_waiting_hold_idx ON Bookings (HoldExpiresAt) WHERE Status = 'waiting';
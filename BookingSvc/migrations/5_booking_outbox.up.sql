@@ -0,0 +1,15 @@
+-- DeadAt - время, когда сообщение исчерпало попытки отправки: больше оно не отправляется и ждёт разбора вручную
+CREATE TABLE IF NOT EXISTS booking_outbox (
+    ID BIGSERIAL PRIMARY KEY,
+    Recipient TEXT NOT NULL,
+    Payload JSONB NOT NULL,
+    Attempts INT NOT NULL DEFAULT 0,
+    LastError TEXT,
+    NextAttemptAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CreatedAt TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    SentAt TIMESTAMP WITH TIME ZONE,
+    DeadAt TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS booking_outbox_pending_idx ON booking_outbox (NextAttemptAt, ID) WHERE SentAt IS NULL AND DeadAt IS NULL;
+CREATE INDEX IF NOT EXISTS booking_outbox_dead_idx ON booking_outbox (DeadAt) WHERE DeadAt IS NOT NULL;
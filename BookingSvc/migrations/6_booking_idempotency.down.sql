@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS bookings_user_idempotency_key_idx;
+
+ALTER TABLE Bookings
+    DROP COLUMN IF EXISTS IdempotencyKey;
@@ -0,0 +1,4 @@
+ALTER TABLE Bookings
+    ADD COLUMN IdempotencyKey TEXT;
+
+CREATE UNIQUE INDEX IF NOT EXISTS bookings_user_idempotency_key_idx ON Bookings (UserID, IdempotencyKey) WHERE IdempotencyKey IS NOT NULL;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS bookings_hotel_period_idx;
+
+ALTER TABLE Bookings
+    DROP CONSTRAINT IF EXISTS bookings_room_period_excl,
+    DROP CONSTRAINT IF EXISTS bookings_period_check,
+    DROP COLUMN IF EXISTS Period;
@@ -0,0 +1,57 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Бронирования с пустым или обратным периодом не проходят bookings_period_check.
+-- Исправить их автоматически нельзя, поэтому миграция останавливается и называет их.
+DO $$
+DECLARE
+    invalid TEXT;
+BEGIN
+    SELECT string_agg(ID::TEXT, ', ' ORDER BY ID) INTO invalid
+    FROM Bookings
+    WHERE StartDate >= EndDate;
+    IF invalid IS NOT NULL THEN
+        RAISE EXCEPTION 'bookings with StartDate >= EndDate: %', invalid
+            USING HINT = 'fix the dates or cancel these bookings and run the migration again';
+    END IF;
+END $$;
+
+-- До ограничения двойные бронирования были возможны. Неоплаченная бронь, пересекающаяся с оплаченной
+-- или с более ранней неоплаченной, снимается как истёкшая: гость ничего не платил, а комнату держит другой.
+UPDATE Bookings b
+SET Status = 'expired', HoldExpiresAt = NULL, UpdatedAt = NOW()
+WHERE b.Status = 'waiting'
+  AND EXISTS (
+    SELECT 1 FROM Bookings o
+    WHERE o.RoomID = b.RoomID
+      AND o.ID <> b.ID
+      AND (o.Status = 'success' OR (o.Status = 'waiting' AND o.ID < b.ID))
+      AND tstzrange(o.StartDate, o.EndDate, '[)') && tstzrange(b.StartDate, b.EndDate, '[)')
+  );
+
+-- Пересечение двух оплаченных бронирований требует решения владельца отеля и возврата одному из гостей.
+-- Миграция останавливается и перечисляет такие пары: после отмены лишних бронирований её нужно запустить снова.
+DO $$
+DECLARE
+    conflicts TEXT;
+BEGIN
+    SELECT string_agg(a.ID || ' and ' || b.ID || ' (room ' || a.RoomID || ')', ', ' ORDER BY a.ID, b.ID) INTO conflicts
+    FROM Bookings a
+    JOIN Bookings b ON b.RoomID = a.RoomID AND b.ID > a.ID
+    WHERE a.Status = 'success' AND b.Status = 'success'
+      AND tstzrange(a.StartDate, a.EndDate, '[)') && tstzrange(b.StartDate, b.EndDate, '[)');
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'paid bookings overlap: %', conflicts
+            USING HINT = 'cancel and refund one booking of each pair, then run the migration again';
+    END IF;
+END $$;
+
+-- Период проживания [StartDate, EndDate): день выезда свободен для следующего гостя
+ALTER TABLE Bookings
+    ADD COLUMN Period TSTZRANGE GENERATED ALWAYS AS (tstzrange(StartDate, EndDate, '[)')) STORED;
+
+ALTER TABLE Bookings
+    ADD CONSTRAINT bookings_period_check CHECK (StartDate < EndDate),
+    ADD CONSTRAINT bookings_room_period_excl EXCLUDE USING gist (RoomID WITH =, Period WITH &&)
+        WHERE (Status IN ('waiting', 'success'));
+
+CREATE INDEX IF NOT EXISTS bookings_hotel_period_idx ON Bookings USING gist (HotelID, Period);
@@ -0,0 +1,3 @@
+-- Расчёт стоимости по ночам на момент бронирования или последнего изменения
+ALTER TABLE Bookings
+    ADD COLUMN PriceBreakdown JSONB;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS bookings_promo_code_idx;
+
+ALTER TABLE Bookings
+    DROP COLUMN IF EXISTS PromoCodeID;
@@ -0,0 +1,6 @@
+-- Промокод из HotelSvc, применённый к бронированию.
+-- Использования кода считаются по активным бронированиям с ним.
+ALTER TABLE Bookings
+    ADD COLUMN PromoCodeID INT;
+
+CREATE INDEX IF NOT EXISTS bookings_promo_code_idx ON Bookings (PromoCodeID, UserID) WHERE PromoCodeID IS NOT NULL;
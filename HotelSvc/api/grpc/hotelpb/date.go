@@ -0,0 +1,4 @@
+package hotelpb
+
+// DateLayout - формат дат заезда и выезда в GetPriceQuotesRequest и NightPrice
+const DateLayout = "2006-01-02"
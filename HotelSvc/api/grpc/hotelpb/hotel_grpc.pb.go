@@ -21,6 +21,10 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	HotelService_GetRoomsByHotelId_FullMethodName   = "/hotelpb.HotelService/GetRoomsByHotelId"
 	HotelService_GetOwnerIdByHotelId_FullMethodName = "/hotelpb.HotelService/GetOwnerIdByHotelId"
+	HotelService_GetRoomDetails_FullMethodName      = "/hotelpb.HotelService/GetRoomDetails"
+	HotelService_GetPriceQuotes_FullMethodName      = "/hotelpb.HotelService/GetPriceQuotes"
+	HotelService_GetPromoCode_FullMethodName        = "/hotelpb.HotelService/GetPromoCode"
+	HotelService_SearchHotels_FullMethodName        = "/hotelpb.HotelService/SearchHotels"
 )
 
 // HotelServiceClient is the client API for HotelService service.
@@ -29,6 +33,14 @@ const (
 type HotelServiceClient interface {
 	GetRoomsByHotelId(ctx context.Context, in *GetRoomsRequest, opts ...grpc.CallOption) (*GetRoomsResponse, error)
 	GetOwnerIdByHotelId(ctx context.Context, in *GetOwnerIdRequest, opts ...grpc.CallOption) (*GetOwnerIdResponse, error)
+	// Данные комнаты для расчёта стоимости бронирования, NOT_FOUND если комнаты нет в отеле
+	GetRoomDetails(ctx context.Context, in *GetRoomDetailsRequest, opts ...grpc.CallOption) (*GetRoomDetailsResponse, error)
+	// Стоимость проживания по ночам, NOT_FOUND если какой-то комнаты нет в отеле
+	GetPriceQuotes(ctx context.Context, in *GetPriceQuotesRequest, opts ...grpc.CallOption) (*GetPriceQuotesResponse, error)
+	// Действующий промокод отеля, NOT_FOUND если кода нет или он отключён
+	GetPromoCode(ctx context.Context, in *GetPromoCodeRequest, opts ...grpc.CallOption) (*GetPromoCodeResponse, error)
+	// Отели города вместе с их комнатами, INVALID_ARGUMENT если город не задан
+	SearchHotels(ctx context.Context, in *SearchHotelsRequest, opts ...grpc.CallOption) (*SearchHotelsResponse, error)
 }
 
 type hotelServiceClient struct {
@@ -59,12 +71,60 @@ func (c *hotelServiceClient) GetOwnerIdByHotelId(ctx context.Context, in *GetOwn
 	return out, nil
 }
 
+func (c *hotelServiceClient) GetRoomDetails(ctx context.Context, in *GetRoomDetailsRequest, opts ...grpc.CallOption) (*GetRoomDetailsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDetailsResponse)
+	err := c.cc.Invoke(ctx, HotelService_GetRoomDetails_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hotelServiceClient) GetPriceQuotes(ctx context.Context, in *GetPriceQuotesRequest, opts ...grpc.CallOption) (*GetPriceQuotesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPriceQuotesResponse)
+	err := c.cc.Invoke(ctx, HotelService_GetPriceQuotes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hotelServiceClient) GetPromoCode(ctx context.Context, in *GetPromoCodeRequest, opts ...grpc.CallOption) (*GetPromoCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPromoCodeResponse)
+	err := c.cc.Invoke(ctx, HotelService_GetPromoCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hotelServiceClient) SearchHotels(ctx context.Context, in *SearchHotelsRequest, opts ...grpc.CallOption) (*SearchHotelsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchHotelsResponse)
+	err := c.cc.Invoke(ctx, HotelService_SearchHotels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HotelServiceServer is the server API for HotelService service.
 // All implementations must embed UnimplementedHotelServiceServer
 // for forward compatibility.
 type HotelServiceServer interface {
 	GetRoomsByHotelId(context.Context, *GetRoomsRequest) (*GetRoomsResponse, error)
 	GetOwnerIdByHotelId(context.Context, *GetOwnerIdRequest) (*GetOwnerIdResponse, error)
+	// Данные комнаты для расчёта стоимости бронирования, NOT_FOUND если комнаты нет в отеле
+	GetRoomDetails(context.Context, *GetRoomDetailsRequest) (*GetRoomDetailsResponse, error)
+	// Стоимость проживания по ночам, NOT_FOUND если какой-то комнаты нет в отеле
+	GetPriceQuotes(context.Context, *GetPriceQuotesRequest) (*GetPriceQuotesResponse, error)
+	// Действующий промокод отеля, NOT_FOUND если кода нет или он отключён
+	GetPromoCode(context.Context, *GetPromoCodeRequest) (*GetPromoCodeResponse, error)
+	// Отели города вместе с их комнатами, INVALID_ARGUMENT если город не задан
+	SearchHotels(context.Context, *SearchHotelsRequest) (*SearchHotelsResponse, error)
 	mustEmbedUnimplementedHotelServiceServer()
 }
 
@@ -81,6 +141,18 @@ func (UnimplementedHotelServiceServer) GetRoomsByHotelId(context.Context, *GetRo
 func (UnimplementedHotelServiceServer) GetOwnerIdByHotelId(context.Context, *GetOwnerIdRequest) (*GetOwnerIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOwnerIdByHotelId not implemented")
 }
+func (UnimplementedHotelServiceServer) GetRoomDetails(context.Context, *GetRoomDetailsRequest) (*GetRoomDetailsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoomDetails not implemented")
+}
+func (UnimplementedHotelServiceServer) GetPriceQuotes(context.Context, *GetPriceQuotesRequest) (*GetPriceQuotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPriceQuotes not implemented")
+}
+func (UnimplementedHotelServiceServer) GetPromoCode(context.Context, *GetPromoCodeRequest) (*GetPromoCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPromoCode not implemented")
+}
+func (UnimplementedHotelServiceServer) SearchHotels(context.Context, *SearchHotelsRequest) (*SearchHotelsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchHotels not implemented")
+}
 func (UnimplementedHotelServiceServer) mustEmbedUnimplementedHotelServiceServer() {}
 func (UnimplementedHotelServiceServer) testEmbeddedByValue()                      {}
 
@@ -138,6 +210,78 @@ func _HotelService_GetOwnerIdByHotelId_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HotelService_GetRoomDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HotelServiceServer).GetRoomDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HotelService_GetRoomDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HotelServiceServer).GetRoomDetails(ctx, req.(*GetRoomDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HotelService_GetPriceQuotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPriceQuotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HotelServiceServer).GetPriceQuotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HotelService_GetPriceQuotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HotelServiceServer).GetPriceQuotes(ctx, req.(*GetPriceQuotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HotelService_GetPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HotelServiceServer).GetPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HotelService_GetPromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HotelServiceServer).GetPromoCode(ctx, req.(*GetPromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HotelService_SearchHotels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchHotelsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HotelServiceServer).SearchHotels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HotelService_SearchHotels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HotelServiceServer).SearchHotels(ctx, req.(*SearchHotelsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HotelService_ServiceDesc is the grpc.ServiceDesc for HotelService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +297,22 @@ var HotelService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOwnerIdByHotelId",
 			Handler:    _HotelService_GetOwnerIdByHotelId_Handler,
 		},
+		{
+			MethodName: "GetRoomDetails",
+			Handler:    _HotelService_GetRoomDetails_Handler,
+		},
+		{
+			MethodName: "GetPriceQuotes",
+			Handler:    _HotelService_GetPriceQuotes_Handler,
+		},
+		{
+			MethodName: "GetPromoCode",
+			Handler:    _HotelService_GetPromoCode_Handler,
+		},
+		{
+			MethodName: "SearchHotels",
+			Handler:    _HotelService_SearchHotels_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "hotel.proto",
@@ -2,14 +2,25 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/myerror"
 	"github.com/Quizert/room-reservation-system/HotelSvc/internal/service"
 	"net/http"
+	"strconv"
 )
 
 type HotelHandler struct {
-	hotelService *service.HotelService
-	roomService  *service.RoomService
+	hotelService     *service.HotelService
+	roomService      *service.RoomService
+	pricingService   *service.PricingService
+	promoCodeService *service.PromoCodeService
+}
+
+// deactivatePromoCodeRequest - тело запроса на отключение промокода
+type deactivatePromoCodeRequest struct {
+	HotelID int `json:"hotel_id"`
+	ID      int `json:"id"`
 }
 
 // GetHotels - обработчик для получения списка отелей
@@ -93,6 +104,32 @@ func (h *HotelHandler) AddRoomType(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err := h.roomService.AddRoomType(roomType); err != nil {
+			if errors.Is(err, myerror.ErrInvalidCapacity) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// AddRateSeason - обработчик для добавления сезонной цены типа комнаты
+func (h *HotelHandler) AddRateSeason(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		var season models.RateSeason
+		if err := json.NewDecoder(r.Body).Decode(&season); err != nil {
+			http.Error(w, "Invalid input", http.StatusBadRequest)
+			return
+		}
+		if err := h.pricingService.AddRateSeason(r.Context(), season); err != nil {
+			if errors.Is(err, myerror.ErrInvalidRatePlan) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
 			http.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
@@ -101,3 +138,110 @@ func (h *HotelHandler) AddRoomType(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 	}
 }
+
+// AddStayDiscount - обработчик для добавления скидки за длительное проживание
+func (h *HotelHandler) AddStayDiscount(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		var discount models.StayDiscount
+		if err := json.NewDecoder(r.Body).Decode(&discount); err != nil {
+			http.Error(w, "Invalid input", http.StatusBadRequest)
+			return
+		}
+		if err := h.pricingService.AddStayDiscount(r.Context(), discount); err != nil {
+			if errors.Is(err, myerror.ErrInvalidRatePlan) {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	} else {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// GetPromoCodes - обработчик для получения промокодов отеля его владельцем
+func (h *HotelHandler) GetPromoCodes(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		ctx := r.Context()
+		ownerID := ctx.Value("user_id").(int)
+
+		hotelID, err := strconv.Atoi(r.URL.Query().Get("hotel_id"))
+		if err != nil {
+			http.Error(w, "Invalid hotel_id", http.StatusBadRequest)
+			return
+		}
+		promoCodes, err := h.promoCodeService.GetPromoCodes(ctx, ownerID, hotelID)
+		if err != nil {
+			writePromoCodeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(promoCodes)
+	} else {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// AddPromoCode - обработчик для добавления промокода в отель
+func (h *HotelHandler) AddPromoCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		ctx := r.Context()
+		ownerID := ctx.Value("user_id").(int)
+
+		var promo models.PromoCode
+		if err := json.NewDecoder(r.Body).Decode(&promo); err != nil {
+			http.Error(w, "Invalid input", http.StatusBadRequest)
+			return
+		}
+		id, err := h.promoCodeService.AddPromoCode(ctx, ownerID, promo)
+		if err != nil {
+			writePromoCodeError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]int{"id": id})
+	} else {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// DeactivatePromoCode - обработчик для отключения промокода
+func (h *HotelHandler) DeactivatePromoCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "POST" {
+		ctx := r.Context()
+		ownerID := ctx.Value("user_id").(int)
+
+		var request deactivatePromoCodeRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, "Invalid input", http.StatusBadRequest)
+			return
+		}
+		if err := h.promoCodeService.DeactivatePromoCode(ctx, ownerID, request.HotelID, request.ID); err != nil {
+			writePromoCodeError(w, err)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	} else {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writePromoCodeError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, myerror.ErrInvalidPromoCode):
+		http.Error(w, "Invalid promo code", http.StatusBadRequest)
+	case errors.Is(err, myerror.ErrNotHotelOwner):
+		http.Error(w, "You are not the owner of the hotel", http.StatusForbidden)
+	case errors.Is(err, myerror.ErrHotelNotFound):
+		http.Error(w, "Hotel not found", http.StatusNotFound)
+	case errors.Is(err, myerror.ErrPromoCodeNotFound):
+		http.Error(w, "Promo code not found", http.StatusNotFound)
+	case errors.Is(err, myerror.ErrPromoCodeExists):
+		http.Error(w, "Promo code already exists", http.StatusConflict)
+	default:
+		http.Error(w, "Failed to process promo code", http.StatusInternalServerError)
+	}
+}
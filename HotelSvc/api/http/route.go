@@ -6,13 +6,18 @@ import (
 	"net/http"
 )
 
-func RegisterHotelRoutes(mux *http.ServeMux, hotelService *service.HotelService, roomService *service.RoomService) {
-	handler := &HotelHandler{hotelService: hotelService, roomService: roomService}
+func RegisterHotelRoutes(mux *http.ServeMux, hotelService *service.HotelService, roomService *service.RoomService, pricingService *service.PricingService, promoCodeService *service.PromoCodeService) {
+	handler := &HotelHandler{hotelService: hotelService, roomService: roomService, pricingService: pricingService, promoCodeService: promoCodeService}
 
 	middlewareHandler := middleware.NewMiddleware("LUIGI")
-	mux.HandleFunc("/hotels", handler.GetHotels)                                       // GET - список отелей
-	mux.HandleFunc("/add_hotel", middlewareHandler.Auth(handler.AddHotel, true))       // POST - добавление отеля
-	mux.HandleFunc("/update_hotel", middlewareHandler.Auth(handler.UpdateHotel, true)) // PUT - обновление отеля
-	mux.HandleFunc("/add_room", middlewareHandler.Auth(handler.AddRoom, true)) // POST - добавление комнаты в отель
-	mux.HandleFunc("/add_room_type", middlewareHandler.Auth(handler.AddRoomType, true)) // POST - добавление типа комнаты
+	mux.HandleFunc("/hotels", handler.GetHotels)                                                        // GET - список отелей
+	mux.HandleFunc("/add_hotel", middlewareHandler.Auth(handler.AddHotel, true))                        // POST - добавление отеля
+	mux.HandleFunc("/update_hotel", middlewareHandler.Auth(handler.UpdateHotel, true))                  // PUT - обновление отеля
+	mux.HandleFunc("/add_room", middlewareHandler.Auth(handler.AddRoom, true))                          // POST - добавление комнаты в отель
+	mux.HandleFunc("/add_room_type", middlewareHandler.Auth(handler.AddRoomType, true))                 // POST - добавление типа комнаты
+	mux.HandleFunc("/add_rate_season", middlewareHandler.Auth(handler.AddRateSeason, true))             // POST - сезонная цена типа комнаты
+	mux.HandleFunc("/add_stay_discount", middlewareHandler.Auth(handler.AddStayDiscount, true))         // POST - скидка за длительность проживания
+	mux.HandleFunc("/promo_codes", middlewareHandler.Auth(handler.GetPromoCodes, true))                 // GET - промокоды отеля
+	mux.HandleFunc("/add_promo_code", middlewareHandler.Auth(handler.AddPromoCode, true))               // POST - добавление промокода
+	mux.HandleFunc("/deactivate_promo_code", middlewareHandler.Auth(handler.DeactivatePromoCode, true)) // POST - отключение промокода
 }
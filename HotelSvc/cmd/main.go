@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
 	"github.com/Quizert/room-reservation-system/HotelSvc/internal/myerror"
 	postgresql2 "github.com/Quizert/room-reservation-system/HotelSvc/internal/repository/postgresql"
 	service2 "github.com/Quizert/room-reservation-system/HotelSvc/internal/service"
@@ -15,6 +16,7 @@ import (
 	"net/http"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
 	handler "github.com/Quizert/room-reservation-system/HotelSvc/api/http"
@@ -49,12 +51,20 @@ func main() {
 	ownerRepo := postgresql2.NewPostgresOwnerRepository(db)
 
 	ownerService := service2.NewOwnerService(ownerRepo)
+
+	ratePlanRepo := postgresql2.NewPostgresRatePlanRepository(db)
+
+	pricingService := service2.NewPricingService(ratePlanRepo)
+
+	promoCodeRepo := postgresql2.NewPostgresPromoCodeRepository(db)
+
+	promoCodeService := service2.NewPromoCodeService(promoCodeRepo, ownerRepo)
 	var wg sync.WaitGroup
 	wg.Add(2)
 
 	go func() {
 		defer wg.Done()
-		if err := startHTTPServer(hotelService, roomService); err != nil {
+		if err := startHTTPServer(hotelService, roomService, pricingService, promoCodeService); err != nil {
 			log.Fatalf("Failed to start HTTP server: %v", err)
 		}
 	}()
@@ -62,7 +72,7 @@ func main() {
 	// Запуск gRPC сервера в отдельной горутине
 	go func() {
 		defer wg.Done()
-		if err := startGRPCServer(roomService, ownerService); err != nil {
+		if err := startGRPCServer(hotelService, roomService, ownerService, pricingService, promoCodeService); err != nil {
 			log.Fatalf("Failed to start gRPC server: %v", err)
 		}
 	}()
@@ -93,9 +103,9 @@ func initDB() (*sql.DB, error) {
 }
 
 // startHTTPServer запускает HTTP сервер для обработки REST-запросов
-func startHTTPServer(hotelService *service2.HotelService, roomService *service2.RoomService) error {
+func startHTTPServer(hotelService *service2.HotelService, roomService *service2.RoomService, pricingService *service2.PricingService, promoCodeService *service2.PromoCodeService) error {
 	mux := http.NewServeMux()
-	handler.RegisterHotelRoutes(mux, hotelService, roomService)
+	handler.RegisterHotelRoutes(mux, hotelService, roomService, pricingService, promoCodeService)
 
 	addr := ":" + os.Getenv("HOTEL_HTTP_PORT")
 	log.Printf("Starting HTTP server on %s...", addr)
@@ -104,8 +114,11 @@ func startHTTPServer(hotelService *service2.HotelService, roomService *service2.
 
 type server struct {
 	hotelpb.UnimplementedHotelServiceServer
-	roomService  *service2.RoomService
-	ownerService *service2.OwnerService
+	hotelService     *service2.HotelService
+	roomService      *service2.RoomService
+	ownerService     *service2.OwnerService
+	pricingService   *service2.PricingService
+	promoCodeService *service2.PromoCodeService
 }
 
 func (s *server) GetRoomsByHotelId(ctx context.Context, req *hotelpb.GetRoomsRequest) (*hotelpb.GetRoomsResponse, error) {
@@ -131,7 +144,141 @@ func (s *server) GetOwnerIdByHotelId(ctx context.Context, req *hotelpb.GetOwnerI
 	return &hotelpb.GetOwnerIdResponse{OwnerId: int32(ownerId)}, nil
 }
 
-func startGRPCServer(roomService *service2.RoomService, ownerService *service2.OwnerService) error {
+func (s *server) GetRoomDetails(ctx context.Context, req *hotelpb.GetRoomDetailsRequest) (*hotelpb.GetRoomDetailsResponse, error) {
+	details, err := s.roomService.GetRoomDetails(ctx, int(req.GetHotelId()), int(req.GetRoomId()))
+	if err != nil {
+		if errors.Is(err, myerror.ErrRoomNotFound) {
+			return nil, status.Error(codes.NotFound, "room not found")
+		}
+		return nil, fmt.Errorf("in server: %w", err)
+	}
+	return details, nil
+}
+
+func (s *server) GetPriceQuotes(ctx context.Context, req *hotelpb.GetPriceQuotesRequest) (*hotelpb.GetPriceQuotesResponse, error) {
+	checkIn, err := time.Parse(hotelpb.DateLayout, req.GetStartDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid start date")
+	}
+	checkOut, err := time.Parse(hotelpb.DateLayout, req.GetEndDate())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid end date")
+	}
+	roomIDs := make([]int, 0, len(req.GetRoomIds()))
+	for _, id := range req.GetRoomIds() {
+		roomIDs = append(roomIDs, int(id))
+	}
+
+	quotes, err := s.pricingService.GetPriceQuotes(ctx, int(req.GetHotelId()), roomIDs, checkIn, checkOut, int(req.GetGuests()))
+	if err != nil {
+		switch {
+		case errors.Is(err, myerror.ErrRoomNotFound):
+			return nil, status.Error(codes.NotFound, "room not found")
+		case errors.Is(err, myerror.ErrInvalidStay):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("in server: %w", err)
+	}
+
+	response := &hotelpb.GetPriceQuotesResponse{Quotes: make([]*hotelpb.PriceQuote, 0, len(quotes))}
+	for _, quote := range quotes {
+		response.Quotes = append(response.Quotes, toPriceQuotePb(quote))
+	}
+	return response, nil
+}
+
+func toPriceQuotePb(quote *models.PriceQuote) *hotelpb.PriceQuote {
+	nights := make([]*hotelpb.NightPrice, 0, len(quote.Nights))
+	for _, night := range quote.Nights {
+		nights = append(nights, &hotelpb.NightPrice{
+			Date:            night.Date.Format(hotelpb.DateLayout),
+			Rate:            night.Rate,
+			Price:           int32(night.Price),
+			ExtraGuestPrice: int32(night.ExtraGuestPrice),
+		})
+	}
+	return &hotelpb.PriceQuote{
+		RoomId:          int32(quote.RoomID),
+		Nights:          nights,
+		Subtotal:        int32(quote.Subtotal),
+		DiscountPercent: int32(quote.DiscountPercent),
+		Discount:        int32(quote.Discount),
+		Total:           int32(quote.Total),
+	}
+}
+
+func (s *server) GetPromoCode(ctx context.Context, req *hotelpb.GetPromoCodeRequest) (*hotelpb.GetPromoCodeResponse, error) {
+	promo, err := s.promoCodeService.GetActivePromoCode(ctx, int(req.GetHotelId()), req.GetCode())
+	if err != nil {
+		if errors.Is(err, myerror.ErrPromoCodeNotFound) {
+			return nil, status.Error(codes.NotFound, "promo code not found")
+		}
+		return nil, fmt.Errorf("in server: %w", err)
+	}
+	return &hotelpb.GetPromoCodeResponse{PromoCode: toPromoCodePb(promo)}, nil
+}
+
+func toPromoCodePb(promo *models.PromoCode) *hotelpb.PromoCode {
+	result := &hotelpb.PromoCode{
+		Id:               int32(promo.ID),
+		HotelId:          int32(promo.HotelID),
+		Code:             promo.Code,
+		DiscountType:     promo.DiscountType,
+		Value:            int32(promo.Value),
+		ValidFrom:        promo.ValidFrom.Format(time.RFC3339),
+		ValidUntil:       promo.ValidUntil.Format(time.RFC3339),
+		FirstBookingOnly: promo.FirstBookingOnly,
+	}
+	if promo.StayFrom != nil {
+		result.StayFrom = promo.StayFrom.Format(hotelpb.DateLayout)
+	}
+	if promo.StayUntil != nil {
+		result.StayUntil = promo.StayUntil.Format(hotelpb.DateLayout)
+	}
+	if promo.UsageLimit != nil {
+		result.UsageLimit = int32(*promo.UsageLimit)
+	}
+	if promo.PerUserLimit != nil {
+		result.PerUserLimit = int32(*promo.PerUserLimit)
+	}
+	return result
+}
+
+func (s *server) SearchHotels(ctx context.Context, req *hotelpb.SearchHotelsRequest) (*hotelpb.SearchHotelsResponse, error) {
+	hotels, err := s.hotelService.GetHotelsByCity(ctx, req.GetCity())
+	if err != nil {
+		if errors.Is(err, myerror.ErrInvalidSearch) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, fmt.Errorf("in server: %w", err)
+	}
+	hotelIDs := make([]int, 0, len(hotels))
+	for _, hotel := range hotels {
+		hotelIDs = append(hotelIDs, hotel.Id)
+	}
+	rooms, err := s.roomService.GetRoomsByHotelIDs(ctx, hotelIDs)
+	if err != nil {
+		return nil, fmt.Errorf("in server: %w", err)
+	}
+	roomsByHotel := make(map[int32][]*hotelpb.Room, len(hotels))
+	for _, room := range rooms {
+		roomsByHotel[room.HotelId] = append(roomsByHotel[room.HotelId], room)
+	}
+
+	response := &hotelpb.SearchHotelsResponse{Hotels: make([]*hotelpb.HotelWithRooms, 0, len(hotels))}
+	for _, hotel := range hotels {
+		response.Hotels = append(response.Hotels, &hotelpb.HotelWithRooms{
+			Id:      int32(hotel.Id),
+			Name:    hotel.Name,
+			City:    hotel.City,
+			Address: hotel.Address,
+			Rooms:   roomsByHotel[int32(hotel.Id)],
+		})
+	}
+	return response, nil
+}
+
+func startGRPCServer(hotelService *service2.HotelService, roomService *service2.RoomService, ownerService *service2.OwnerService, pricingService *service2.PricingService, promoCodeService *service2.PromoCodeService) error {
 	addr := ":" + os.Getenv("HOTEL_GRPC_PORT")
 	lis, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -139,7 +286,7 @@ func startGRPCServer(roomService *service2.RoomService, ownerService *service2.O
 	}
 
 	s := grpc.NewServer()
-	hotelpb.RegisterHotelServiceServer(s, &server{roomService: roomService, ownerService: ownerService})
+	hotelpb.RegisterHotelServiceServer(s, &server{hotelService: hotelService, roomService: roomService, ownerService: ownerService, pricingService: pricingService, promoCodeService: promoCodeService})
 
 	reflection.Register(s)
 
@@ -6,4 +6,6 @@ type Hotel struct {
 	OwnerId     int    `json:"OwnerId"`
 	Name        string `json:"name"`
 	Description string `json:"description"`
+	City        string `json:"city"`
+	Address     string `json:"address"`
 }
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Типы скидки промокода
+const (
+	DiscountPercent = "percent"
+	DiscountFixed   = "fixed"
+)
+
+// PromoCode - промокод отеля. Незаданные StayFrom/StayUntil, UsageLimit и PerUserLimit не ограничивают применение.
+type PromoCode struct {
+	ID               int        `json:"id"`
+	HotelID          int        `json:"hotel_id"`
+	Code             string     `json:"code"`
+	DiscountType     string     `json:"discount_type"`
+	Value            int        `json:"value"` // Процент или сумма скидки
+	ValidFrom        time.Time  `json:"valid_from"`
+	ValidUntil       time.Time  `json:"valid_until"`
+	StayFrom         *time.Time `json:"stay_from"`
+	StayUntil        *time.Time `json:"stay_until"`
+	UsageLimit       *int       `json:"usage_limit"`
+	PerUserLimit     *int       `json:"per_user_limit"`
+	FirstBookingOnly bool       `json:"first_booking_only"`
+	Active           bool       `json:"active"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
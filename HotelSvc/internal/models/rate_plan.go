@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// RateSeason задаёт цену ночи типа комнаты на период [StartDate, EndDate).
+type RateSeason struct {
+	ID           int       `json:"id"`
+	RoomTypeID   int       `json:"room_type_id"`
+	Name         string    `json:"name"`
+	StartDate    time.Time `json:"start_date"`
+	EndDate      time.Time `json:"end_date"`
+	Price        int       `json:"price"`
+	WeekendPrice *int      `json:"weekend_price"`
+}
+
+// StayDiscount - скидка в процентах при проживании от MinNights ночей.
+type StayDiscount struct {
+	ID         int `json:"id"`
+	RoomTypeID int `json:"room_type_id"`
+	MinNights  int `json:"min_nights"`
+	Percent    int `json:"percent"`
+}
+
+// RatePlan - полный тариф комнаты, по которому считается стоимость проживания.
+type RatePlan struct {
+	RoomID        int
+	RoomType      RoomType
+	Seasons       []RateSeason
+	StayDiscounts []StayDiscount
+}
+
+// NightPrice - стоимость одной ночи.
+type NightPrice struct {
+	Date            time.Time
+	Rate            string // base, weekend или название сезона
+	Price           int
+	ExtraGuestPrice int
+}
+
+// PriceQuote - расчёт стоимости проживания в комнате.
+type PriceQuote struct {
+	RoomID          int
+	Nights          []NightPrice
+	Subtotal        int
+	DiscountPercent int
+	Discount        int
+	Total           int
+}
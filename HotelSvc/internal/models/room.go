@@ -8,10 +8,21 @@ type Room struct {
 	Number     int `json:"number"`
 }
 
-// RoomType описывает тип комнаты и её базовую стоимость.
+// RoomType описывает тип комнаты и её тариф.
 type RoomType struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	BasePrice   int    `json:"base_price"`
+	ID               int    `json:"id"`
+	Name             string `json:"name"`
+	Description      string `json:"description"`
+	BasePrice        int    `json:"base_price"`        // Цена ночи за BaseOccupancy гостей
+	WeekendPrice     *int   `json:"weekend_price"`     // Цена ночи с пятницы и субботы, если отличается
+	BaseOccupancy    int    `json:"base_occupancy"`    // Сколько гостей входит в BasePrice
+	ExtraGuestPrice  int    `json:"extra_guest_price"` // Доплата за ночь за каждого гостя сверх BaseOccupancy
+	MaxAdults        int    `json:"max_adults"`        // Сколько взрослых можно разместить, по умолчанию не меньше BaseOccupancy
+	MaxChildren      int    `json:"max_children"`      // Сколько детей можно разместить дополнительно к взрослым
+	BedConfiguration string `json:"bed_configuration"` // Кровати в комнате, например "1 double, 1 sofa"
+}
+
+// Capacity - сколько всего гостей вмещает тип комнаты
+func (roomType RoomType) Capacity() int {
+	return roomType.MaxAdults + roomType.MaxChildren
 }
@@ -3,5 +3,15 @@ package myerror
 import "errors"
 
 var (
-	ErrHotelNotFound = errors.New("hotel Not Found")
+	ErrHotelNotFound   = errors.New("hotel Not Found")
+	ErrRoomNotFound    = errors.New("room Not Found")
+	ErrInvalidStay     = errors.New("invalid stay dates or guests")
+	ErrInvalidRatePlan = errors.New("invalid rate plan")
+	ErrInvalidSearch   = errors.New("city is required for hotel search")
+	ErrInvalidCapacity = errors.New("invalid room capacity")
+
+	ErrNotHotelOwner     = errors.New("you are not the owner of the hotel")
+	ErrInvalidPromoCode  = errors.New("invalid promo code")
+	ErrPromoCodeExists   = errors.New("promo code already exists")
+	ErrPromoCodeNotFound = errors.New("promo code not found")
 )
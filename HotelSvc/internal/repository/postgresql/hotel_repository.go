@@ -1,8 +1,10 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
 )
 
@@ -15,7 +17,7 @@ func NewPostgresHotelRepository(db *sql.DB) *PostgresHotelRepository {
 }
 
 func (repo *PostgresHotelRepository) GetAllHotels() ([]models.Hotel, error) {
-	rows, err := repo.db.Query("SELECT Id, OwnerId, Name, Description FROM hotels")
+	rows, err := repo.db.Query("SELECT Id, OwnerId, Name, Description, City, Address FROM hotels")
 	if err != nil {
 		return nil, err
 	}
@@ -24,7 +26,7 @@ func (repo *PostgresHotelRepository) GetAllHotels() ([]models.Hotel, error) {
 	var hotels []models.Hotel
 	for rows.Next() {
 		var hotel models.Hotel
-		if err := rows.Scan(&hotel.Id, &hotel.OwnerId, &hotel.Name, &hotel.Description); err != nil {
+		if err := rows.Scan(&hotel.Id, &hotel.OwnerId, &hotel.Name, &hotel.Description, &hotel.City, &hotel.Address); err != nil {
 			return nil, err
 		}
 		hotels = append(hotels, hotel)
@@ -35,16 +37,16 @@ func (repo *PostgresHotelRepository) GetAllHotels() ([]models.Hotel, error) {
 
 func (repo *PostgresHotelRepository) AddHotel(hotel models.Hotel) error {
 	_, err := repo.db.Exec(
-		"INSERT INTO hotels (OwnerId, Name, Description) VALUES ($1, $2, $3)",
-		hotel.OwnerId, hotel.Name, hotel.Description,
+		"INSERT INTO hotels (OwnerId, Name, Description, City, Address) VALUES ($1, $2, $3, $4, $5)",
+		hotel.OwnerId, hotel.Name, hotel.Description, hotel.City, hotel.Address,
 	)
 	return err
 }
 
 func (repo *PostgresHotelRepository) UpdateHotel(hotel models.Hotel) error {
 	result, err := repo.db.Exec(
-		"UPDATE hotels SET name = $1, description = $2 WHERE id = $3",
-		hotel.Name, hotel.Description, hotel.Id,
+		"UPDATE hotels SET name = $1, description = $2, city = $3, address = $4 WHERE id = $5",
+		hotel.Name, hotel.Description, hotel.City, hotel.Address, hotel.Id,
 	)
 	if err != nil {
 		return err
@@ -62,10 +64,34 @@ func (repo *PostgresHotelRepository) UpdateHotel(hotel models.Hotel) error {
 
 func (repo *PostgresHotelRepository) GetHotelByID(id int) (*models.Hotel, error) {
 	var hotel models.Hotel
-	err := repo.db.QueryRow("SELECT Id, OwnerId, Name, Description FROM hotels WHERE id = $1", id).
-		Scan(&hotel.Id, &hotel.OwnerId, &hotel.Name, &hotel.Description)
+	err := repo.db.QueryRow("SELECT Id, OwnerId, Name, Description, City, Address FROM hotels WHERE id = $1", id).
+		Scan(&hotel.Id, &hotel.OwnerId, &hotel.Name, &hotel.Description, &hotel.City, &hotel.Address)
 	if err == sql.ErrNoRows {
 		return nil, errors.New("hotel not found")
 	}
 	return &hotel, err
 }
+
+// GetHotelsByCity возвращает отели города без учёта регистра
+func (repo *PostgresHotelRepository) GetHotelsByCity(ctx context.Context, city string) ([]models.Hotel, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		"SELECT Id, OwnerId, Name, Description, City, Address FROM hotels WHERE LOWER(City) = LOWER($1) ORDER BY Id",
+		city,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting hotels by city: %w", err)
+	}
+	defer rows.Close()
+
+	hotels := make([]models.Hotel, 0)
+	for rows.Next() {
+		var hotel models.Hotel
+		var description sql.NullString
+		if err := rows.Scan(&hotel.Id, &hotel.OwnerId, &hotel.Name, &description, &hotel.City, &hotel.Address); err != nil {
+			return nil, fmt.Errorf("error scanning hotel: %w", err)
+		}
+		hotel.Description = description.String
+		hotels = append(hotels, hotel)
+	}
+	return hotels, rows.Err()
+}
@@ -0,0 +1,124 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/myerror"
+	"github.com/lib/pq"
+)
+
+// Код ошибки Postgres при нарушении уникальности
+const uniqueViolationCode = "23505"
+
+const promoCodeColumns = `ID, HotelID, Code, DiscountType, Value, ValidFrom, ValidUntil, StayFrom, StayUntil,
+	UsageLimit, PerUserLimit, FirstBookingOnly, Active, CreatedAt`
+
+type PostgresPromoCodeRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresPromoCodeRepository(db *sql.DB) *PostgresPromoCodeRepository {
+	return &PostgresPromoCodeRepository{db: db}
+}
+
+func (repo *PostgresPromoCodeRepository) AddPromoCode(ctx context.Context, promo models.PromoCode) (int, error) {
+	var id int
+	err := repo.db.QueryRowContext(ctx,
+		`INSERT INTO promo_codes (HotelID, Code, DiscountType, Value, ValidFrom, ValidUntil, StayFrom, StayUntil,
+			UsageLimit, PerUserLimit, FirstBookingOnly)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING ID`,
+		promo.HotelID, promo.Code, promo.DiscountType, promo.Value, promo.ValidFrom, promo.ValidUntil, promo.StayFrom,
+		promo.StayUntil, promo.UsageLimit, promo.PerUserLimit, promo.FirstBookingOnly,
+	).Scan(&id)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+			return 0, fmt.Errorf("error adding promo code: %w", myerror.ErrPromoCodeExists)
+		}
+		return 0, fmt.Errorf("error adding promo code: %w", err)
+	}
+	return id, nil
+}
+
+func (repo *PostgresPromoCodeRepository) GetPromoCodesByHotelID(ctx context.Context, hotelID int) ([]models.PromoCode, error) {
+	rows, err := repo.db.QueryContext(ctx,
+		"SELECT "+promoCodeColumns+" FROM promo_codes WHERE HotelID = $1 ORDER BY ID",
+		hotelID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting promo codes: %w", err)
+	}
+	defer rows.Close()
+
+	promoCodes := make([]models.PromoCode, 0)
+	for rows.Next() {
+		promo, err := scanPromoCode(rows)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning promo code: %w", err)
+		}
+		promoCodes = append(promoCodes, *promo)
+	}
+	return promoCodes, rows.Err()
+}
+
+// GetPromoCodeByCode ищет промокод отеля без учёта регистра
+func (repo *PostgresPromoCodeRepository) GetPromoCodeByCode(ctx context.Context, hotelID int, code string) (*models.PromoCode, error) {
+	row := repo.db.QueryRowContext(ctx,
+		"SELECT "+promoCodeColumns+" FROM promo_codes WHERE HotelID = $1 AND UPPER(Code) = UPPER($2)",
+		hotelID, code,
+	)
+	promo, err := scanPromoCode(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("error getting promo code: %w", myerror.ErrPromoCodeNotFound)
+		}
+		return nil, fmt.Errorf("error getting promo code: %w", err)
+	}
+	return promo, nil
+}
+
+func (repo *PostgresPromoCodeRepository) SetPromoCodeActive(ctx context.Context, hotelID, promoCodeID int, active bool) error {
+	result, err := repo.db.ExecContext(ctx,
+		"UPDATE promo_codes SET Active = $1 WHERE ID = $2 AND HotelID = $3",
+		active, promoCodeID, hotelID,
+	)
+	if err != nil {
+		return fmt.Errorf("error updating promo code: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error updating promo code: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("error updating promo code: %w", myerror.ErrPromoCodeNotFound)
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPromoCode(row rowScanner) (*models.PromoCode, error) {
+	var promo models.PromoCode
+	var stayFrom, stayUntil sql.NullTime
+	var usageLimit, perUserLimit sql.NullInt64
+	if err := row.Scan(&promo.ID, &promo.HotelID, &promo.Code, &promo.DiscountType, &promo.Value, &promo.ValidFrom,
+		&promo.ValidUntil, &stayFrom, &stayUntil, &usageLimit, &perUserLimit, &promo.FirstBookingOnly, &promo.Active,
+		&promo.CreatedAt); err != nil {
+		return nil, err
+	}
+	if stayFrom.Valid {
+		promo.StayFrom = &stayFrom.Time
+	}
+	if stayUntil.Valid {
+		promo.StayUntil = &stayUntil.Time
+	}
+	promo.UsageLimit = nullableInt(usageLimit)
+	promo.PerUserLimit = nullableInt(perUserLimit)
+	return &promo, nil
+}
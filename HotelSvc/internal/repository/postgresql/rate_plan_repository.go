@@ -0,0 +1,146 @@
+package postgresql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/lib/pq"
+)
+
+type PostgresRatePlanRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRatePlanRepository(db *sql.DB) *PostgresRatePlanRepository {
+	return &PostgresRatePlanRepository{db: db}
+}
+
+// GetRatePlans возвращает тарифы комнат отеля по ID комнаты. Комнат из других отелей в результате нет.
+func (repo *PostgresRatePlanRepository) GetRatePlans(ctx context.Context, hotelID int, roomIDs []int) (map[int]*models.RatePlan, error) {
+	ids := make([]int64, 0, len(roomIDs))
+	for _, id := range roomIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT r.ID, rt.ID, rt.Name, rt.Description, rt.BasePrice, rt.WeekendPrice, rt.BaseOccupancy, rt.ExtraGuestPrice
+		FROM rooms r
+		JOIN room_type rt ON r.RoomTypeID = rt.ID
+		WHERE r.HotelID = $1 AND r.ID = ANY($2)`,
+		hotelID, pq.Array(ids),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rate plans: %w", err)
+	}
+	defer rows.Close()
+
+	plans := make(map[int]*models.RatePlan)
+	byRoomType := make(map[int][]*models.RatePlan)
+	for rows.Next() {
+		var plan models.RatePlan
+		var description sql.NullString
+		var weekendPrice sql.NullInt64
+		if err = rows.Scan(&plan.RoomID, &plan.RoomType.ID, &plan.RoomType.Name, &description, &plan.RoomType.BasePrice,
+			&weekendPrice, &plan.RoomType.BaseOccupancy, &plan.RoomType.ExtraGuestPrice); err != nil {
+			return nil, fmt.Errorf("error scanning rate plan: %w", err)
+		}
+		plan.RoomType.Description = description.String
+		plan.RoomType.WeekendPrice = nullableInt(weekendPrice)
+		plans[plan.RoomID] = &plan
+		byRoomType[plan.RoomType.ID] = append(byRoomType[plan.RoomType.ID], &plan)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error getting rate plans: %w", err)
+	}
+	if len(byRoomType) == 0 {
+		return plans, nil
+	}
+
+	roomTypeIDs := make([]int64, 0, len(byRoomType))
+	for id := range byRoomType {
+		roomTypeIDs = append(roomTypeIDs, int64(id))
+	}
+	if err = repo.loadSeasons(ctx, roomTypeIDs, byRoomType); err != nil {
+		return nil, err
+	}
+	if err = repo.loadStayDiscounts(ctx, roomTypeIDs, byRoomType); err != nil {
+		return nil, err
+	}
+	return plans, nil
+}
+
+func (repo *PostgresRatePlanRepository) loadSeasons(ctx context.Context, roomTypeIDs []int64, byRoomType map[int][]*models.RatePlan) error {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT ID, RoomTypeID, Name, StartDate, EndDate, Price, WeekendPrice
+		FROM rate_season
+		WHERE RoomTypeID = ANY($1)
+		ORDER BY StartDate`,
+		pq.Array(roomTypeIDs),
+	)
+	if err != nil {
+		return fmt.Errorf("error getting rate seasons: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var season models.RateSeason
+		var weekendPrice sql.NullInt64
+		if err = rows.Scan(&season.ID, &season.RoomTypeID, &season.Name, &season.StartDate, &season.EndDate,
+			&season.Price, &weekendPrice); err != nil {
+			return fmt.Errorf("error scanning rate season: %w", err)
+		}
+		season.WeekendPrice = nullableInt(weekendPrice)
+		for _, plan := range byRoomType[season.RoomTypeID] {
+			plan.Seasons = append(plan.Seasons, season)
+		}
+	}
+	return rows.Err()
+}
+
+func (repo *PostgresRatePlanRepository) loadStayDiscounts(ctx context.Context, roomTypeIDs []int64, byRoomType map[int][]*models.RatePlan) error {
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT ID, RoomTypeID, MinNights, Percent
+		FROM stay_discount
+		WHERE RoomTypeID = ANY($1)`,
+		pq.Array(roomTypeIDs),
+	)
+	if err != nil {
+		return fmt.Errorf("error getting stay discounts: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var discount models.StayDiscount
+		if err = rows.Scan(&discount.ID, &discount.RoomTypeID, &discount.MinNights, &discount.Percent); err != nil {
+			return fmt.Errorf("error scanning stay discount: %w", err)
+		}
+		for _, plan := range byRoomType[discount.RoomTypeID] {
+			plan.StayDiscounts = append(plan.StayDiscounts, discount)
+		}
+	}
+	return rows.Err()
+}
+
+func (repo *PostgresRatePlanRepository) AddRateSeason(ctx context.Context, season models.RateSeason) error {
+	_, err := repo.db.ExecContext(ctx,
+		"INSERT INTO rate_season (RoomTypeID, Name, StartDate, EndDate, Price, WeekendPrice) VALUES ($1, $2, $3, $4, $5, $6)",
+		season.RoomTypeID, season.Name, season.StartDate, season.EndDate, season.Price, season.WeekendPrice,
+	)
+	return err
+}
+
+func (repo *PostgresRatePlanRepository) AddStayDiscount(ctx context.Context, discount models.StayDiscount) error {
+	_, err := repo.db.ExecContext(ctx,
+		"INSERT INTO stay_discount (RoomTypeID, MinNights, Percent) VALUES ($1, $2, $3)",
+		discount.RoomTypeID, discount.MinNights, discount.Percent,
+	)
+	return err
+}
+
+func nullableInt(value sql.NullInt64) *int {
+	if !value.Valid {
+		return nil
+	}
+	result := int(value.Int64)
+	return &result
+}
@@ -1,9 +1,14 @@
 package postgresql
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
 	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/myerror"
+	"github.com/lib/pq"
 )
 
 type PostgresRoomRepository struct {
@@ -21,7 +26,11 @@ func (repo *PostgresRoomRepository) GetRoomsByHotelId(id int) ([]*hotelpb.Room,
         r.HotelId, 
         r.Number, 
         rt.Description, 
-        rt.BasePrice AS Cost 
+        rt.BasePrice AS Cost, 
+        r.RoomTypeId, 
+        rt.MaxAdults, 
+        rt.MaxChildren, 
+        rt.BedConfiguration 
      FROM 
         rooms r 
      JOIN 
@@ -40,7 +49,8 @@ func (repo *PostgresRoomRepository) GetRoomsByHotelId(id int) ([]*hotelpb.Room,
 	var rooms []*hotelpb.Room
 	for rows.Next() {
 		var room hotelpb.Room
-		if err := rows.Scan(&room.Id, &room.HotelId, &room.Number, &room.Description, &room.BasePrice); err != nil {
+		if err := rows.Scan(&room.Id, &room.HotelId, &room.Number, &room.Description, &room.BasePrice, &room.RoomTypeId,
+			&room.MaxAdults, &room.MaxChildren, &room.BedConfiguration); err != nil {
 			return nil, err
 		}
 		rooms = append(rooms, &room)
@@ -49,6 +59,69 @@ func (repo *PostgresRoomRepository) GetRoomsByHotelId(id int) ([]*hotelpb.Room,
 
 }
 
+// GetRoomDetails возвращает комнату отеля вместе с её типом и названием отеля
+func (repo *PostgresRoomRepository) GetRoomDetails(ctx context.Context, hotelID, roomID int) (*hotelpb.GetRoomDetailsResponse, error) {
+	room := &hotelpb.Room{}
+	roomType := &hotelpb.RoomType{}
+	var hotelName string
+	var roomTypeDescription sql.NullString
+	err := repo.db.QueryRowContext(ctx,
+		`SELECT r.ID, r.HotelID, r.Number, rt.ID, rt.Name, rt.Description, rt.BasePrice,
+			rt.MaxAdults, rt.MaxChildren, rt.BedConfiguration, h.Name
+		FROM rooms r
+		JOIN room_type rt ON r.RoomTypeID = rt.ID
+		JOIN hotels h ON r.HotelID = h.ID
+		WHERE r.ID = $1 AND r.HotelID = $2`,
+		roomID, hotelID,
+	).Scan(&room.Id, &room.HotelId, &room.Number, &roomType.Id, &roomType.Name, &roomTypeDescription, &roomType.BasePrice,
+		&room.MaxAdults, &room.MaxChildren, &room.BedConfiguration, &hotelName)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("error getting room details: %w", myerror.ErrRoomNotFound)
+		}
+		return nil, fmt.Errorf("error getting room details: %w", err)
+	}
+	roomType.Description = roomTypeDescription.String
+	room.RoomTypeId = roomType.Id
+	room.Description = roomType.Description
+	room.BasePrice = roomType.BasePrice
+	return &hotelpb.GetRoomDetailsResponse{Room: room, RoomType: roomType, HotelName: hotelName}, nil
+}
+
+// GetRoomsByHotelIDs возвращает комнаты нескольких отелей одним запросом, для поиска по городу
+func (repo *PostgresRoomRepository) GetRoomsByHotelIDs(ctx context.Context, hotelIDs []int) ([]*hotelpb.Room, error) {
+	ids := make([]int64, 0, len(hotelIDs))
+	for _, id := range hotelIDs {
+		ids = append(ids, int64(id))
+	}
+	rows, err := repo.db.QueryContext(ctx,
+		`SELECT r.ID, r.HotelID, r.Number, rt.Description, rt.BasePrice, r.RoomTypeID,
+			rt.MaxAdults, rt.MaxChildren, rt.BedConfiguration
+		FROM rooms r
+		JOIN room_type rt ON r.RoomTypeID = rt.ID
+		WHERE r.HotelID = ANY($1)
+		ORDER BY r.HotelID, r.ID`,
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting rooms by hotels: %w", err)
+	}
+	defer rows.Close()
+
+	rooms := make([]*hotelpb.Room, 0)
+	for rows.Next() {
+		var room hotelpb.Room
+		var description sql.NullString
+		if err := rows.Scan(&room.Id, &room.HotelId, &room.Number, &description, &room.BasePrice, &room.RoomTypeId,
+			&room.MaxAdults, &room.MaxChildren, &room.BedConfiguration); err != nil {
+			return nil, fmt.Errorf("error scanning room: %w", err)
+		}
+		room.Description = description.String
+		rooms = append(rooms, &room)
+	}
+	return rooms, rows.Err()
+}
+
 func (repo *PostgresRoomRepository) AddRoom(room models.Room) error {
 	_, err := repo.db.Exec(
 		"INSERT INTO rooms (HotelId, RoomTypeId, Number) VALUES ($1, $2, $3)",
@@ -59,8 +132,10 @@ func (repo *PostgresRoomRepository) AddRoom(room models.Room) error {
 
 func (repo *PostgresRoomRepository) AddRoomType(roomType models.RoomType) error {
 	_, err := repo.db.Exec(
-		"INSERT INTO room_type (Name, Description, BasePrice) VALUES ($1, $2, $3)",
-		roomType.Name, roomType.Description, roomType.BasePrice,
+		`INSERT INTO room_type (Name, Description, BasePrice, WeekendPrice, BaseOccupancy, ExtraGuestPrice, MaxAdults, MaxChildren, BedConfiguration)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		roomType.Name, roomType.Description, roomType.BasePrice, roomType.WeekendPrice, roomType.BaseOccupancy, roomType.ExtraGuestPrice,
+		roomType.MaxAdults, roomType.MaxChildren, roomType.BedConfiguration,
 	)
 	return err
 }
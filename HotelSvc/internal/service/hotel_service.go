@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/myerror"
+	"strings"
 )
 
 type HotelRepository interface {
@@ -11,6 +13,7 @@ type HotelRepository interface {
 	AddHotel(hotel models.Hotel) error
 	UpdateHotel(hotel models.Hotel) error
 	GetHotelByID(id int) (*models.Hotel, error)
+	GetHotelsByCity(ctx context.Context, city string) ([]models.Hotel, error)
 }
 
 type HotelService struct {
@@ -62,3 +65,12 @@ func (s *HotelService) UpdateHotel(ctx context.Context, hotel models.Hotel) erro
 func (s *HotelService) GetHotelByID(id int) (*models.Hotel, error) {
 	return s.hotelRepo.GetHotelByID(id)
 }
+
+// GetHotelsByCity возвращает отели города для поиска свободных комнат.
+func (s *HotelService) GetHotelsByCity(ctx context.Context, city string) ([]models.Hotel, error) {
+	city = strings.TrimSpace(city)
+	if city == "" {
+		return nil, myerror.ErrInvalidSearch
+	}
+	return s.hotelRepo.GetHotelsByCity(ctx, city)
+}
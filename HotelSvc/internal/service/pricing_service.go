@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/myerror"
+	"time"
+)
+
+const (
+	rateBase    = "base"
+	rateWeekend = "weekend"
+)
+
+type RatePlanRepository interface {
+	GetRatePlans(ctx context.Context, hotelID int, roomIDs []int) (map[int]*models.RatePlan, error)
+	AddRateSeason(ctx context.Context, season models.RateSeason) error
+	AddStayDiscount(ctx context.Context, discount models.StayDiscount) error
+}
+
+type PricingService struct {
+	ratePlanRepo RatePlanRepository
+}
+
+// NewPricingService создает новый экземпляр PricingService.
+func NewPricingService(ratePlanRepo RatePlanRepository) *PricingService {
+	return &PricingService{ratePlanRepo: ratePlanRepo}
+}
+
+// GetPriceQuotes считает стоимость проживания в комнатах отеля с checkIn по checkOut.
+// Ночи считаются по календарным датам UTC: ночь с даты checkOut не оплачивается.
+func (s *PricingService) GetPriceQuotes(ctx context.Context, hotelID int, roomIDs []int, checkIn, checkOut time.Time, guests int) ([]*models.PriceQuote, error) {
+	checkIn, checkOut = truncateToDate(checkIn), truncateToDate(checkOut)
+	if !checkOut.After(checkIn) || guests <= 0 {
+		return nil, fmt.Errorf("in service GetPriceQuotes: %w", myerror.ErrInvalidStay)
+	}
+
+	plans, err := s.ratePlanRepo.GetRatePlans(ctx, hotelID, roomIDs)
+	if err != nil {
+		return nil, fmt.Errorf("in service GetPriceQuotes: %w", err)
+	}
+	quotes := make([]*models.PriceQuote, 0, len(roomIDs))
+	for _, roomID := range roomIDs {
+		plan, ok := plans[roomID]
+		if !ok {
+			return nil, fmt.Errorf("in service GetPriceQuotes: room %d: %w", roomID, myerror.ErrRoomNotFound)
+		}
+		quotes = append(quotes, quote(plan, checkIn, checkOut, guests))
+	}
+	return quotes, nil
+}
+
+// AddRateSeason добавляет сезонную цену для типа комнаты.
+func (s *PricingService) AddRateSeason(ctx context.Context, season models.RateSeason) error {
+	season.StartDate, season.EndDate = truncateToDate(season.StartDate), truncateToDate(season.EndDate)
+	if season.RoomTypeID == 0 || season.Name == "" || season.Price <= 0 || !season.EndDate.After(season.StartDate) {
+		return fmt.Errorf("invalid rate season: %w", myerror.ErrInvalidRatePlan)
+	}
+	return s.ratePlanRepo.AddRateSeason(ctx, season)
+}
+
+// AddStayDiscount добавляет скидку за длительное проживание.
+func (s *PricingService) AddStayDiscount(ctx context.Context, discount models.StayDiscount) error {
+	if discount.RoomTypeID == 0 || discount.MinNights <= 0 || discount.Percent <= 0 || discount.Percent >= 100 {
+		return fmt.Errorf("invalid stay discount: %w", myerror.ErrInvalidRatePlan)
+	}
+	return s.ratePlanRepo.AddStayDiscount(ctx, discount)
+}
+
+// quote считает стоимость по тарифу: цена каждой ночи берётся из сезона или базового тарифа,
+// к ней добавляется доплата за гостей сверх BaseOccupancy, на сумму действует лучшая скидка за длительность.
+func quote(plan *models.RatePlan, checkIn, checkOut time.Time, guests int) *models.PriceQuote {
+	roomType := plan.RoomType
+	extraGuests := guests - max(roomType.BaseOccupancy, 1)
+	extraGuestPrice := max(extraGuests, 0) * roomType.ExtraGuestPrice
+
+	result := &models.PriceQuote{RoomID: plan.RoomID}
+	for night := checkIn; night.Before(checkOut); night = night.AddDate(0, 0, 1) {
+		rate, price := nightRate(plan, night)
+		result.Nights = append(result.Nights, models.NightPrice{
+			Date:            night,
+			Rate:            rate,
+			Price:           price,
+			ExtraGuestPrice: extraGuestPrice,
+		})
+		result.Subtotal += price + extraGuestPrice
+	}
+
+	for _, discount := range plan.StayDiscounts {
+		if len(result.Nights) >= discount.MinNights && discount.Percent > result.DiscountPercent {
+			result.DiscountPercent = discount.Percent
+		}
+	}
+	result.Discount = result.Subtotal * result.DiscountPercent / 100
+	result.Total = result.Subtotal - result.Discount
+	return result
+}
+
+// nightRate возвращает название и цену тарифа на ночь. Если сезоны пересекаются, действует начавшийся позже.
+func nightRate(plan *models.RatePlan, night time.Time) (string, int) {
+	weekend := night.Weekday() == time.Friday || night.Weekday() == time.Saturday
+
+	var season *models.RateSeason
+	for i := range plan.Seasons {
+		candidate := &plan.Seasons[i]
+		if night.Before(candidate.StartDate) || !night.Before(candidate.EndDate) {
+			continue
+		}
+		if season == nil || candidate.StartDate.After(season.StartDate) {
+			season = candidate
+		}
+	}
+
+	if season != nil {
+		if weekend && season.WeekendPrice != nil {
+			return season.Name, *season.WeekendPrice
+		}
+		return season.Name, season.Price
+	}
+	if weekend && plan.RoomType.WeekendPrice != nil {
+		return rateWeekend, *plan.RoomType.WeekendPrice
+	}
+	return rateBase, plan.RoomType.BasePrice
+}
+
+func truncateToDate(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func price(value int) *int {
+	return &value
+}
+
+// ratePlan - тариф: 1000 за ночь на двоих, 1500 в выходные, 300 за каждого гостя сверх двух
+func ratePlan(seasons []models.RateSeason, discounts []models.StayDiscount) *models.RatePlan {
+	return &models.RatePlan{
+		RoomID: 1,
+		RoomType: models.RoomType{
+			BasePrice:       1000,
+			WeekendPrice:    price(1500),
+			BaseOccupancy:   2,
+			ExtraGuestPrice: 300,
+		},
+		Seasons:       seasons,
+		StayDiscounts: discounts,
+	}
+}
+
+func TestNightRate(t *testing.T) {
+	summer := models.RateSeason{Name: "summer", StartDate: date(6, 1), EndDate: date(7, 1), Price: 2000, WeekendPrice: price(2500)}
+	festival := models.RateSeason{Name: "festival", StartDate: date(6, 10), EndDate: date(6, 13), Price: 3000}
+
+	tests := []struct {
+		name  string
+		plan  *models.RatePlan
+		night time.Time
+		rate  string
+		price int
+	}{
+		{"weekday", ratePlan(nil, nil), date(5, 4), rateBase, 1000},
+		{"friday", ratePlan(nil, nil), date(5, 8), rateWeekend, 1500},
+		{"saturday", ratePlan(nil, nil), date(5, 9), rateWeekend, 1500},
+		{"sunday", ratePlan(nil, nil), date(5, 10), rateBase, 1000},
+		{"weekend without weekend price", &models.RatePlan{RoomType: models.RoomType{BasePrice: 1000}}, date(5, 8), rateBase, 1000},
+		{"season weekday", ratePlan([]models.RateSeason{summer}, nil), date(6, 2), "summer", 2000},
+		{"season weekend", ratePlan([]models.RateSeason{summer}, nil), date(6, 6), "summer", 2500},
+		{"season start", ratePlan([]models.RateSeason{summer}, nil), date(6, 1), "summer", 2000},
+		{"season end is not included", ratePlan([]models.RateSeason{summer}, nil), date(7, 1), rateBase, 1000},
+		{"overlap: later start wins", ratePlan([]models.RateSeason{summer, festival}, nil), date(6, 11), "festival", 3000},
+		{"overlap: order does not matter", ratePlan([]models.RateSeason{festival, summer}, nil), date(6, 11), "festival", 3000},
+		{"overlap: later season without weekend price", ratePlan([]models.RateSeason{summer, festival}, nil), date(6, 12), "festival", 3000},
+		{"overlap: after later season ends", ratePlan([]models.RateSeason{summer, festival}, nil), date(6, 13), "summer", 2500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, price := nightRate(tt.plan, tt.night)
+			assert.Equal(t, tt.rate, rate)
+			assert.Equal(t, tt.price, price)
+		})
+	}
+}
+
+func TestQuote(t *testing.T) {
+	discounts := []models.StayDiscount{{MinNights: 3, Percent: 5}, {MinNights: 7, Percent: 10}, {MinNights: 14, Percent: 20}}
+
+	tests := []struct {
+		name            string
+		plan            *models.RatePlan
+		checkIn         time.Time
+		checkOut        time.Time
+		guests          int
+		extraGuestPrice int
+		subtotal        int
+		discountPercent int
+		total           int
+	}{
+		{"base occupancy", ratePlan(nil, nil), date(6, 1), date(6, 3), 2, 0, 2000, 0, 2000},
+		{"fewer guests than base occupancy", ratePlan(nil, nil), date(6, 1), date(6, 3), 1, 0, 2000, 0, 2000},
+		{"extra guests", ratePlan(nil, nil), date(6, 1), date(6, 3), 4, 600, 2 * (1000 + 600), 0, 3200},
+		{"extra guest over zero base occupancy", &models.RatePlan{RoomType: models.RoomType{BasePrice: 1000, ExtraGuestPrice: 300}},
+			date(6, 1), date(6, 2), 2, 300, 1300, 0, 1300},
+		{"weekend nights", ratePlan(nil, nil), date(6, 4), date(6, 7), 2, 0, 1000 + 1500 + 1500, 0, 4000},
+		{"stay too short for discount", ratePlan(nil, discounts), date(6, 1), date(6, 3), 2, 0, 2000, 0, 2000},
+		{"discount from min nights", ratePlan(nil, discounts), date(6, 1), date(6, 4), 2, 0, 3000, 5, 2850},
+		{"best discount applies", ratePlan(nil, discounts), date(6, 1), date(6, 8), 2, 0, 5*1000 + 2*1500, 10, 7200},
+		{"discount includes extra guests", ratePlan(nil, discounts), date(6, 1), date(6, 4), 3, 300, 3 * 1300, 5, 3705},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := quote(tt.plan, tt.checkIn, tt.checkOut, tt.guests)
+
+			require.Len(t, result.Nights, int(tt.checkOut.Sub(tt.checkIn)/(24*time.Hour)))
+			for _, night := range result.Nights {
+				assert.Equal(t, tt.extraGuestPrice, night.ExtraGuestPrice, night.Date)
+			}
+			assert.Equal(t, tt.plan.RoomID, result.RoomID)
+			assert.Equal(t, tt.subtotal, result.Subtotal)
+			assert.Equal(t, tt.discountPercent, result.DiscountPercent)
+			assert.Equal(t, tt.subtotal-tt.total, result.Discount)
+			assert.Equal(t, tt.total, result.Total)
+		})
+	}
+}
+
+func TestQuote_SeasonNights(t *testing.T) {
+	summer := models.RateSeason{Name: "summer", StartDate: date(6, 1), EndDate: date(7, 1), Price: 2000, WeekendPrice: price(2500)}
+	festival := models.RateSeason{Name: "festival", StartDate: date(6, 10), EndDate: date(6, 13), Price: 3000}
+
+	// Заезд до сезона, выезд после фестиваля: каждая ночь по своему тарифу
+	result := quote(ratePlan([]models.RateSeason{summer, festival}, nil), date(5, 29), date(6, 14), 2)
+
+	rates := make(map[string]int)
+	for _, night := range result.Nights {
+		rates[night.Rate]++
+	}
+	assert.Equal(t, map[string]int{rateWeekend: 2, rateBase: 1, "summer": 10, "festival": 3}, rates)
+	assert.Equal(t, 2*1500+1000+7*2000+3*2500+3*3000, result.Subtotal)
+	assert.Equal(t, result.Subtotal, result.Total)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/myerror"
+	"strings"
+)
+
+const maxPromoCodeLength = 64
+
+type PromoCodeRepository interface {
+	AddPromoCode(ctx context.Context, promo models.PromoCode) (int, error)
+	GetPromoCodesByHotelID(ctx context.Context, hotelID int) ([]models.PromoCode, error)
+	GetPromoCodeByCode(ctx context.Context, hotelID int, code string) (*models.PromoCode, error)
+	SetPromoCodeActive(ctx context.Context, hotelID, promoCodeID int, active bool) error
+}
+
+type PromoCodeService struct {
+	promoRepo PromoCodeRepository
+	ownerRepo OwnerRepository
+}
+
+// NewPromoCodeService создает новый экземпляр PromoCodeService.
+func NewPromoCodeService(promoRepo PromoCodeRepository, ownerRepo OwnerRepository) *PromoCodeService {
+	return &PromoCodeService{promoRepo: promoRepo, ownerRepo: ownerRepo}
+}
+
+// AddPromoCode добавляет промокод в отель владельца ownerID и возвращает его ID.
+func (s *PromoCodeService) AddPromoCode(ctx context.Context, ownerID int, promo models.PromoCode) (int, error) {
+	promo.Code = strings.TrimSpace(promo.Code)
+	if promo.StayFrom != nil {
+		stayFrom := truncateToDate(*promo.StayFrom)
+		promo.StayFrom = &stayFrom
+	}
+	if promo.StayUntil != nil {
+		stayUntil := truncateToDate(*promo.StayUntil)
+		promo.StayUntil = &stayUntil
+	}
+	if err := validatePromoCode(promo); err != nil {
+		return 0, fmt.Errorf("in service AddPromoCode: %w", err)
+	}
+	if err := s.checkOwner(ctx, ownerID, promo.HotelID); err != nil {
+		return 0, fmt.Errorf("in service AddPromoCode: %w", err)
+	}
+
+	id, err := s.promoRepo.AddPromoCode(ctx, promo)
+	if err != nil {
+		return 0, fmt.Errorf("in service AddPromoCode: %w", err)
+	}
+	return id, nil
+}
+
+// GetPromoCodes возвращает все промокоды отеля его владельцу.
+func (s *PromoCodeService) GetPromoCodes(ctx context.Context, ownerID, hotelID int) ([]models.PromoCode, error) {
+	if err := s.checkOwner(ctx, ownerID, hotelID); err != nil {
+		return nil, fmt.Errorf("in service GetPromoCodes: %w", err)
+	}
+	promoCodes, err := s.promoRepo.GetPromoCodesByHotelID(ctx, hotelID)
+	if err != nil {
+		return nil, fmt.Errorf("in service GetPromoCodes: %w", err)
+	}
+	return promoCodes, nil
+}
+
+// DeactivatePromoCode отключает промокод. Уже созданные с ним бронирования не меняются.
+func (s *PromoCodeService) DeactivatePromoCode(ctx context.Context, ownerID, hotelID, promoCodeID int) error {
+	if err := s.checkOwner(ctx, ownerID, hotelID); err != nil {
+		return fmt.Errorf("in service DeactivatePromoCode: %w", err)
+	}
+	if err := s.promoRepo.SetPromoCodeActive(ctx, hotelID, promoCodeID, false); err != nil {
+		return fmt.Errorf("in service DeactivatePromoCode: %w", err)
+	}
+	return nil
+}
+
+// GetActivePromoCode возвращает действующий промокод отеля для BookingSvc.
+// Окно действия и лимиты проверяет BookingSvc при создании бронирования.
+func (s *PromoCodeService) GetActivePromoCode(ctx context.Context, hotelID int, code string) (*models.PromoCode, error) {
+	promo, err := s.promoRepo.GetPromoCodeByCode(ctx, hotelID, strings.TrimSpace(code))
+	if err != nil {
+		return nil, fmt.Errorf("in service GetActivePromoCode: %w", err)
+	}
+	if !promo.Active {
+		return nil, fmt.Errorf("in service GetActivePromoCode: %w", myerror.ErrPromoCodeNotFound)
+	}
+	return promo, nil
+}
+
+func (s *PromoCodeService) checkOwner(ctx context.Context, ownerID, hotelID int) error {
+	hotelOwnerID, err := s.ownerRepo.GetOwnerIdByHotelId(ctx, hotelID)
+	if err != nil {
+		return err
+	}
+	if hotelOwnerID != ownerID {
+		return myerror.ErrNotHotelOwner
+	}
+	return nil
+}
+
+func validatePromoCode(promo models.PromoCode) error {
+	switch {
+	case promo.HotelID == 0 || promo.Code == "" || len(promo.Code) > maxPromoCodeLength:
+		return myerror.ErrInvalidPromoCode
+	case promo.DiscountType != models.DiscountPercent && promo.DiscountType != models.DiscountFixed:
+		return myerror.ErrInvalidPromoCode
+	case promo.Value <= 0 || promo.DiscountType == models.DiscountPercent && promo.Value > 100:
+		return myerror.ErrInvalidPromoCode
+	case !promo.ValidUntil.After(promo.ValidFrom):
+		return myerror.ErrInvalidPromoCode
+	case promo.StayFrom != nil && promo.StayUntil != nil && !promo.StayUntil.After(*promo.StayFrom):
+		return myerror.ErrInvalidPromoCode
+	case promo.UsageLimit != nil && *promo.UsageLimit <= 0, promo.PerUserLimit != nil && *promo.PerUserLimit <= 0:
+		return myerror.ErrInvalidPromoCode
+	}
+	return nil
+}
@@ -2,12 +2,17 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/Quizert/room-reservation-system/HotelSvc/api/grpc/hotelpb"
 	"github.com/Quizert/room-reservation-system/HotelSvc/internal/models"
+	"github.com/Quizert/room-reservation-system/HotelSvc/internal/myerror"
 )
 
 type RoomRepository interface {
 	GetRoomsByHotelId(id int) ([]*hotelpb.Room, error)
+	GetRoomsByHotelIDs(ctx context.Context, hotelIDs []int) ([]*hotelpb.Room, error)
+	GetRoomDetails(ctx context.Context, hotelID, roomID int) (*hotelpb.GetRoomDetailsResponse, error)
 	AddRoomType(roomType models.RoomType) error
 	AddRoom(room models.Room) error
 }
@@ -29,10 +34,42 @@ func (s *RoomService) GetRoomsByHotelId(id int) ([]*hotelpb.Room, error) {
 	return rooms, err
 }
 
+// GetRoomsByHotelIDs возвращает комнаты нескольких отелей
+func (s *RoomService) GetRoomsByHotelIDs(ctx context.Context, hotelIDs []int) ([]*hotelpb.Room, error) {
+	if len(hotelIDs) == 0 {
+		return []*hotelpb.Room{}, nil
+	}
+	rooms, err := s.roomRepo.GetRoomsByHotelIDs(ctx, hotelIDs)
+	if err != nil {
+		return nil, fmt.Errorf("in service GetRoomsByHotelIDs: %w", err)
+	}
+	return rooms, nil
+}
+
+// GetRoomDetails возвращает данные комнаты, по которым BookingSvc считает стоимость бронирования.
+func (s *RoomService) GetRoomDetails(ctx context.Context, hotelID, roomID int) (*hotelpb.GetRoomDetailsResponse, error) {
+	details, err := s.roomRepo.GetRoomDetails(ctx, hotelID, roomID)
+	if err != nil {
+		if errors.Is(err, myerror.ErrRoomNotFound) {
+			return nil, fmt.Errorf("in service GetRoomDetails: %w", myerror.ErrRoomNotFound)
+		}
+		return nil, fmt.Errorf("in service GetRoomDetails: %w", err)
+	}
+	return details, nil
+}
+
 func (s *RoomService) AddRoom(ctx context.Context, room models.Room) error {
 	return s.roomRepo.AddRoom(room)
 }
 
+// AddRoomType добавляет тип комнаты. Если вместимость не задана, тип вмещает BaseOccupancy взрослых.
 func (s *RoomService) AddRoomType(roomType models.RoomType) error {
+	roomType.BaseOccupancy = max(roomType.BaseOccupancy, 1)
+	if roomType.MaxAdults == 0 {
+		roomType.MaxAdults = roomType.BaseOccupancy
+	}
+	if roomType.MaxAdults < 0 || roomType.MaxChildren < 0 || roomType.Capacity() < roomType.BaseOccupancy {
+		return fmt.Errorf("in service AddRoomType: %w", myerror.ErrInvalidCapacity)
+	}
 	return s.roomRepo.AddRoomType(roomType)
 }
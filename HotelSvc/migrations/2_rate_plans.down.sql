@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS stay_discount;
+
+DROP TABLE IF EXISTS rate_season;
+
+ALTER TABLE room_type
+    DROP COLUMN IF EXISTS WeekendPrice,
+    DROP COLUMN IF EXISTS BaseOccupancy,
+    DROP COLUMN IF EXISTS ExtraGuestPrice;
@@ -0,0 +1,32 @@
+-- Тариф типа комнаты: BasePrice - цена ночи за BaseOccupancy гостей,
+-- каждый следующий гость доплачивает ExtraGuestPrice за ночь
+ALTER TABLE room_type
+    ADD COLUMN WeekendPrice INT,
+    ADD COLUMN BaseOccupancy INT NOT NULL DEFAULT 1,
+    ADD COLUMN ExtraGuestPrice INT NOT NULL DEFAULT 0;
+
+-- Раньше стоимость считалась как BasePrice за каждого гостя, сохраняем это для существующих типов
+UPDATE room_type SET ExtraGuestPrice = BasePrice;
+
+-- Сезонные цены, EndDate не входит в сезон
+CREATE TABLE IF NOT EXISTS rate_season (
+    ID SERIAL PRIMARY KEY,
+    RoomTypeID INT NOT NULL REFERENCES room_type(ID) ON DELETE CASCADE,
+    Name TEXT NOT NULL,
+    StartDate DATE NOT NULL,
+    EndDate DATE NOT NULL,
+    Price INT NOT NULL,
+    WeekendPrice INT,
+    CHECK (StartDate < EndDate)
+);
+
+CREATE INDEX IF NOT EXISTS rate_season_room_type_idx ON rate_season (RoomTypeID, StartDate);
+
+-- Скидки за длительность проживания
+CREATE TABLE IF NOT EXISTS stay_discount (
+    ID SERIAL PRIMARY KEY,
+    RoomTypeID INT NOT NULL REFERENCES room_type(ID) ON DELETE CASCADE,
+    MinNights INT NOT NULL CHECK (MinNights > 0),
+    Percent INT NOT NULL CHECK (Percent > 0 AND Percent < 100),
+    UNIQUE (RoomTypeID, MinNights)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS promo_codes;
@@ -0,0 +1,25 @@
+-- Промокоды отеля. ValidFrom/ValidUntil - когда код можно применить,
+-- StayFrom/StayUntil - если заданы, все ночи проживания должны попадать в [StayFrom, StayUntil).
+-- Использования считает BookingSvc по активным бронированиям с этим кодом.
+CREATE TABLE IF NOT EXISTS promo_codes (
+    ID SERIAL PRIMARY KEY,
+    HotelID INT NOT NULL REFERENCES Hotels(ID) ON DELETE CASCADE,
+    Code TEXT NOT NULL,
+    DiscountType TEXT NOT NULL CHECK (DiscountType IN ('percent', 'fixed')),
+    Value INT NOT NULL CHECK (Value > 0),
+    ValidFrom TIMESTAMPTZ NOT NULL,
+    ValidUntil TIMESTAMPTZ NOT NULL,
+    StayFrom DATE,
+    StayUntil DATE,
+    UsageLimit INT CHECK (UsageLimit > 0),
+    PerUserLimit INT CHECK (PerUserLimit > 0),
+    FirstBookingOnly BOOLEAN NOT NULL DEFAULT FALSE,
+    Active BOOLEAN NOT NULL DEFAULT TRUE,
+    CreatedAt TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (ValidFrom < ValidUntil),
+    CHECK (StayFrom IS NULL OR StayUntil IS NULL OR StayFrom < StayUntil),
+    CHECK (DiscountType <> 'percent' OR Value <= 100)
+);
+
+-- Код уникален в пределах отеля без учёта регистра
+CREATE UNIQUE INDEX IF NOT EXISTS promo_codes_hotel_code_idx ON promo_codes (HotelID, UPPER(Code));
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS hotels_city_idx;
+
+ALTER TABLE Hotels
+    DROP COLUMN IF EXISTS City,
+    DROP COLUMN IF EXISTS Address;
@@ -0,0 +1,5 @@
+-- Расположение отеля для поиска свободных комнат по городу
+ALTER TABLE Hotels
+    ADD COLUMN City TEXT NOT NULL DEFAULT '',
+    ADD COLUMN Address TEXT NOT NULL DEFAULT '';
+CREATE INDEX IF NOT EXISTS hotels_city_idx ON Hotels (LOWER(City));
@@ -0,0 +1,4 @@
+ALTER TABLE room_type
+    DROP COLUMN IF EXISTS MaxAdults,
+    DROP COLUMN IF EXISTS MaxChildren,
+    DROP COLUMN IF EXISTS BedConfiguration;
@@ -0,0 +1,8 @@
+-- Вместимость типа комнаты: сколько взрослых и детей можно разместить и какие в ней кровати
+ALTER TABLE room_type
+    ADD COLUMN MaxAdults INT NOT NULL DEFAULT 2 CHECK (MaxAdults > 0),
+    ADD COLUMN MaxChildren INT NOT NULL DEFAULT 0 CHECK (MaxChildren >= 0),
+    ADD COLUMN BedConfiguration TEXT NOT NULL DEFAULT '';
+
+-- Существующие типы должны вмещать хотя бы гостей, входящих в базовую цену
+UPDATE room_type SET MaxAdults = GREATEST(MaxAdults, BaseOccupancy);
@@ -9,9 +9,20 @@ import (
 	"fmt"
 	"log"
 	"strconv"
+	"strings"
+)
+
+// Типы событий бронирования от BookingSvc
+const (
+	EventBookingCreated   = "created"
+	EventBookingCancelled = "cancelled"
+	EventBookingModified  = "modified"
+	EventBookingExpired   = "expired"
+	EventWaitlistOffer    = "waitlist_offer"
 )
 
 type BookingEvent struct {
+	Event           string `json:"event"`
 	BookingID       int    `json:"booking_id"`
 	HotelName       string `json:"hotel_name"`
 	RoomDescription string `json:"room_description"`
@@ -20,6 +31,32 @@ type BookingEvent struct {
 	EndDate         string `json:"end_date"`
 	UserName        string `json:"user_name"`
 	ChatId          string `json:"chat_id"`
+
+	GroupID int         `json:"group_id"`
+	Rooms   []EventRoom `json:"rooms"` // Комнаты группового бронирования
+
+	ClaimURL       string `json:"claim_url"`        // Ссылка на освободившуюся комнату из листа ожидания
+	ClaimExpiresAt string `json:"claim_expires_at"` // До какого времени действует ссылка
+}
+
+type EventRoom struct {
+	BookingID       int    `json:"booking_id"`
+	RoomDescription string `json:"room_description"`
+	RoomNumber      int    `json:"room_number"`
+	CountOfPeople   int    `json:"count_of_people"`
+}
+
+// roomsText описывает комнату бронирования или все комнаты группы
+func roomsText(event BookingEvent) string {
+	if len(event.Rooms) == 0 {
+		return fmt.Sprintf("Описание номера: %s\nНомер комнаты: %d\n", event.RoomDescription, event.RoomNumber)
+	}
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Групповое бронирование №%d, комнат: %d\n", event.GroupID, len(event.Rooms))
+	for _, room := range event.Rooms {
+		fmt.Fprintf(&builder, "- комната %d (%s), гостей: %d\n", room.RoomNumber, room.RoomDescription, room.CountOfPeople)
+	}
+	return builder.String()
 }
 
 type NotificationHandler struct {
@@ -43,15 +80,21 @@ func (h *NotificationHandler) handleHotelBookingEvent(ctx context.Context, messa
 
 	chatId, _ := strconv.ParseInt(event.ChatId, 10, 64)
 
+	title := "Новое бронирование отеля:\n"
+	switch event.Event {
+	case EventBookingCancelled:
+		title = "Бронирование отменено гостем:\n"
+	case EventBookingModified:
+		title = "Гость изменил бронирование:\n"
+	}
 	notificationMessage := fmt.Sprintf(
-		"Новое бронирование отеля:\n"+
+		title+
 			"Название отеля: %s\n"+
-			"Описание номера: %s\n"+
-			"Номер комнаты: %d\n"+
+			"%s"+
 			"Дата заезда: %s\n"+
 			"Дата выезда: %s\n"+
 			"Имя гостя: %s\n",
-		event.HotelName, event.RoomDescription, event.RoomNumber, event.StartDate, event.EndDate, event.UserName,
+		event.HotelName, roomsText(event), event.StartDate, event.EndDate, event.UserName,
 	)
 
 	h.notificationService.SendNotification(notificationMessage, chatId)
@@ -68,15 +111,28 @@ func (h *NotificationHandler) handleClientBookingEvent(ctx context.Context, mess
 
 	chatId, _ := strconv.ParseInt(event.ChatId, 10, 64)
 
+	title := "%s, у Вас новое бронирование отеля.\nОзнакомьтесь с информацией ниже:\n"
+	switch event.Event {
+	case EventBookingCancelled:
+		title = "%s, Ваше бронирование отменено, средства будут возвращены.\nОтменённое бронирование:\n"
+	case EventBookingModified:
+		title = "%s, Ваше бронирование изменено.\nАктуальная информация:\n"
+	case EventBookingExpired:
+		title = "%s, оплата не поступила вовремя, бронирование снято.\nСнятое бронирование:\n"
+	case EventWaitlistOffer:
+		title = "%s, освободилась комната, которую Вы ждали.\nУспейте забронировать её по ссылке ниже:\n"
+	}
 	notificationMessage := fmt.Sprintf(
-		"%s, у Вас новое бронирование отеля.\nОзнакомьтесь с информацией ниже:\n"+
+		title+
 			"Название отеля: %s\n"+
-			"Описание номера: %s\n"+
-			"Номер комнаты: %d\n"+
+			"%s"+
 			"Дата заезда: %s\n"+
 			"Дата выезда: %s\n",
-		event.UserName, event.HotelName, event.RoomDescription, event.RoomNumber, event.StartDate, event.EndDate,
+		event.UserName, event.HotelName, roomsText(event), event.StartDate, event.EndDate,
 	)
+	if event.Event == EventWaitlistOffer {
+		notificationMessage += fmt.Sprintf("Забронировать: %s\nСсылка действует до %s\n", event.ClaimURL, event.ClaimExpiresAt)
+	}
 
 	h.notificationService.SendNotification(notificationMessage, chatId)
 }
@@ -6,6 +6,7 @@ import (
 	"github.com/Quizert/room-reservation-system/PaymentSystem/internal/service"
 	"log"
 	"net/http"
+	"os"
 )
 
 type App struct {
@@ -19,7 +20,12 @@ func NewApp() *App {
 
 func main() {
 	app := NewApp()
-	app.service = service.NewPaymentService()
+	// Без секрета получатель не сможет проверить ни один вебхук
+	webHookSecret := os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if webHookSecret == "" {
+		log.Fatal("PAYMENT_WEBHOOK_SECRET is not set")
+	}
+	app.service = service.NewPaymentService(webHookSecret)
 
 	paymentHandler := handlers.NewPaymentHandler(app.service)
 	app.server = &http.Server{
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"github.com/Quizert/room-reservation-system/PaymentSystem/internal/models"
 	"github.com/Quizert/room-reservation-system/PaymentSystem/internal/service"
 	"log"
@@ -38,3 +39,54 @@ func (p *PaymentHandler) ProcessPayment(w http.ResponseWriter, r *http.Request)
 	w.WriteHeader(http.StatusAccepted)
 	w.Write([]byte("Payment processing started"))
 }
+
+func (p *PaymentHandler) Refund(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var refundRequest models.RefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&refundRequest); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	if err := p.PaymentService.Refund(r.Context(), &refundRequest); err != nil {
+		log.Println("in handler refund failed:", err)
+		switch {
+		case errors.Is(err, service.ErrPaymentNotFound):
+			http.Error(w, "payment not found", http.StatusNotFound)
+		case errors.Is(err, service.ErrInvalidRefund):
+			http.Error(w, "invalid refund amount", http.StatusConflict)
+		default:
+			http.Error(w, "server error", http.StatusInternalServerError)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Refund completed"))
+}
+
+// GetPaymentStatus возвращает статус платежа по заказу: processing, success, failed или refunded
+func (p *PaymentHandler) GetPaymentStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	orderID := r.URL.Query().Get("order_id")
+	if orderID == "" {
+		http.Error(w, "order_id is required", http.StatusBadRequest)
+		return
+	}
+	payment, err := p.PaymentService.GetPayment(r.Context(), orderID)
+	if err != nil {
+		if errors.Is(err, service.ErrPaymentNotFound) {
+			http.Error(w, "payment not found", http.StatusNotFound)
+			return
+		}
+		log.Println("in handler get payment status failed:", err)
+		http.Error(w, "server error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(payment)
+}
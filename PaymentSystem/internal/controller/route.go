@@ -8,5 +8,7 @@ import (
 func SetupRoutes(PaymentHandler *handlers.PaymentHandler) *http.ServeMux {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/payment", PaymentHandler.ProcessPayment)
+	mux.HandleFunc("/payment/status", PaymentHandler.GetPaymentStatus)
+	mux.HandleFunc("/refund", PaymentHandler.Refund)
 	return mux
 }
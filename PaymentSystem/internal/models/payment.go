@@ -1,6 +1,9 @@
 package models
 
+import "time"
+
 type PaymentRequest struct {
+	OrderID    string `json:"order_id"`
 	CardNumber string `json:"card_number"`
 	Amount     int    `json:"amount"`
 	WebHookURL string `json:"web_hook_url"`
@@ -13,3 +16,21 @@ type PaymentResponse struct {
 
 	MetaData map[string]interface{} `json:"meta_data"` // Произвольные метаданные
 }
+
+// RefundRequest - запрос на возврат средств по заказу.
+// RefundID - ключ идемпотентности: повторный запрос с тем же ключом деньги не возвращает
+type RefundRequest struct {
+	OrderID  string `json:"order_id"`
+	Amount   int    `json:"amount"`
+	RefundID string `json:"refund_id,omitempty"`
+}
+
+// Payment - платёж по заказу. Статус относится к последней попытке оплаты,
+// Amount - сумма всех успешных попыток
+type Payment struct {
+	OrderID   string    `json:"order_id"`
+	Amount    int       `json:"amount"`
+	Refunded  int       `json:"refunded"`
+	Status    string    `json:"status"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -3,35 +3,86 @@ package service
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Quizert/room-reservation-system/PaymentSystem/internal/models"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
 	"time"
 )
 
+const (
+	StatusProcessing = "processing"
+	StatusSuccess    = "success"
+	StatusFailed     = "failed"
+	StatusRefunded   = "refunded"
+)
+
+// Заголовки подписи вебхука: время отправки в unix-секундах
+// и HMAC-SHA256 от "время.путь?запрос.тело" на общем с получателем секрете
+const (
+	HeaderWebHookTimestamp = "X-Payment-Timestamp"
+	HeaderWebHookSignature = "X-Payment-Signature"
+)
+
+var (
+	ErrPaymentNotFound = errors.New("payment not found")
+	ErrInvalidRefund   = errors.New("invalid refund amount")
+)
+
 type PaymentService struct {
-	client *http.Client
+	client        *http.Client
+	webHookSecret []byte // Секрет подписи вебхуков, тот же задан у получателя
+
+	mu       sync.Mutex
+	payments map[string]*models.Payment // Платежи по OrderID
+	refunds  map[string]struct{}        // Исполненные возвраты по RefundID
 }
 
-func NewPaymentService() *PaymentService {
-	return &PaymentService{client: &http.Client{}}
+func NewPaymentService(webHookSecret string) *PaymentService {
+	return &PaymentService{
+		client:        &http.Client{},
+		webHookSecret: []byte(webHookSecret),
+		payments:      make(map[string]*models.Payment),
+		refunds:       make(map[string]struct{}),
+	}
 }
 
-func (p *PaymentService) sendWebHook(ctx context.Context, url string, paymentResponse *models.PaymentResponse) error {
+// signWebHook подписывает вебхук вместе с адресом: заказ получатель берёт из query-параметров,
+// поэтому подмена адреса ломает подпись так же, как подмена тела
+func (p *PaymentService) signWebHook(timestamp, requestURI string, body []byte) string {
+	mac := hmac.New(sha256.New, p.webHookSecret)
+	mac.Write([]byte(timestamp + "." + requestURI + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *PaymentService) sendWebHook(ctx context.Context, webHookURL string, paymentResponse *models.PaymentResponse) error {
 	data, err := json.Marshal(paymentResponse)
 	if err != nil {
 		return fmt.Errorf("failed to marshal paymentResponse: %w", err)
 	}
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(data))
+	parsedURL, err := url.Parse(webHookURL)
+	if err != nil {
+		return fmt.Errorf("invalid web hook url: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", webHookURL, bytes.NewBuffer(data))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebHookTimestamp, timestamp)
+	req.Header.Set(HeaderWebHookSignature, p.signWebHook(timestamp, parsedURL.RequestURI(), data))
 
 	resp, err := p.client.Do(req)
-	log.Println(string(data), "ADASDASDKLASFKOL:ASJFOLIKASFJHIKOPASFJKLASJKO")
 	if err != nil {
 		return fmt.Errorf("failed to send request: %w", err)
 	}
@@ -40,22 +91,25 @@ func (p *PaymentService) sendWebHook(ctx context.Context, url string, paymentRes
 }
 
 func (p *PaymentService) ProcessPayment(ctx context.Context, req *models.PaymentRequest) error {
+	p.setPaymentStatus(req, StatusProcessing)
 	time.Sleep(15 * time.Second) // Имитация обратки платежа, связь с банком и т.д.
 	paymentResponse := &models.PaymentResponse{
-		Status: "success",
+		Status: StatusSuccess,
 
 		MetaData: req.MetaData,
 	}
 
 	select {
 	case <-ctx.Done():
-		paymentResponse.Status = "failed"
+		paymentResponse.Status = StatusFailed
+		p.setPaymentStatus(req, StatusFailed)
 		сtx := context.Background()
 		err := p.sendWebHook(сtx, req.WebHookURL, paymentResponse)
 		if err != nil {
 			return fmt.Errorf("payment process myerror: %w", err)
 		}
 	default:
+		p.setPaymentStatus(req, StatusSuccess)
 		err := p.sendWebHook(ctx, req.WebHookURL, paymentResponse)
 		if err != nil {
 			return fmt.Errorf("payment process myerror: %w", err)
@@ -63,3 +117,72 @@ func (p *PaymentService) ProcessPayment(ctx context.Context, req *models.Payment
 	}
 	return nil
 }
+
+// setPaymentStatus запоминает исход попытки оплаты, успешная попытка добавляет сумму к заказу
+func (p *PaymentService) setPaymentStatus(req *models.PaymentRequest, status string) {
+	if req.OrderID == "" {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// По одному заказу возможны доплаты
+	payment, ok := p.payments[req.OrderID]
+	if !ok {
+		payment = &models.Payment{OrderID: req.OrderID}
+		p.payments[req.OrderID] = payment
+	}
+	if status == StatusSuccess {
+		payment.Amount += req.Amount
+	}
+	payment.Status = status
+	payment.UpdatedAt = time.Now().UTC()
+}
+
+// GetPayment возвращает платёж по заказу, чтобы получатель мог сверить статус без вебхука
+func (p *PaymentService) GetPayment(ctx context.Context, orderID string) (*models.Payment, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	payment, ok := p.payments[orderID]
+	if !ok {
+		return nil, fmt.Errorf("payment order %s: %w", orderID, ErrPaymentNotFound)
+	}
+	result := *payment
+	return &result, nil
+}
+
+// Refund возвращает средства по заказу, нулевая сумма - возврат всего остатка.
+// Повторный возврат уже возвращённого заказа или с уже исполненным RefundID не является ошибкой.
+func (p *PaymentService) Refund(ctx context.Context, req *models.RefundRequest) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, done := p.refunds[req.RefundID]; done {
+		return nil
+	}
+
+	payment, ok := p.payments[req.OrderID]
+	if !ok || payment.Amount == 0 {
+		return fmt.Errorf("refund order %s: %w", req.OrderID, ErrPaymentNotFound)
+	}
+	if payment.Status == StatusRefunded {
+		return nil
+	}
+	amount := req.Amount
+	if amount == 0 {
+		amount = payment.Amount - payment.Refunded
+	}
+	if amount < 0 || amount > payment.Amount-payment.Refunded {
+		return fmt.Errorf("refund order %s: %w", req.OrderID, ErrInvalidRefund)
+	}
+	payment.Refunded += amount
+	if payment.Refunded == payment.Amount {
+		payment.Status = StatusRefunded
+	}
+	if req.RefundID != "" {
+		p.refunds[req.RefundID] = struct{}{}
+	}
+	payment.UpdatedAt = time.Now().UTC()
+	log.Printf("refunded %d for order %s", amount, req.OrderID)
+	return nil
+}